@@ -1,21 +1,32 @@
 package main
 
 import (
-	_ "avito_shop/docs"
+	grpcapp "avito_shop/internal/app/grpc"
 	httpapp "avito_shop/internal/app/http"
+	schedulerapp "avito_shop/internal/app/scheduler"
 	"avito_shop/internal/config"
+	"avito_shop/internal/repository/metrics"
 	"avito_shop/internal/repository/postgres"
 	"avito_shop/internal/usecases/service"
 	pkgconfig "avito_shop/pkg/config"
+	"avito_shop/pkg/health"
+	"avito_shop/pkg/idempotency"
 	"avito_shop/pkg/infra"
 	pkgredis "avito_shop/pkg/infra/cache/redis"
+	"avito_shop/pkg/infra/events"
+	"avito_shop/pkg/infra/mail"
 	pkglog "avito_shop/pkg/log"
+	"avito_shop/pkg/ratelimit"
 	"avito_shop/pkg/shutdown"
+	"avito_shop/pkg/tracing"
 	"context"
 	"errors"
 	"log/slog"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -28,6 +39,7 @@ const (
 //	@version					1.0.0
 //	@schemes					http
 //	@host						localhost:8080
+//	@BasePath					/api
 //	@securityDefinitions.apikey	BearerAuth
 //	@in							header
 //	@name						Authorization
@@ -40,28 +52,77 @@ func main() {
 	defer func() { _ = file.Close() }()
 	slog.SetDefault(log)
 	log.Info("Starting Avito Shop", slog.Any("config", cfg.Redact()))
+	defer setupTracing(log, cfg.Tracing)()
 
-	dbPool, err := infra.NewPostgresPool(cfg.PG)
-	if err != nil {
-		pkglog.Fatal(log, "error while setting new postgres connection: ", err)
-	}
+	dbPool, redisClient := mustConnect(log, cfg)
 	defer dbPool.Close()
+	defer pkgredis.ShutdownClient(redisClient)
 
-	redisClient, err := pkgredis.NewRedisClient(cfg.Redis)
+	userCache := pkgredis.NewRedisService(redisClient, log)
+
+	sink, sinkCloser, err := events.NewSink(cfg.Outbox.Sink)
 	if err != nil {
-		pkglog.Fatal(log, "error while setting new redis connection: ", err)
+		pkglog.Fatal(log, "error while setting up outbox sink: ", err)
 	}
-	defer pkgredis.ShutdownClient(redisClient)
+	defer func() { _ = sinkCloser.Close() }()
 
-	userCache := pkgredis.NewRedisService(redisClient, log)
+	// relayed events also go to every replica for the event streams of connected users
+	broker := events.NewRedisBroker(redisClient, cfg.Stream.Channel)
+	sink = events.NewFanoutSink(sink, broker)
 
-	txRepo := postgres.NewTransactionRepository(dbPool)
+	limiter, idempotencyStore := mustRequestStores(log, cfg.HTTPServer, redisClient)
+
+	uow := postgres.NewUnitOfWork(dbPool)
+	txRepo := metrics.NewTransactionRepository(postgres.NewTransactionRepository(dbPool))
 	userRepo := postgres.NewUserRepository(dbPool, userCache, cfg.Redis.TTL, cfg.Redis.WriteTimeout)
 	merchRepo := postgres.NewMerchRepository(dbPool)
+	coinRequestRepo := postgres.NewCoinRequestRepository(dbPool)
+	scheduledTransferRepo := postgres.NewScheduledTransferRepository(dbPool)
+	ledgerRepo := postgres.NewLedgerRepository(dbPool)
+	reconRepo := postgres.NewReconciliationRepository(dbPool)
+	outboxRepo := postgres.NewOutboxRepository(dbPool)
+	webhookRepo := postgres.NewWebhookRepository(dbPool)
+	notificationRepo := postgres.NewNotificationRepository(dbPool)
+	emailRepo := postgres.NewEmailRepository(dbPool)
+	historyRepo := postgres.NewHistoryRepository(dbPool)
 
 	authService := service.NewAuth(userRepo, cfg.AuthSecret)
 	userService := service.NewUser(userRepo)
-	txService := service.NewTransaction(txRepo, userRepo, merchRepo)
+	txService := service.NewTransaction(uow, txRepo, userRepo, merchRepo)
+	coinRequestService := service.NewCoinRequest(uow, coinRequestRepo, txRepo, userRepo, cfg.CoinRequestTTL)
+	treasuryService := service.NewTreasury(txRepo, userRepo, cfg.Allowance.Amount, cfg.CoinExpiry.BatchSize)
+	ledgerService := service.NewLedger(ledgerRepo)
+	reconService := service.NewReconciliation(reconRepo)
+	outboxService := service.NewOutbox(outboxRepo, sink, cfg.Outbox.BatchSize, cfg.Outbox.Lease, cfg.Outbox.MaxBackoff)
+	webhookService := service.NewWebhook(
+		webhookRepo,
+		events.NewWebhookClient(cfg.Webhook.Timeout),
+		cfg.Webhook.BatchSize,
+		cfg.Webhook.Lease,
+		cfg.Webhook.MaxAttempts,
+		cfg.Webhook.MaxBackoff,
+	)
+	eventStreamService := service.NewEventStream(log, broker, cfg.Stream.BufferSize)
+	notificationService := service.NewNotification(notificationRepo)
+	emailService := service.NewEmail(
+		emailRepo,
+		mail.NewSMTPSender(cfg.Email.SMTP),
+		cfg.Email.Domain,
+		cfg.Email.BatchSize,
+		cfg.Email.Lease,
+		cfg.Email.MaxAttempts,
+		cfg.Email.MaxBackoff,
+	)
+	merchService := service.NewMerch(merchRepo)
+	historyService := service.NewHistory(historyRepo)
+	scheduledTransferService := service.NewScheduledTransfer(
+		uow,
+		scheduledTransferRepo,
+		txRepo,
+		userRepo,
+		cfg.Scheduler.BatchSize,
+		cfg.Scheduler.Lease,
+	)
 
 	httpApp := httpapp.New(
 		log,
@@ -69,31 +130,145 @@ func main() {
 		authService,
 		userService,
 		txService,
+		coinRequestService,
+		scheduledTransferService,
+		treasuryService,
+		ledgerService,
+		reconService,
+		webhookService,
+		eventStreamService,
+		notificationService,
+		emailService,
+		merchService,
+		historyService,
+		newHealthChecker(cfg, dbPool, redisClient),
+		limiter,
+		idempotencyStore,
 		cfg.HTTPServer,
 	)
 
+	grpcApp := grpcapp.New(log, authService, userService, txService, cfg.GRPCServer)
+
+	schedulerApp := schedulerapp.New(
+		log,
+		schedulerapp.ScheduledTransfersJob(scheduledTransferService, cfg.Scheduler.PollInterval),
+		schedulerapp.AllowanceJob(treasuryService, cfg.Allowance.CheckInterval),
+		schedulerapp.CoinExpiryJob(treasuryService, cfg.CoinExpiry.CheckInterval),
+		schedulerapp.ReconciliationJob(reconService, cfg.Reconciliation.Interval),
+		schedulerapp.OutboxRelayJob(outboxService, cfg.Outbox.PollInterval),
+		schedulerapp.WebhookDeliveryJob(webhookService, cfg.Webhook.PollInterval),
+		schedulerapp.EmailJob(emailService, cfg.Email.PollInterval),
+	)
+
+	err = run(log, []server{httpApp, grpcApp}, schedulerApp.Run, eventStreamService.Listen)
+	if err != nil && !errors.Is(err, shutdown.ErrOSSignal) {
+		log.Error("Exit reason", slog.String("error", err.Error()))
+	}
+}
+
+// mustConnect opens the Postgres pool and the Redis client, the pool reports its stats to the metrics.
+func mustConnect(log *slog.Logger, cfg config.Config) (*pgxpool.Pool, *redis.Client) {
+	dbPool, err := infra.NewPostgresPool(cfg.PG)
+	if err != nil {
+		pkglog.Fatal(log, "error while setting new postgres connection: ", err)
+	}
+	prometheus.MustRegister(infra.NewPoolCollector(dbPool))
+
+	redisClient, err := pkgredis.NewRedisClient(cfg.Redis)
+	if err != nil {
+		pkglog.Fatal(log, "error while setting new redis connection: ", err)
+	}
+
+	return dbPool, redisClient
+}
+
+// mustRequestStores sets up where the rate limits and the idempotency keys are kept.
+func mustRequestStores(
+	log *slog.Logger,
+	cfg config.HTTPConfig,
+	redisClient *redis.Client,
+) (ratelimit.Limiter, idempotency.Store) {
+	limiter, err := ratelimit.NewLimiter(cfg.RateLimit, redisClient)
+	if err != nil {
+		pkglog.Fatal(log, "error while setting up rate limiter: ", err)
+	}
+
+	idempotencyStore, err := idempotency.NewStore(cfg.Idempotency, redisClient)
+	if err != nil {
+		pkglog.Fatal(log, "error while setting up idempotency store: ", err)
+	}
+
+	return limiter, idempotencyStore
+}
+
+// newHealthChecker makes the instance ready only while both storages answer.
+func newHealthChecker(cfg config.Config, dbPool *pgxpool.Pool, redisClient *redis.Client) *health.Checker {
+	return health.NewChecker(cfg.HTTPServer.ReadinessTimeout, map[string]health.Check{
+		"postgres": dbPool.Ping,
+		"redis":    func(ctx context.Context) error { return redisClient.Ping(ctx).Err() },
+	})
+}
+
+// setupTracing installs the tracer provider and returns the func flushing the spans left on exit.
+func setupTracing(log *slog.Logger, cfg tracing.Config) func() {
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg)
+	if err != nil {
+		pkglog.Fatal(log, "error while setting up tracing: ", err)
+	}
+
+	return func() {
+		const ctxTimeExceed = 5 * time.Second
+
+		ctx, cancel := context.WithTimeout(context.Background(), ctxTimeExceed)
+		defer cancel()
+		_ = shutdownTracing(ctx)
+	}
+}
+
+type server interface {
+	Run() error
+	Stop(ctx context.Context) error
+}
+
+// run starts the servers and the background workers and blocks until one of them fails
+// or a shutdown signal arrives, then stops the servers. Workers must return once ctx is done.
+func run(log *slog.Logger, servers []server, workers ...func(ctx context.Context) error) error {
 	g, ctx := errgroup.WithContext(context.Background())
 	g.Go(func() error {
 		return shutdown.ListenSignal(ctx, log)
 	})
 
-	g.Go(func() error {
-		return httpApp.Run()
-	})
+	for _, srv := range servers {
+		g.Go(func() error {
+			return srv.Run()
+		})
+	}
+
+	for _, worker := range workers {
+		g.Go(func() error {
+			return worker(ctx)
+		})
+	}
 
 	g.Go(func() error {
 		<-ctx.Done()
-		log.InfoContext(ctx, "Shutdown signal received, stopping server")
+		log.InfoContext(ctx, "Shutdown signal received, stopping servers")
 
 		const ctxTimeExceed = 5 * time.Second
 
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), ctxTimeExceed)
 		defer cancel()
-		return httpApp.Stop(shutdownCtx)
+
+		// the servers share the timeout, so they drain their connections at the same time
+		var stop errgroup.Group
+		for _, srv := range servers {
+			stop.Go(func() error {
+				return srv.Stop(shutdownCtx)
+			})
+		}
+
+		return stop.Wait()
 	})
 
-	err = g.Wait()
-	if err != nil && !errors.Is(err, shutdown.ErrOSSignal) {
-		log.Error("Exit reason", slog.String("error", err.Error()))
-	}
+	return g.Wait()
 }
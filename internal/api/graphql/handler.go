@@ -0,0 +1,187 @@
+package graphql
+
+import (
+	"avito_shop/internal/config"
+	"avito_shop/internal/domain"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/http/handlers"
+	pkglog "avito_shop/pkg/log"
+	"context"
+	_ "embed"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	gql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// loaderMaxBatch keeps the ids of a batch well within a single query.
+const loaderMaxBatch = 500
+
+type Handler struct {
+	logger       *slog.Logger
+	schema       *gql.Schema
+	limits       queryLimits
+	batchWait    time.Duration
+	userService  usecases.User
+	merchService usecases.Merch
+}
+
+func NewHandler(
+	logger *slog.Logger,
+	userService usecases.User,
+	txService usecases.Transaction,
+	merchService usecases.Merch,
+	historyService usecases.History,
+	cfg config.GraphQLConfig,
+) *Handler {
+	root := &rootResolver{
+		logger:         logger,
+		userService:    userService,
+		txService:      txService,
+		merchService:   merchService,
+		historyService: historyService,
+	}
+
+	// the executor enforces the depth too, in case a query gets past the limits
+	opts := []gql.SchemaOpt{gql.UseStringDescriptions(), gql.MaxDepth(cfg.MaxDepth)}
+	if cfg.MaxParallelism > 0 {
+		opts = append(opts, gql.MaxParallelism(cfg.MaxParallelism))
+	}
+	schema := gql.MustParseSchema(schemaSDL, root, opts...)
+
+	return &Handler{
+		logger: logger,
+		schema: schema,
+		limits: queryLimits{
+			schema:        schema.ASTSchema(),
+			maxDepth:      cfg.MaxDepth,
+			maxComplexity: cfg.MaxComplexity,
+		},
+		batchWait:    cfg.BatchWait,
+		userService:  userService,
+		merchService: merchService,
+	}
+}
+
+const postGraphQLPath = "/graphql"
+
+func (h *Handler) WithSecuredGraphQLHandlers(authService usecases.Auth) handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(libmiddleware.WithTokenAuth(authService))
+			r.Post(postGraphQLPath, h.postGraphQL)
+		})
+	}
+}
+
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// @Summary		GraphQL-запрос к данным пользователя и каталогу
+// @Description	Схема: me (монеты, инвентарь, транзакции, заказы), merch, мутации sendCoin и buy.
+// @Description	Запросы глубже или сложнее установленных лимитов отклоняются до выполнения.
+// @Security		BearerAuth
+// @Accept			json
+// @Produce		json
+// @Param			body	body	graphql.Request	true	"GraphQL-запрос"
+// @Success		200		"Ответ GraphQL с полями data и errors"
+// @Failure		400		"Неверный запрос"
+// @Failure		401		{object}	responses.ErrorResponse	"Неавторизован"
+// @Router			/graphql [post]
+func (h *Handler) postGraphQL(w http.ResponseWriter, r *http.Request) {
+	const op = "GraphQLHandler.postGraphQL"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		handlers.WriteResponse(w, r, domain.HandleResult(err, nil))
+		return
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	var req Request
+	if err = handlers.DecodeRequest(r, &req); err != nil || req.Query == "" {
+		log.Warn("error while forming request", pkglog.Err(err))
+		writeGraphQLResponse(w, r, http.StatusBadRequest, &gql.Response{
+			Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("request must be JSON with a query")},
+		})
+		return
+	}
+
+	if limitErr := h.limits.check(req.Query, req.OperationName, req.Variables); limitErr != nil {
+		log.Warn("query rejected", slog.String("reason", limitErr.Message))
+		writeGraphQLResponse(w, r, http.StatusOK, &gql.Response{Errors: []*gqlerrors.QueryError{limitErr}})
+		return
+	}
+
+	ctx := withLoaders(r.Context(), h.newLoaders())
+
+	res := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	writeGraphQLResponse(w, r, http.StatusOK, res)
+}
+
+func writeGraphQLResponse(w http.ResponseWriter, r *http.Request, status int, res *gql.Response) {
+	render.Status(r, status)
+	render.JSON(w, r, res)
+}
+
+type loaders struct {
+	users *loader[domain.UserID, domain.UserName]
+	merch *loader[domain.MerchName, domain.Merch]
+}
+
+func (h *Handler) newLoaders() *loaders {
+	return &loaders{
+		users: newLoader(h.batchWait, loaderMaxBatch, h.userService.GetNamesByIDs),
+		merch: newLoader(h.batchWait, loaderMaxBatch,
+			func(ctx context.Context, names []domain.MerchName) (map[domain.MerchName]domain.Merch, error) {
+				items, err := h.merchService.GetByNames(ctx, names)
+				if err != nil {
+					return nil, err
+				}
+
+				byName := make(map[domain.MerchName]domain.Merch, len(items))
+				for _, item := range items {
+					byName[item.Name] = item
+				}
+
+				return byName, nil
+			}),
+	}
+}
+
+type loadersCtxKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersCtxKey{}, l)
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersCtxKey{}).(*loaders)
+	return l
+}
+
+func userIDFromContext(ctx context.Context) (domain.UserID, error) {
+	id, ok := ctx.Value(libmiddleware.AuthContextKey).(domain.UserID)
+	if !ok {
+		return 0, libmiddleware.ErrContextParsing
+	}
+
+	return id, nil
+}
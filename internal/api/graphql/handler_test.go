@@ -0,0 +1,196 @@
+package graphql
+
+import (
+	"avito_shop/internal/config"
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/testutils"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testServices struct {
+	users   *mocks.User
+	tx      *mocks.Transaction
+	merch   *mocks.Merch
+	history *mocks.History
+}
+
+func newTestHandler(t *testing.T) (*Handler, testServices) {
+	svc := testServices{
+		users:   mocks.NewUser(t),
+		tx:      mocks.NewTransaction(t),
+		merch:   mocks.NewMerch(t),
+		history: mocks.NewHistory(t),
+	}
+
+	h := NewHandler(testutils.NewDummyLogger(), svc.users, svc.tx, svc.merch, svc.history, config.GraphQLConfig{
+		MaxDepth:      5,
+		MaxComplexity: 200,
+		BatchWait:     5 * time.Millisecond,
+	})
+
+	return h, svc
+}
+
+type testResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func doGraphQL(t *testing.T, h *Handler, req any) (int, testResponse) {
+	t.Helper()
+
+	httpReq := testutils.AddUserIDToRequestContext(testutils.NewMockJSONRequest(t, req), 1)
+	w := httptest.NewRecorder()
+
+	h.postGraphQL(w, httpReq)
+
+	var res testResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+	return w.Code, res
+}
+
+func TestPostGraphQL_BatchesLookups(t *testing.T) {
+	t.Parallel()
+
+	h, svc := newTestHandler(t)
+
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	txs := []domain.TransactionRecord{
+		{Transaction: domain.Transaction{ID: 3, From: 1, To: 2, Amount: 10}, CreatedAt: created},
+		{Transaction: domain.Transaction{ID: 2, From: 3, To: 1, Amount: 20}, CreatedAt: created},
+		{Transaction: domain.Transaction{ID: 1, From: 0, To: 1, Amount: 5}, Reason: "refund", CreatedAt: created},
+	}
+	orders := []domain.Order{
+		{ID: 7, Item: "cup", Price: 20, CreatedAt: created},
+		{ID: 6, Item: "pen", Price: 10, CreatedAt: created},
+	}
+	info := domain.UserInfo{Coins: 900, Inventory: []domain.Inventory{{Name: "cup", Quantity: 1}}}
+
+	svc.users.On("GetInfoByID", mock.Anything, 1).Return(info, nil).Once()
+	svc.history.On("Transactions", mock.Anything, 1, 3).Return(txs, nil).Once()
+	svc.history.On("Orders", mock.Anything, 1, 20).Return(orders, nil).Once()
+	// every user and every item is looked up in one call no matter how often it's referenced
+	svc.users.On("GetNamesByIDs", mock.Anything, mock.MatchedBy(func(ids []int) bool {
+		return len(ids) == 3
+	})).Return(map[int]string{1: "me", 2: "bob", 3: "alice"}, nil).Once()
+	svc.merch.On("GetByNames", mock.Anything, mock.MatchedBy(func(names []string) bool {
+		return len(names) == 2
+	})).Return([]domain.Merch{{Name: "cup", Price: 25}, {Name: "pen", Price: 10}}, nil).Once()
+
+	code, res := doGraphQL(t, h, Request{Query: `{
+		me {
+			name
+			coins
+			inventory { quantity merch { name price } }
+			transactions(limit: 3) { id direction amount reason createdAt from { name } to { name } }
+			orders { price item { name price } }
+		}
+	}`})
+
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, res.Errors)
+
+	me, ok := res.Data["me"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "me", me["name"])
+	require.InDelta(t, 900, me["coins"], 0)
+	require.Equal(t, []any{
+		map[string]any{"quantity": float64(1), "merch": map[string]any{"name": "cup", "price": float64(25)}},
+	}, me["inventory"])
+	require.Equal(t, []any{
+		map[string]any{
+			"id": float64(3), "direction": "SENT", "amount": float64(10), "reason": nil,
+			"createdAt": "2025-01-02T03:04:05Z",
+			"from":      map[string]any{"name": "me"}, "to": map[string]any{"name": "bob"},
+		},
+		map[string]any{
+			"id": float64(2), "direction": "RECEIVED", "amount": float64(20), "reason": nil,
+			"createdAt": "2025-01-02T03:04:05Z",
+			"from":      map[string]any{"name": "alice"}, "to": map[string]any{"name": "me"},
+		},
+		map[string]any{
+			"id": float64(1), "direction": "RECEIVED", "amount": float64(5), "reason": "refund",
+			"createdAt": "2025-01-02T03:04:05Z",
+			"from":      nil, "to": map[string]any{"name": "me"},
+		},
+	}, me["transactions"])
+	require.Equal(t, []any{
+		map[string]any{"price": float64(20), "item": map[string]any{"name": "cup", "price": float64(25)}},
+		map[string]any{"price": float64(10), "item": map[string]any{"name": "pen", "price": float64(10)}},
+	}, me["orders"])
+}
+
+func TestPostGraphQL_Mutations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Err     error
+		ExpCode string
+		ExpMsg  string
+	}{
+		{"Success", nil, "", ""},
+		{"Low Balance", domain.ErrLowBalance, "BAD_REQUEST", domain.ErrLowBalance.Error()},
+		{"Unexpected DBError", errors.New("unexpected DBError"), "INTERNAL", "internal error"},
+	}
+
+	for _, test := range tests {
+		h, svc := newTestHandler(t)
+
+		svc.tx.On("SendCoinByName", mock.Anything, domain.Transaction{From: 1, Amount: 10}, "bob").Return(test.Err)
+		if test.Err == nil {
+			svc.users.On("GetInfoByID", mock.Anything, 1).Return(domain.UserInfo{Coins: 990}, nil)
+		}
+
+		code, res := doGraphQL(t, h, Request{
+			Query:     `mutation($to: String!) { sendCoin(toUser: $to, amount: 10) { coins } }`,
+			Variables: map[string]any{"to": "bob"},
+		})
+
+		require.Equal(t, http.StatusOK, code, test.Name)
+
+		if test.Err == nil {
+			require.Empty(t, res.Errors, test.Name)
+			require.Equal(t, map[string]any{"sendCoin": map[string]any{"coins": float64(990)}}, res.Data, test.Name)
+			continue
+		}
+
+		require.Len(t, res.Errors, 1, test.Name)
+		require.Equal(t, test.ExpMsg, res.Errors[0].Message, test.Name)
+		require.Equal(t, test.ExpCode, res.Errors[0].Extensions["code"], test.Name)
+	}
+}
+
+func TestPostGraphQL_Rejected(t *testing.T) {
+	t.Parallel()
+
+	h, _ := newTestHandler(t)
+
+	code, res := doGraphQL(t, h, []byte(`{"query":`))
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Len(t, res.Errors, 1)
+
+	// no resolver runs for a query over the limits, the mocks would fail otherwise
+	code, res = doGraphQL(t, h, Request{Query: `{ me { transactions(limit: 100) { from { name } } } }`})
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, res.Errors, 1)
+	require.Contains(t, res.Errors[0].Message, "complexity")
+	require.Nil(t, res.Data)
+
+	code, res = doGraphQL(t, h, Request{Query: `{ me { unknown } }`})
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, res.Errors, 1)
+}
@@ -0,0 +1,195 @@
+package graphql
+
+import (
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/types"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+const (
+	// listSizeArg caps the length of a list field, fields without it are assumed to return assumedListSize items.
+	listSizeArg     = "limit"
+	assumedListSize = 20
+)
+
+// introspectionTypes holds the types of the introspection fields every query type has.
+var introspectionTypes = map[string]string{
+	"__schema": "__Schema",
+	"__type":   "__Type",
+}
+
+// queryLimits rejects queries that are too deep or too expensive before any resolver runs.
+// Introspection fields count like any other field.
+type queryLimits struct {
+	schema        *types.Schema
+	maxDepth      int
+	maxComplexity int
+}
+
+type queryCost struct {
+	depth      int
+	complexity int
+}
+
+// check rejects queries it can't make sense of, a query whose cost is unknown never reaches the executor.
+func (l queryLimits) check(query, operationName string, variables map[string]any) *gqlerrors.QueryError {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return gqlerrors.Errorf("invalid query: %v", err)
+	}
+
+	op := selectOperation(doc, operationName)
+	if op == nil {
+		return gqlerrors.Errorf("operation %q not found", operationName)
+	}
+
+	entry, ok := l.schema.EntryPoints[string(op.Operation)]
+	if !ok {
+		return gqlerrors.Errorf("%s operations are not supported", op.Operation)
+	}
+
+	w := costWalker{
+		doc:       doc,
+		schema:    l.schema,
+		variables: variables,
+		visited:   make(map[string]bool),
+	}
+	cost := w.selectionSet(op.SelectionSet, entry.TypeName())
+
+	if l.maxDepth > 0 && cost.depth > l.maxDepth {
+		return gqlerrors.Errorf("query depth %d exceeds the limit of %d", cost.depth, l.maxDepth)
+	}
+
+	if l.maxComplexity > 0 && cost.complexity > l.maxComplexity {
+		return gqlerrors.Errorf("query complexity %d exceeds the limit of %d", cost.complexity, l.maxComplexity)
+	}
+
+	return nil
+}
+
+func selectOperation(doc *ast.QueryDocument, name string) *ast.OperationDefinition {
+	if name == "" {
+		if len(doc.Operations) != 1 {
+			return nil
+		}
+		return doc.Operations[0]
+	}
+
+	return doc.Operations.ForName(name)
+}
+
+type costWalker struct {
+	doc       *ast.QueryDocument
+	schema    *types.Schema
+	variables map[string]any
+	// visited holds the fragments on the current path, validation rejects cycles later on
+	visited map[string]bool
+}
+
+func (w costWalker) selectionSet(set ast.SelectionSet, typeName string) queryCost {
+	var total queryCost
+
+	for _, sel := range set {
+		var cost queryCost
+
+		switch sel := sel.(type) {
+		case *ast.Field:
+			cost = w.field(sel, typeName)
+		case *ast.InlineFragment:
+			cond := typeName
+			if sel.TypeCondition != "" {
+				cond = sel.TypeCondition
+			}
+			cost = w.selectionSet(sel.SelectionSet, cond)
+		case *ast.FragmentSpread:
+			frag := w.doc.Fragments.ForName(sel.Name)
+			if frag == nil || w.visited[sel.Name] {
+				continue
+			}
+			w.visited[sel.Name] = true
+			cost = w.selectionSet(frag.SelectionSet, frag.TypeCondition)
+			delete(w.visited, sel.Name)
+		}
+
+		total.complexity += cost.complexity
+		total.depth = max(total.depth, cost.depth)
+	}
+
+	return total
+}
+
+func (w costWalker) field(field *ast.Field, typeName string) queryCost {
+	def := w.fieldDefinition(typeName, field.Name)
+	if def == nil {
+		// __schema and __type aren't fields of the query type, other unknown fields fail validation later on
+		children := w.selectionSet(field.SelectionSet, introspectionTypes[field.Name])
+		return queryCost{complexity: 1 + children.complexity, depth: 1 + children.depth}
+	}
+
+	elem, isList := unwrapType(def.Type)
+	children := w.selectionSet(field.SelectionSet, elem)
+
+	size := 1
+	if isList {
+		size = w.listSize(field)
+	}
+
+	return queryCost{
+		complexity: 1 + size*children.complexity,
+		depth:      1 + children.depth,
+	}
+}
+
+func (w costWalker) fieldDefinition(typeName, fieldName string) *types.FieldDefinition {
+	switch t := w.schema.Types[typeName].(type) {
+	case *types.ObjectTypeDefinition:
+		return t.Fields.Get(fieldName)
+	case *types.InterfaceTypeDefinition:
+		return t.Fields.Get(fieldName)
+	default:
+		return nil
+	}
+}
+
+func (w costWalker) listSize(field *ast.Field) int {
+	arg := field.Arguments.ForName(listSizeArg)
+	if arg == nil {
+		return assumedListSize
+	}
+
+	val, err := arg.Value.Value(w.variables)
+	if err != nil {
+		return assumedListSize
+	}
+
+	switch n := val.(type) {
+	case int64:
+		return int(max(n, 0))
+	case float64:
+		// JSON numbers in the variables
+		return int(max(n, 0))
+	default:
+		// a missing or mistyped value, validation reports the latter
+		return assumedListSize
+	}
+}
+
+// unwrapType returns the name of the named type behind the non-null and list wrappers.
+func unwrapType(t types.Type) (string, bool) {
+	isList := false
+
+	for {
+		switch wrapped := t.(type) {
+		case *types.NonNull:
+			t = wrapped.OfType
+		case *types.List:
+			isList = true
+			t = wrapped.OfType
+		case types.NamedType:
+			return wrapped.TypeName(), isList
+		default:
+			return "", isList
+		}
+	}
+}
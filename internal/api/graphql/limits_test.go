@@ -0,0 +1,100 @@
+package graphql
+
+import (
+	"testing"
+
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func newTestLimits(maxDepth, maxComplexity int) queryLimits {
+	schema := gql.MustParseSchema(schemaSDL, nil)
+
+	return queryLimits{
+		schema:        schema.ASTSchema(),
+		maxDepth:      maxDepth,
+		maxComplexity: maxComplexity,
+	}
+}
+
+func TestQueryLimits_Cost(t *testing.T) {
+	t.Parallel()
+
+	l := newTestLimits(0, 0)
+
+	tests := []struct {
+		Name          string
+		Query         string
+		Variables     map[string]any
+		ExpDepth      int
+		ExpComplexity int
+	}{
+		{"Scalars", `{ me { id coins } }`, nil, 2, 3},
+		{"Limited list", `{ me { transactions(limit: 5) { id amount } } }`, nil, 3, 1 + 1 + 5*2},
+		{"Default list size", `{ me { orders { id } } }`, nil, 3, 1 + 1 + assumedListSize},
+		{"Limit from variables", `query($n: Int) { me { transactions(limit: $n) { id } } }`,
+			map[string]any{"n": float64(3)}, 3, 1 + 1 + 3},
+		{"Nested lists", `{ me { transactions(limit: 2) { from { name } } } }`, nil, 4, 1 + 1 + 2*(1+1)},
+		{"Fragments", `{ me { ...f } } fragment f on Me { inventory { merch { name } } }`, nil, 4,
+			1 + 1 + assumedListSize*(1+1)},
+		{"Inline fragments", `{ me { ... on Me { id } } }`, nil, 2, 2},
+		{"Introspection", `{ __schema { types { name fields { name } } } }`, nil, 4,
+			1 + 1 + assumedListSize*(1+1+assumedListSize)},
+		{"Type name", `{ me { __typename } }`, nil, 2, 2},
+	}
+
+	for _, test := range tests {
+		doc := parseTestQuery(t, test.Query)
+		w := costWalker{doc: doc, schema: l.schema, variables: test.Variables, visited: map[string]bool{}}
+
+		cost := w.selectionSet(doc.Operations[0].SelectionSet, "Query")
+
+		require.Equal(t, test.ExpDepth, cost.depth, test.Name)
+		require.Equal(t, test.ExpComplexity, cost.complexity, test.Name)
+	}
+}
+
+func TestQueryLimits_Check(t *testing.T) {
+	t.Parallel()
+
+	l := newTestLimits(3, 50)
+
+	require.Nil(t, l.check(`{ me { transactions(limit: 10) { id } } }`, "", nil))
+
+	err := l.check(`{ me { transactions(limit: 10) { from { name } } } }`, "", nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "depth 4")
+
+	err = l.check(`{ me { transactions(limit: 100) { id } } }`, "", nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "complexity 102")
+
+	// the operation to run is the one that counts
+	query := `query Small { me { id } } query Big { me { transactions(limit: 100) { id } } }`
+	require.Nil(t, l.check(query, "Small", nil))
+	require.NotNil(t, l.check(query, "Big", nil))
+
+	// introspection doesn't get around the limits
+	err = l.check(`{ __type(name: "Me") { fields { type { fields { name } } } } }`, "", nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Message, "depth 5")
+
+	// queries that can't be costed are rejected
+	require.NotNil(t, l.check(`{ me {`, "", nil))
+	require.NotNil(t, l.check(query, "Missing", nil))
+	require.NotNil(t, l.check(query, "", nil))
+
+	// cyclic fragments don't hang the walker
+	require.Nil(t, l.check(`{ me { ...a } } fragment a on Me { ...b } fragment b on Me { ...a }`, "", nil))
+}
+
+func parseTestQuery(t *testing.T, query string) *ast.QueryDocument {
+	t.Helper()
+
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	require.NoError(t, err)
+
+	return doc
+}
@@ -0,0 +1,105 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// loader batches the keys requested by concurrently running resolvers into one fetch, so a list
+// of transactions asks Postgres for the names of all counterparts at once instead of one by one.
+// A loader lives for a single request and keeps what it fetched for the rest of it.
+type loader[K comparable, V any] struct {
+	fetch    func(ctx context.Context, keys []K) (map[K]V, error)
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	cache map[K]*loaderResult[V]
+	batch *loaderBatch[K, V]
+}
+
+type loaderResult[V any] struct {
+	value V
+	found bool
+	err   error
+	done  chan struct{}
+}
+
+type loaderBatch[K comparable, V any] struct {
+	keys    []K
+	results map[K]*loaderResult[V]
+}
+
+func newLoader[K comparable, V any](
+	wait time.Duration,
+	maxBatch int,
+	fetch func(ctx context.Context, keys []K) (map[K]V, error),
+) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		cache:    make(map[K]*loaderResult[V]),
+	}
+}
+
+// Load returns the value for key, found is false when the fetch didn't return the key.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, bool, error) {
+	l.mu.Lock()
+
+	res, ok := l.cache[key]
+	if !ok {
+		res = &loaderResult[V]{done: make(chan struct{})}
+		l.cache[key] = res
+
+		if l.batch == nil {
+			l.batch = &loaderBatch[K, V]{results: make(map[K]*loaderResult[V])}
+
+			batch := l.batch
+			time.AfterFunc(l.wait, func() { l.dispatch(ctx, batch) })
+		}
+
+		l.batch.keys = append(l.batch.keys, key)
+		l.batch.results[key] = res
+
+		if len(l.batch.keys) >= l.maxBatch {
+			batch := l.batch
+			l.batch = nil
+			go l.run(ctx, batch)
+		}
+	}
+
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.found, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, false, ctx.Err()
+	}
+}
+
+// dispatch runs the batch unless it was already run for reaching maxBatch.
+func (l *loader[K, V]) dispatch(ctx context.Context, batch *loaderBatch[K, V]) {
+	l.mu.Lock()
+	if l.batch != batch {
+		l.mu.Unlock()
+		return
+	}
+	l.batch = nil
+	l.mu.Unlock()
+
+	l.run(ctx, batch)
+}
+
+func (l *loader[K, V]) run(ctx context.Context, batch *loaderBatch[K, V]) {
+	values, err := l.fetch(ctx, batch.keys)
+
+	for key, res := range batch.results {
+		res.value, res.found = values[key]
+		res.err = err
+		close(res.done)
+	}
+}
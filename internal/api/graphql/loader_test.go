@@ -0,0 +1,112 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingFetch struct {
+	mu      sync.Mutex
+	batches [][]int
+	err     error
+}
+
+func (f *recordingFetch) fetch(_ context.Context, keys []int) (map[int]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	batch := append([]int(nil), keys...)
+	sort.Ints(batch)
+	f.batches = append(f.batches, batch)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	res := make(map[int]string, len(keys))
+	for _, key := range keys {
+		if key > 0 {
+			res[key] = string(rune('a' + key))
+		}
+	}
+
+	return res, nil
+}
+
+func loadConcurrently(t *testing.T, l *loader[int, string], keys ...int) []string {
+	t.Helper()
+
+	res := make([]string, len(keys))
+	errs := make([]error, len(keys))
+
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res[i], _, errs[i] = l.Load(context.Background(), key)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	return res
+}
+
+func TestLoader_BatchesConcurrentLoads(t *testing.T) {
+	t.Parallel()
+
+	f := &recordingFetch{}
+	l := newLoader(10*time.Millisecond, 100, f.fetch)
+
+	res := loadConcurrently(t, l, 1, 2, 3, 2, 1)
+
+	require.Equal(t, []string{"b", "c", "d", "c", "b"}, res)
+	require.Equal(t, [][]int{{1, 2, 3}}, f.batches)
+
+	// the values stay cached for the rest of the request
+	val, found, err := l.Load(context.Background(), 3)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "d", val)
+	require.Len(t, f.batches, 1)
+}
+
+func TestLoader_SplitsBatchesAtMax(t *testing.T) {
+	t.Parallel()
+
+	f := &recordingFetch{}
+	l := newLoader(time.Hour, 2, f.fetch)
+
+	loadConcurrently(t, l, 1, 2, 3, 4)
+
+	require.Len(t, f.batches, 2)
+	for _, batch := range f.batches {
+		require.Len(t, batch, 2)
+	}
+}
+
+func TestLoader_MissingKeysAndErrors(t *testing.T) {
+	t.Parallel()
+
+	l := newLoader(time.Millisecond, 100, (&recordingFetch{}).fetch)
+
+	_, found, err := l.Load(context.Background(), -1)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	fetchErr := errors.New("unexpected DBError")
+	l = newLoader(time.Millisecond, 100, (&recordingFetch{err: fetchErr}).fetch)
+
+	_, _, err = l.Load(context.Background(), 1)
+	require.ErrorIs(t, err, fetchErr)
+}
@@ -0,0 +1,382 @@
+package graphql
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/locales"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/i18n"
+	pkglog "avito_shop/pkg/log"
+	pkgerr "avito_shop/pkg/pkgerror"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/go-chi/chi/v5/middleware"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+const (
+	directionSent     = "SENT"
+	directionReceived = "RECEIVED"
+)
+
+type rootResolver struct {
+	logger         *slog.Logger
+	userService    usecases.User
+	txService      usecases.Transaction
+	merchService   usecases.Merch
+	historyService usecases.History
+}
+
+func (r *rootResolver) Me(ctx context.Context) (*meResolver, error) {
+	uid, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, r.fail(ctx, "rootResolver.Me", err)
+	}
+
+	return &meResolver{root: r, uid: uid}, nil
+}
+
+func (r *rootResolver) Merch(ctx context.Context) ([]*merchResolver, error) {
+	items, err := r.merchService.List(ctx)
+	if err != nil {
+		return nil, r.fail(ctx, "rootResolver.Merch", err)
+	}
+
+	res := make([]*merchResolver, 0, len(items))
+	for _, item := range items {
+		res = append(res, &merchResolver{item: item})
+	}
+
+	return res, nil
+}
+
+type sendCoinArgs struct {
+	ToUser string
+	Amount int32
+}
+
+func (r *rootResolver) SendCoin(ctx context.Context, args sendCoinArgs) (*meResolver, error) {
+	const op = "rootResolver.SendCoin"
+
+	uid, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, r.fail(ctx, op, err)
+	}
+
+	if args.ToUser == "" || args.Amount == 0 {
+		return nil, r.fail(ctx, op, domain.ErrBadRequest)
+	}
+
+	err = r.txService.SendCoinByName(ctx, domain.Transaction{From: uid, Amount: int(args.Amount)}, args.ToUser)
+	if err != nil {
+		return nil, r.fail(ctx, op, err)
+	}
+
+	return &meResolver{root: r, uid: uid}, nil
+}
+
+type buyArgs struct {
+	Item string
+}
+
+func (r *rootResolver) Buy(ctx context.Context, args buyArgs) (*meResolver, error) {
+	const op = "rootResolver.Buy"
+
+	uid, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, r.fail(ctx, op, err)
+	}
+
+	if args.Item == "" {
+		return nil, r.fail(ctx, op, domain.ErrBadRequest)
+	}
+
+	err = r.txService.BuyItemByName(ctx, uid, args.Item)
+	if err != nil {
+		return nil, r.fail(ctx, op, err)
+	}
+
+	return &meResolver{root: r, uid: uid}, nil
+}
+
+// fail logs err and turns it into an error that is safe to show, with the same classification
+// the REST API uses for its status codes.
+func (r *rootResolver) fail(ctx context.Context, op string, err error) error {
+	log := r.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+	)
+	if uid, uidErr := userIDFromContext(ctx); uidErr == nil {
+		log = log.With(slog.Int("user_id", uid))
+	}
+
+	var code string
+	switch status := domain.HandleResult(err, nil).StatusCode(); {
+	case status == http.StatusUnauthorized:
+		code = "UNAUTHENTICATED"
+	case status == http.StatusForbidden:
+		code = "FORBIDDEN"
+	case status >= http.StatusBadRequest && status < http.StatusInternalServerError:
+		code = "BAD_REQUEST"
+	default:
+		log.Error("error while resolving field", pkglog.Err(err))
+		return &resolverError{code: "INTERNAL", message: "internal error"}
+	}
+
+	log.Warn("error while resolving field", pkglog.Err(err))
+	return &resolverError{code: code, message: pkgerr.UnwrapAll(err).Error()}
+}
+
+// resolverError shows up in the response with its code in the extensions.
+type resolverError struct {
+	code    string
+	message string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+type meResolver struct {
+	root *rootResolver
+	uid  domain.UserID
+
+	infoOnce sync.Once
+	info     domain.UserInfo
+	infoErr  error
+}
+
+func (m *meResolver) ID() int32 {
+	return toInt(m.uid)
+}
+
+func (m *meResolver) Name(ctx context.Context) (string, error) {
+	name, found, err := loadersFromContext(ctx).users.Load(ctx, m.uid)
+	if err != nil {
+		return "", m.root.fail(ctx, "meResolver.Name", err)
+	}
+	if !found {
+		return "", m.root.fail(ctx, "meResolver.Name", domain.ErrUserNotFound)
+	}
+
+	return name, nil
+}
+
+func (m *meResolver) Coins(ctx context.Context) (int32, error) {
+	info, err := m.loadInfo(ctx)
+	if err != nil {
+		return 0, m.root.fail(ctx, "meResolver.Coins", err)
+	}
+
+	return toInt(info.Coins), nil
+}
+
+func (m *meResolver) Inventory(ctx context.Context) ([]*inventoryResolver, error) {
+	info, err := m.loadInfo(ctx)
+	if err != nil {
+		return nil, m.root.fail(ctx, "meResolver.Inventory", err)
+	}
+
+	res := make([]*inventoryResolver, 0, len(info.Inventory))
+	for _, inv := range info.Inventory {
+		res = append(res, &inventoryResolver{root: m.root, inv: inv})
+	}
+
+	return res, nil
+}
+
+// loadInfo shares one lookup between the coins and the inventory.
+func (m *meResolver) loadInfo(ctx context.Context) (domain.UserInfo, error) {
+	m.infoOnce.Do(func() {
+		m.info, m.infoErr = m.root.userService.GetInfoByID(ctx, m.uid)
+	})
+
+	return m.info, m.infoErr
+}
+
+type limitArgs struct {
+	Limit int32
+}
+
+func (m *meResolver) Transactions(ctx context.Context, args limitArgs) ([]*transactionResolver, error) {
+	txs, err := m.root.historyService.Transactions(ctx, m.uid, int(args.Limit))
+	if err != nil {
+		return nil, m.root.fail(ctx, "meResolver.Transactions", err)
+	}
+
+	res := make([]*transactionResolver, 0, len(txs))
+	for _, tx := range txs {
+		res = append(res, &transactionResolver{root: m.root, uid: m.uid, tx: tx})
+	}
+
+	return res, nil
+}
+
+func (m *meResolver) Orders(ctx context.Context, args limitArgs) ([]*orderResolver, error) {
+	orders, err := m.root.historyService.Orders(ctx, m.uid, int(args.Limit))
+	if err != nil {
+		return nil, m.root.fail(ctx, "meResolver.Orders", err)
+	}
+
+	res := make([]*orderResolver, 0, len(orders))
+	for _, order := range orders {
+		res = append(res, &orderResolver{root: m.root, order: order})
+	}
+
+	return res, nil
+}
+
+type userResolver struct {
+	id   domain.UserID
+	name domain.UserName
+}
+
+func (u *userResolver) ID() int32 {
+	return toInt(u.id)
+}
+
+func (u *userResolver) Name() string {
+	return u.name
+}
+
+type merchResolver struct {
+	item domain.Merch
+}
+
+func (m *merchResolver) Name() string {
+	return m.item.Name
+}
+
+func (m *merchResolver) Price() int32 {
+	return toInt(m.item.Price)
+}
+
+func (m *merchResolver) DisplayName(ctx context.Context) string {
+	return i18n.FromContext(ctx).T(locales.MerchNameKey(m.item.Name), m.item.Name)
+}
+
+func (m *merchResolver) Description(ctx context.Context) *string {
+	desc := i18n.FromContext(ctx).T(locales.MerchDescriptionKey(m.item.Name), "")
+	if desc == "" {
+		return nil
+	}
+
+	return &desc
+}
+
+// loadMerch resolves an item by name through the request's loader.
+func loadMerch(ctx context.Context, root *rootResolver, op string, name domain.MerchName) (*merchResolver, error) {
+	item, found, err := loadersFromContext(ctx).merch.Load(ctx, name)
+	if err != nil {
+		return nil, root.fail(ctx, op, err)
+	}
+	if !found {
+		return nil, root.fail(ctx, op, fmt.Errorf("%s: %w", name, domain.ErrMerchNotFound))
+	}
+
+	return &merchResolver{item: item}, nil
+}
+
+type inventoryResolver struct {
+	root *rootResolver
+	inv  domain.Inventory
+}
+
+func (i *inventoryResolver) Merch(ctx context.Context) (*merchResolver, error) {
+	return loadMerch(ctx, i.root, "inventoryResolver.Merch", i.inv.Name)
+}
+
+func (i *inventoryResolver) Quantity() int32 {
+	return toInt(i.inv.Quantity)
+}
+
+type transactionResolver struct {
+	root *rootResolver
+	uid  domain.UserID
+	tx   domain.TransactionRecord
+}
+
+func (t *transactionResolver) ID() int32 {
+	return toInt(t.tx.ID)
+}
+
+func (t *transactionResolver) Direction() string {
+	if t.tx.From == t.uid {
+		return directionSent
+	}
+
+	return directionReceived
+}
+
+func (t *transactionResolver) From(ctx context.Context) (*userResolver, error) {
+	return t.loadUser(ctx, "transactionResolver.From", t.tx.From)
+}
+
+func (t *transactionResolver) To(ctx context.Context) (*userResolver, error) {
+	return t.loadUser(ctx, "transactionResolver.To", t.tx.To)
+}
+
+func (t *transactionResolver) loadUser(ctx context.Context, op string, id domain.UserID) (*userResolver, error) {
+	if id == 0 {
+		return nil, nil
+	}
+
+	name, found, err := loadersFromContext(ctx).users.Load(ctx, id)
+	if err != nil {
+		return nil, t.root.fail(ctx, op, err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return &userResolver{id: id, name: name}, nil
+}
+
+func (t *transactionResolver) Amount() int32 {
+	return toInt(t.tx.Amount)
+}
+
+func (t *transactionResolver) Reason() *string {
+	if t.tx.Reason == "" {
+		return nil
+	}
+
+	return &t.tx.Reason
+}
+
+func (t *transactionResolver) CreatedAt() gql.Time {
+	return gql.Time{Time: t.tx.CreatedAt}
+}
+
+type orderResolver struct {
+	root  *rootResolver
+	order domain.Order
+}
+
+func (o *orderResolver) ID() int32 {
+	return toInt(o.order.ID)
+}
+
+func (o *orderResolver) Item(ctx context.Context) (*merchResolver, error) {
+	return loadMerch(ctx, o.root, "orderResolver.Item", o.order.Item)
+}
+
+func (o *orderResolver) Price() int32 {
+	return toInt(o.order.Price)
+}
+
+func (o *orderResolver) CreatedAt() gql.Time {
+	return gql.Time{Time: o.order.CreatedAt}
+}
+
+// toInt converts to the 32-bit GraphQL Int.
+func toInt(v int) int32 {
+	return int32(v) //nolint:gosec // the values come from 32-bit INT columns
+}
@@ -0,0 +1,44 @@
+package grpc
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases"
+	shopv1 "avito_shop/pkg/api/shop/v1"
+	pkglog "avito_shop/pkg/log"
+	"context"
+	"log/slog"
+)
+
+type AuthServer struct {
+	shopv1.UnimplementedAuthServiceServer
+	logger  *slog.Logger
+	service usecases.Auth
+}
+
+func NewAuthServer(logger *slog.Logger, service usecases.Auth) *AuthServer {
+	return &AuthServer{
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (s *AuthServer) Login(ctx context.Context, req *shopv1.LoginRequest) (*shopv1.LoginResponse, error) {
+	const op = "AuthServer.Login"
+
+	log := s.logger.With(
+		slog.String("op", op),
+	)
+
+	if req.GetUsername() == "" || req.GetPassword() == "" {
+		log.Warn("request field is missed")
+		return nil, toStatus(domain.ErrBadRequest)
+	}
+
+	token, err := s.service.Login(ctx, req.GetUsername(), req.GetPassword())
+	if err != nil {
+		log.Warn("error with user login", pkglog.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &shopv1.LoginResponse{Token: token}, nil
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases/mocks"
+	shopv1 "avito_shop/pkg/api/shop/v1"
+	"avito_shop/pkg/testutils"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLogin_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewAuth(t)
+	s := NewAuthServer(testutils.NewDummyLogger(), svc)
+
+	svc.On("Login", mock.Anything, "Avito", "12345").Return("token", nil)
+
+	res, err := s.Login(context.Background(), &shopv1.LoginRequest{Username: "Avito", Password: "12345"})
+
+	require.NoError(t, err)
+	require.Equal(t, "token", res.GetToken())
+}
+
+func TestLogin_ErrorCases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Req     *shopv1.LoginRequest
+		Err     error
+		ExpCode codes.Code
+	}{
+		{"Empty Username", &shopv1.LoginRequest{Password: "12345"}, nil, codes.InvalidArgument},
+		{"Empty Password", &shopv1.LoginRequest{Username: "Avito"}, nil, codes.InvalidArgument},
+		{"Wrong Password", &shopv1.LoginRequest{Username: "Avito", Password: "1"}, domain.ErrUnauthorized,
+			codes.Unauthenticated},
+		{"Unexpected DBError", &shopv1.LoginRequest{Username: "Avito", Password: "1"}, errors.New("unexpected DBError"),
+			codes.Internal},
+	}
+
+	for _, test := range tests {
+		svc := mocks.NewAuth(t)
+		s := NewAuthServer(testutils.NewDummyLogger(), svc)
+
+		if test.Err != nil {
+			svc.On("Login", mock.Anything, test.Req.GetUsername(), test.Req.GetPassword()).Return("", test.Err)
+		}
+
+		_, err := s.Login(context.Background(), test.Req)
+
+		require.Equal(t, test.ExpCode, status.Code(err), test.Name)
+	}
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"avito_shop/internal/domain"
+	pkgerr "avito_shop/pkg/pkgerror"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusCodes maps the HTTP statuses of the error registry to gRPC codes.
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+}
+
+// toStatus is the gRPC counterpart of domain.HandleResult, the code follows from the status
+// the error is registered with, so both APIs classify an error the same way.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	err = pkgerr.UnwrapAll(err)
+
+	spec, ok := domain.LookupError(err)
+	if !ok {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	code, ok := statusCodes[spec.Status]
+	if !ok {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	return status.Error(code, err.Error())
+}
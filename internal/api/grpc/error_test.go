@@ -0,0 +1,52 @@
+package grpc
+
+import (
+	"avito_shop/internal/domain"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Err     error
+		ExpCode codes.Code
+	}{
+		{"Bad Request", domain.ErrBadRequest, codes.InvalidArgument},
+		{"Invalid Token", domain.ErrInvalidAuthToken, codes.Unauthenticated},
+		{"Forbidden", domain.ErrForbidden, codes.PermissionDenied},
+		{"Unknown User", domain.ErrUserNotFound, codes.NotFound},
+		{"User Exists", domain.ErrUserExists, codes.AlreadyExists},
+		{"Low Balance", domain.ErrLowBalance, codes.FailedPrecondition},
+		{"Unexpected Error", errors.New("unexpected DBError"), codes.Internal},
+	}
+
+	for _, test := range tests {
+		err := toStatus(fmt.Errorf("Service.Method: %w", test.Err))
+
+		require.Equal(t, test.ExpCode, status.Code(err), test.Name)
+	}
+
+	require.NoError(t, toStatus(nil))
+}
+
+func TestToStatus_EveryRegisteredError(t *testing.T) {
+	t.Parallel()
+
+	for _, spec := range domain.ErrorSpecs() {
+		code, ok := statusCodes[spec.Status]
+		require.True(t, ok, "%s is served with %d, which has no gRPC code", spec.Code, spec.Status)
+
+		st, ok := status.FromError(toStatus(fmt.Errorf("Service.Method: %w", spec.Err)))
+		require.True(t, ok, spec.Code)
+		require.Equal(t, code, st.Code(), spec.Code)
+		require.Equal(t, spec.Err.Error(), st.Message(), spec.Code)
+	}
+}
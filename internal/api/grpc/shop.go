@@ -0,0 +1,137 @@
+package grpc
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/lib/interceptors"
+	"avito_shop/internal/usecases"
+	shopv1 "avito_shop/pkg/api/shop/v1"
+	pkglog "avito_shop/pkg/log"
+	"context"
+	"log/slog"
+)
+
+type ShopServer struct {
+	shopv1.UnimplementedShopServiceServer
+	logger      *slog.Logger
+	userService usecases.User
+	txService   usecases.Transaction
+}
+
+func NewShopServer(logger *slog.Logger, userService usecases.User, txService usecases.Transaction) *ShopServer {
+	return &ShopServer{
+		logger:      logger,
+		userService: userService,
+		txService:   txService,
+	}
+}
+
+func (s *ShopServer) GetInfo(ctx context.Context, _ *shopv1.GetInfoRequest) (*shopv1.GetInfoResponse, error) {
+	const op = "ShopServer.GetInfo"
+	uid, err := interceptors.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, toStatus(domain.ErrUnauthorized)
+	}
+
+	log := s.logger.With(
+		slog.String("op", op),
+		slog.Int("user_id", uid),
+	)
+
+	info, err := s.userService.GetInfoByID(ctx, uid)
+	if err != nil {
+		log.Error("error while collecting user info: ", pkglog.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return createGetInfoResponse(info), nil
+}
+
+func (s *ShopServer) SendCoin(ctx context.Context, req *shopv1.SendCoinRequest) (*shopv1.SendCoinResponse, error) {
+	const op = "ShopServer.SendCoin"
+	uid, err := interceptors.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, toStatus(domain.ErrUnauthorized)
+	}
+
+	log := s.logger.With(
+		slog.String("op", op),
+		slog.Int("user_id", uid),
+	)
+
+	if req.GetToUser() == "" || req.GetAmount() == 0 {
+		log.Warn("invalid request field")
+		return nil, toStatus(domain.ErrBadRequest)
+	}
+
+	err = s.txService.SendCoinByName(ctx,
+		domain.Transaction{
+			From:   uid,
+			Amount: int(req.GetAmount()),
+		},
+		req.GetToUser(),
+	)
+	if err != nil {
+		log.Warn("error with sending coins", pkglog.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &shopv1.SendCoinResponse{}, nil
+}
+
+func (s *ShopServer) BuyItem(ctx context.Context, req *shopv1.BuyItemRequest) (*shopv1.BuyItemResponse, error) {
+	const op = "ShopServer.BuyItem"
+	uid, err := interceptors.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, toStatus(domain.ErrUnauthorized)
+	}
+
+	log := s.logger.With(
+		slog.String("op", op),
+		slog.Int("user_id", uid),
+	)
+
+	if req.GetItem() == "" {
+		log.Warn("item is missed")
+		return nil, toStatus(domain.ErrBadRequest)
+	}
+
+	err = s.txService.BuyItemByName(ctx, uid, req.GetItem())
+	if err != nil {
+		log.Warn("error while buying item", pkglog.Err(err))
+		return nil, toStatus(err)
+	}
+
+	return &shopv1.BuyItemResponse{}, nil
+}
+
+func createGetInfoResponse(info domain.UserInfo) *shopv1.GetInfoResponse {
+	res := &shopv1.GetInfoResponse{
+		Coins:       int64(info.Coins),
+		CoinHistory: &shopv1.CoinHistory{},
+	}
+
+	for _, inv := range info.Inventory {
+		res.Inventory = append(res.Inventory, &shopv1.InventoryItem{
+			Type:     inv.Name,
+			Quantity: int64(inv.Quantity),
+		})
+	}
+
+	for _, tx := range info.Transactions {
+		if tx.Direction == domain.Received {
+			res.CoinHistory.Received = append(res.CoinHistory.Received, &shopv1.ReceivedCoins{
+				FromUser: tx.OtherUser,
+				Amount:   int64(tx.Amount),
+				Reason:   tx.Reason,
+			})
+		} else {
+			res.CoinHistory.Sent = append(res.CoinHistory.Sent, &shopv1.SentCoins{
+				ToUser: tx.OtherUser,
+				Amount: int64(tx.Amount),
+				Reason: tx.Reason,
+			})
+		}
+	}
+
+	return res
+}
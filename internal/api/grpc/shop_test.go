@@ -0,0 +1,164 @@
+package grpc
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/lib/interceptors"
+	"avito_shop/internal/usecases/mocks"
+	shopv1 "avito_shop/pkg/api/shop/v1"
+	"avito_shop/pkg/testutils"
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestShopClient serves the shop over an in-memory listener behind the auth interceptor.
+func newTestShopClient(
+	t *testing.T,
+	authSvc *mocks.Auth,
+	userSvc *mocks.User,
+	txSvc *mocks.Transaction,
+) shopv1.ShopServiceClient {
+	lis := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(interceptors.WithTokenAuth(authSvc)))
+	shopv1.RegisterShopServiceServer(server, NewShopServer(testutils.NewDummyLogger(), userSvc, txSvc))
+
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return shopv1.NewShopServiceClient(conn)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", token)
+}
+
+func TestShop_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	authSvc := mocks.NewAuth(t)
+	client := newTestShopClient(t, authSvc, mocks.NewUser(t), mocks.NewTransaction(t))
+
+	authSvc.On("ParseToken", "broken").Return(0, domain.ErrInvalidAuthToken)
+
+	_, err := client.GetInfo(context.Background(), &shopv1.GetInfoRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.GetInfo(withToken("broken"), &shopv1.GetInfoRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestShop_GetInfo(t *testing.T) {
+	t.Parallel()
+
+	authSvc := mocks.NewAuth(t)
+	userSvc := mocks.NewUser(t)
+	client := newTestShopClient(t, authSvc, userSvc, mocks.NewTransaction(t))
+
+	info := domain.UserInfo{
+		Coins:     900,
+		Inventory: []domain.Inventory{{Name: "cup", Quantity: 2}},
+		Transactions: []domain.UserTransaction{
+			{OtherUser: "alice", Amount: 50, Direction: domain.Received},
+			{OtherUser: "bob", Amount: 30, Direction: domain.Sent, Reason: "lunch"},
+		},
+	}
+
+	authSvc.On("ParseToken", "token").Return(1, nil)
+	userSvc.On("GetInfoByID", mock.Anything, 1).Return(info, nil)
+
+	res, err := client.GetInfo(withToken("Bearer token"), &shopv1.GetInfoRequest{})
+
+	require.NoError(t, err)
+	require.Equal(t, int64(900), res.GetCoins())
+	require.Len(t, res.GetInventory(), 1)
+	require.Equal(t, "cup", res.GetInventory()[0].GetType())
+	require.Equal(t, int64(2), res.GetInventory()[0].GetQuantity())
+	require.Len(t, res.GetCoinHistory().GetReceived(), 1)
+	require.Equal(t, "alice", res.GetCoinHistory().GetReceived()[0].GetFromUser())
+	require.Len(t, res.GetCoinHistory().GetSent(), 1)
+	require.Equal(t, "bob", res.GetCoinHistory().GetSent()[0].GetToUser())
+	require.Equal(t, "lunch", res.GetCoinHistory().GetSent()[0].GetReason())
+}
+
+func TestShop_SendCoin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Req     *shopv1.SendCoinRequest
+		Err     error
+		ExpCode codes.Code
+	}{
+		{"Success", &shopv1.SendCoinRequest{ToUser: "bob", Amount: 10}, nil, codes.OK},
+		{"Empty Recipient", &shopv1.SendCoinRequest{Amount: 10}, nil, codes.InvalidArgument},
+		{"Low Balance", &shopv1.SendCoinRequest{ToUser: "bob", Amount: 10}, domain.ErrLowBalance,
+			codes.FailedPrecondition},
+		{"Unknown Recipient", &shopv1.SendCoinRequest{ToUser: "bob", Amount: 10}, domain.ErrUserNotFound,
+			codes.NotFound},
+		{"Unexpected DBError", &shopv1.SendCoinRequest{ToUser: "bob", Amount: 10}, errors.New("unexpected DBError"),
+			codes.Internal},
+	}
+
+	for _, test := range tests {
+		authSvc := mocks.NewAuth(t)
+		txSvc := mocks.NewTransaction(t)
+		client := newTestShopClient(t, authSvc, mocks.NewUser(t), txSvc)
+
+		authSvc.On("ParseToken", "token").Return(1, nil)
+		if test.Req.GetToUser() != "" {
+			txSvc.On("SendCoinByName", mock.Anything, domain.Transaction{From: 1, Amount: 10}, "bob").
+				Return(test.Err)
+		}
+
+		_, err := client.SendCoin(withToken("token"), test.Req)
+
+		require.Equal(t, test.ExpCode, status.Code(err), test.Name)
+	}
+}
+
+func TestShop_BuyItem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Err     error
+		ExpCode codes.Code
+	}{
+		{"Success", nil, codes.OK},
+		{"Unknown Item", domain.ErrMerchNotFound, codes.NotFound},
+		{"Low Balance", domain.ErrLowBalance, codes.FailedPrecondition},
+	}
+
+	for _, test := range tests {
+		authSvc := mocks.NewAuth(t)
+		txSvc := mocks.NewTransaction(t)
+		client := newTestShopClient(t, authSvc, mocks.NewUser(t), txSvc)
+
+		authSvc.On("ParseToken", "token").Return(1, nil)
+		txSvc.On("BuyItemByName", mock.Anything, 1, "cup").Return(test.Err)
+
+		_, err := client.BuyItem(withToken("token"), &shopv1.BuyItemRequest{Item: "cup"})
+
+		require.Equal(t, test.ExpCode, status.Code(err), test.Name)
+	}
+}
@@ -0,0 +1,397 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/http/handlers"
+	resp "avito_shop/pkg/http/responses"
+	pkglog "avito_shop/pkg/log"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type AdminHandler struct {
+	logger          *slog.Logger
+	txService       usecases.Transaction
+	treasuryService usecases.Treasury
+	ledgerService   usecases.Ledger
+	reconService    usecases.Reconciliation
+}
+
+func NewAdminHandler(
+	logger *slog.Logger,
+	txService usecases.Transaction,
+	treasuryService usecases.Treasury,
+	ledgerService usecases.Ledger,
+	reconService usecases.Reconciliation,
+) *AdminHandler {
+	return &AdminHandler{
+		logger:          logger,
+		txService:       txService,
+		treasuryService: treasuryService,
+		ledgerService:   ledgerService,
+		reconService:    reconService,
+	}
+}
+
+const (
+	postReverseTransactionPath  = "/admin/transactions/{id}/reverse"
+	postMintPath                = "/admin/mint"
+	postBurnPath                = "/admin/burn"
+	postAllowancePath           = "/admin/allowances"
+	getLedgerVerificationPath   = "/admin/ledger/verification"
+	postReconciliationPath      = "/admin/reconciliations"
+	getLatestReconciliationPath = "/admin/reconciliations/latest"
+	postApplyReconciliationPath = "/admin/reconciliations/{id}/apply"
+)
+
+func (h *AdminHandler) WithAdminHandlers(authService usecases.Auth) handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(libmiddleware.WithTokenAuth(authService))
+			r.Use(libmiddleware.WithAdminAuth(authService))
+			handlers.AddHandler(r.Post, postReverseTransactionPath, h.postReverseTransaction)
+			handlers.AddHandler(r.Post, postMintPath, h.postMint)
+			handlers.AddHandler(r.Post, postBurnPath, h.postBurn)
+			handlers.AddHandler(r.Post, postAllowancePath, h.postAllowance)
+			handlers.AddHandler(r.Get, getLedgerVerificationPath, h.getLedgerVerification)
+			handlers.AddHandler(r.Post, postReconciliationPath, h.postReconciliation)
+			handlers.AddHandler(r.Get, getLatestReconciliationPath, h.getLatestReconciliation)
+			handlers.AddHandler(r.Post, postApplyReconciliationPath, h.postApplyReconciliation)
+		})
+	}
+}
+
+// @Summary	Отменить перевод компенсирующей транзакцией
+// @Security	BearerAuth
+// @Accept		json
+// @Produce	json
+// @Param		id		path		int										true	"Идентификатор транзакции"
+// @Param		body	body		types.PostReverseTransactionRequest		true	"Причина отмены"
+// @Success	200		{object}	types.PostReverseTransactionResponse	"Созданные компенсирующие транзакции"
+// @Failure	400		{object}	responses.ErrorResponse					"Неверный запрос"
+// @Failure	401		{object}	responses.ErrorResponse					"Неавторизован"
+// @Failure	403		{object}	responses.ErrorResponse					"Недостаточно прав"
+// @Failure	500		{object}	responses.ErrorResponse					"Внутренняя ошибка сервера"
+// @Router		/admin/transactions/{id}/reverse [post]
+func (h *AdminHandler) postReverseTransaction(r *http.Request) resp.Response {
+	const op = "AdminHandler.postReverseTransaction"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreatePostReverseTransactionRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(domain.ErrBadRequest, nil)
+	}
+
+	txs, err := h.txService.Reverse(r.Context(), domain.TransactionReversal{
+		TransactionID: req.ID,
+		Reason:        req.Reason,
+		Force:         req.Force,
+		AdminID:       uid,
+	})
+	if err != nil {
+		log.Warn("error while reversing transaction", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	log.Info("transaction reversed",
+		slog.Int("transaction_id", req.ID),
+		slog.String("reason", req.Reason),
+		slog.Bool("force", req.Force),
+	)
+
+	return domain.HandleResult(nil, types.CreatePostReverseTransactionResponse(txs))
+}
+
+// @Summary	Начислить сотруднику бонусные монеты
+// @Security	BearerAuth
+// @Accept		json
+// @Produce	json
+// @Param		body	body		types.PostMintRequest			true	"Получатель, сумма и причина"
+// @Success	200		{object}	types.CoinAdjustmentResponse	"Созданная транзакция"
+// @Failure	400		{object}	responses.ErrorResponse			"Неверный запрос"
+// @Failure	401		{object}	responses.ErrorResponse			"Неавторизован"
+// @Failure	403		{object}	responses.ErrorResponse			"Недостаточно прав"
+// @Failure	500		{object}	responses.ErrorResponse			"Внутренняя ошибка сервера"
+// @Router		/admin/mint [post]
+func (h *AdminHandler) postMint(r *http.Request) resp.Response {
+	const op = "AdminHandler.postMint"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreatePostMintRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(domain.ErrBadRequest, nil)
+	}
+
+	tx, err := h.treasuryService.MintByName(r.Context(),
+		domain.CoinAdjustment{
+			Amount:  req.Amount,
+			Reason:  req.Reason,
+			AdminID: uid,
+		},
+		req.ToUser,
+	)
+	if err != nil {
+		log.Warn("error while minting coins", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	log.Info("coins minted", slog.String("to_user", req.ToUser), slog.Int("amount", tx.Amount))
+
+	return domain.HandleResult(nil, types.CreateCoinAdjustmentResponse(tx))
+}
+
+// @Summary	Списать монеты сотрудника
+// @Security	BearerAuth
+// @Accept		json
+// @Produce	json
+// @Param		body	body		types.PostBurnRequest			true	"Сотрудник, сумма (или весь баланс) и причина"
+// @Success	200		{object}	types.CoinAdjustmentResponse	"Созданная транзакция"
+// @Failure	400		{object}	responses.ErrorResponse			"Неверный запрос"
+// @Failure	401		{object}	responses.ErrorResponse			"Неавторизован"
+// @Failure	403		{object}	responses.ErrorResponse			"Недостаточно прав"
+// @Failure	500		{object}	responses.ErrorResponse			"Внутренняя ошибка сервера"
+// @Router		/admin/burn [post]
+func (h *AdminHandler) postBurn(r *http.Request) resp.Response {
+	const op = "AdminHandler.postBurn"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreatePostBurnRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(domain.ErrBadRequest, nil)
+	}
+
+	tx, err := h.treasuryService.BurnByName(r.Context(),
+		domain.CoinAdjustment{
+			Amount:     req.Amount,
+			Reason:     req.Reason,
+			AdminID:    uid,
+			Deactivate: req.Deactivate,
+		},
+		req.FromUser,
+	)
+	if err != nil {
+		log.Warn("error while burning coins", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	log.Info("coins burnt",
+		slog.String("from_user", req.FromUser),
+		slog.Int("amount", tx.Amount),
+		slog.Bool("deactivate", req.Deactivate),
+	)
+
+	return domain.HandleResult(nil, types.CreateCoinAdjustmentResponse(tx))
+}
+
+// @Summary		Начислить ежемесячные монеты за текущий месяц
+// @Description	Повторный вызов в том же месяце ничего не начисляет.
+// @Security		BearerAuth
+// @Produce		json
+// @Success		200	{object}	types.PostAllowanceResponse	"Период и число сотрудников, получивших монеты"
+// @Failure		401	{object}	responses.ErrorResponse		"Неавторизован"
+// @Failure		403	{object}	responses.ErrorResponse		"Недостаточно прав"
+// @Failure		500	{object}	responses.ErrorResponse		"Внутренняя ошибка сервера"
+// @Router			/admin/allowances [post]
+func (h *AdminHandler) postAllowance(r *http.Request) resp.Response {
+	const op = "AdminHandler.postAllowance"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	grant, err := h.treasuryService.GrantAllowance(r.Context(), time.Now())
+	if err != nil {
+		log.Error("error while granting allowance", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreatePostAllowanceResponse(grant))
+}
+
+// @Summary	Сверить балансы сотрудников с журналом проводок
+// @Security	BearerAuth
+// @Produce	json
+// @Success	200	{object}	types.GetLedgerVerificationResponse	"Результат сверки"
+// @Failure	401	{object}	responses.ErrorResponse				"Неавторизован"
+// @Failure	403	{object}	responses.ErrorResponse				"Недостаточно прав"
+// @Failure	500	{object}	responses.ErrorResponse				"Внутренняя ошибка сервера"
+// @Router		/admin/ledger/verification [get]
+func (h *AdminHandler) getLedgerVerification(r *http.Request) resp.Response {
+	const op = "AdminHandler.getLedgerVerification"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	res, err := h.ledgerService.Verify(r.Context())
+	if err != nil {
+		log.Error("error while verifying ledger", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	if !res.OK() {
+		log.Error("ledger verification failed",
+			slog.Int("total", res.Total),
+			slog.Int("unbalanced_entries", len(res.UnbalancedEntries)),
+			slog.Int("mismatches", len(res.Mismatches)),
+		)
+	}
+
+	return domain.HandleResult(nil, types.CreateGetLedgerVerificationResponse(res))
+}
+
+// @Summary	Пересчитать балансы по истории переводов (без исправлений)
+// @Security	BearerAuth
+// @Produce	json
+// @Success	200	{object}	types.ReconciliationResponse	"Отчёт о расхождениях"
+// @Failure	401	{object}	responses.ErrorResponse			"Неавторизован"
+// @Failure	403	{object}	responses.ErrorResponse			"Недостаточно прав"
+// @Failure	500	{object}	responses.ErrorResponse			"Внутренняя ошибка сервера"
+// @Router		/admin/reconciliations [post]
+func (h *AdminHandler) postReconciliation(r *http.Request) resp.Response {
+	const op = "AdminHandler.postReconciliation"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	rec, err := h.reconService.Run(r.Context())
+	if err != nil {
+		log.Error("error while reconciling balances", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	log.Info("balances reconciled",
+		slog.Int("reconciliation_id", rec.ID),
+		slog.Int("discrepancies", len(rec.Discrepancies)),
+	)
+
+	return domain.HandleResult(nil, types.CreateReconciliationResponse(rec))
+}
+
+// @Summary	Получить результат последней сверки балансов
+// @Security	BearerAuth
+// @Produce	json
+// @Success	200	{object}	types.ReconciliationResponse	"Отчёт о расхождениях"
+// @Failure	400	{object}	responses.ErrorResponse			"Сверок ещё не было"
+// @Failure	401	{object}	responses.ErrorResponse			"Неавторизован"
+// @Failure	403	{object}	responses.ErrorResponse			"Недостаточно прав"
+// @Failure	500	{object}	responses.ErrorResponse			"Внутренняя ошибка сервера"
+// @Router		/admin/reconciliations/latest [get]
+func (h *AdminHandler) getLatestReconciliation(r *http.Request) resp.Response {
+	const op = "AdminHandler.getLatestReconciliation"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	rec, err := h.reconService.GetLatest(r.Context())
+	if err != nil {
+		log.Warn("error while getting latest reconciliation", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateReconciliationResponse(rec))
+}
+
+// @Summary		Исправить расхождения из отчёта сверки
+// @Description	Исправляются только балансы, не изменившиеся с момента сверки.
+// @Security		BearerAuth
+// @Produce		json
+// @Param			id	path		int								true	"Идентификатор сверки"
+// @Success		200	{object}	types.ReconciliationResponse	"Отчёт с исправляющими транзакциями"
+// @Failure		400	{object}	responses.ErrorResponse			"Неверный запрос"
+// @Failure		401	{object}	responses.ErrorResponse			"Неавторизован"
+// @Failure		403	{object}	responses.ErrorResponse			"Недостаточно прав"
+// @Failure		500	{object}	responses.ErrorResponse			"Внутренняя ошибка сервера"
+// @Router			/admin/reconciliations/{id}/apply [post]
+func (h *AdminHandler) postApplyReconciliation(r *http.Request) resp.Response {
+	const op = "AdminHandler.postApplyReconciliation"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreateReconciliationIDRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(domain.ErrBadRequest, nil)
+	}
+
+	rec, err := h.reconService.Apply(r.Context(), req.ID, uid)
+	if err != nil {
+		log.Warn("error while applying reconciliation", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	log.Info("reconciliation applied", slog.Int("reconciliation_id", rec.ID))
+
+	return domain.HandleResult(nil, types.CreateReconciliationResponse(rec))
+}
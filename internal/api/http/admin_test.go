@@ -0,0 +1,277 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/testutils"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostReverseTransaction_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewTransaction(t)
+	h := NewAdminHandler(testutils.NewDummyLogger(), svc, nil, nil, nil)
+
+	uID := 2
+	req := types.PostReverseTransactionRequest{Reason: "wrong recipient", Force: true}
+	reversals := []domain.Transaction{
+		{ID: 10, From: 3, To: 4, Amount: 30},
+		{ID: 11, From: repository.ShopDBID, To: 4, Amount: 20},
+	}
+
+	httpReq := testutils.NewMockJSONRequest(t, req)
+	httpReq = testutils.AddURLParamToRequest(httpReq, "id", "7")
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On("Reverse", mock.Anything, domain.TransactionReversal{
+		TransactionID: 7,
+		Reason:        req.Reason,
+		Force:         req.Force,
+		AdminID:       uID,
+	}).Return(reversals, nil)
+
+	resp := h.postReverseTransaction(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, types.CreatePostReverseTransactionResponse(reversals), resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestPostReverseTransaction_BadRequest(t *testing.T) {
+	t.Parallel()
+
+	h := NewAdminHandler(testutils.NewDummyLogger(), nil, nil, nil, nil)
+
+	uID := 2
+	httpReq := testutils.NewMockJSONRequest(t, types.PostReverseTransactionRequest{})
+	httpReq = testutils.AddURLParamToRequest(httpReq, "id", "7")
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	resp := h.postReverseTransaction(httpReq)
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+}
+
+func TestPostReverseTransaction_ServiceErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Err     error
+		ExpCode int
+	}{
+		{"Transaction doesn't exist", domain.ErrTransactionNotFound, http.StatusNotFound},
+		{"Already reversed", domain.ErrTransactionAlreadyReversed, http.StatusConflict},
+		{"Recipient spent the coins", domain.ErrLowBalance, http.StatusUnprocessableEntity},
+		{"Unexpected DBError", errors.New("unexpected DBError"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		svc := mocks.NewTransaction(t)
+		h := NewAdminHandler(testutils.NewDummyLogger(), svc, nil, nil, nil)
+
+		uID := 2
+		httpReq := testutils.NewMockJSONRequest(t, types.PostReverseTransactionRequest{Reason: "oops"})
+		httpReq = testutils.AddURLParamToRequest(httpReq, "id", "7")
+		httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+		svc.On("Reverse", mock.Anything, mock.Anything).Return(nil, test.Err)
+
+		resp := h.postReverseTransaction(httpReq)
+
+		require.Equal(t, test.ExpCode, resp.StatusCode())
+		svc.AssertExpectations(t)
+	}
+}
+
+func TestPostMint_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewTreasury(t)
+	h := NewAdminHandler(testutils.NewDummyLogger(), nil, svc, nil, nil)
+
+	uID := 2
+	req := types.PostMintRequest{ToUser: "Avito", Amount: 100, Reason: "bonus"}
+	tx := domain.Transaction{ID: 5, From: repository.SystemDBID, To: 3, Amount: req.Amount}
+
+	httpReq := testutils.NewMockJSONRequest(t, req)
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On("MintByName", mock.Anything,
+		domain.CoinAdjustment{Amount: req.Amount, Reason: req.Reason, AdminID: uID},
+		req.ToUser,
+	).Return(tx, nil)
+
+	resp := h.postMint(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, types.CreateCoinAdjustmentResponse(tx), resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestPostBurn_ServiceErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Err     error
+		ExpCode int
+	}{
+		{"User doesn't exist", domain.ErrUserNotFound, http.StatusNotFound},
+		{"Low balance", domain.ErrLowBalance, http.StatusUnprocessableEntity},
+		{"Unexpected DBError", errors.New("unexpected DBError"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		svc := mocks.NewTreasury(t)
+		h := NewAdminHandler(testutils.NewDummyLogger(), nil, svc, nil, nil)
+
+		uID := 2
+		req := types.PostBurnRequest{FromUser: "Avito", All: true, Reason: "leaving", Deactivate: true}
+		httpReq := testutils.NewMockJSONRequest(t, req)
+		httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+		svc.On("BurnByName", mock.Anything,
+			domain.CoinAdjustment{Reason: req.Reason, AdminID: uID, Deactivate: true},
+			req.FromUser,
+		).Return(domain.Transaction{}, test.Err)
+
+		resp := h.postBurn(httpReq)
+
+		require.Equal(t, test.ExpCode, resp.StatusCode())
+		svc.AssertExpectations(t)
+	}
+}
+
+func TestPostAllowance_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewTreasury(t)
+	h := NewAdminHandler(testutils.NewDummyLogger(), nil, svc, nil, nil)
+
+	uID := 2
+	grant := domain.AllowanceGrant{Period: "2026-10", Granted: 42}
+	httpReq := testutils.NewMockRequest()
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On("GrantAllowance", mock.Anything, mock.Anything).Return(grant, nil)
+
+	resp := h.postAllowance(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, types.CreatePostAllowanceResponse(grant), resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestGetLedgerVerification_Mismatch(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewLedger(t)
+	h := NewAdminHandler(testutils.NewDummyLogger(), nil, nil, svc, nil)
+
+	res := domain.LedgerVerification{
+		Postings:   10,
+		Mismatches: []domain.BalanceMismatch{{Account: 3, Name: "Avito", Stored: 900, Posted: 1000}},
+	}
+	httpReq := testutils.NewMockRequest()
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, 2)
+
+	svc.On("Verify", mock.Anything).Return(res, nil)
+
+	resp := h.getLedgerVerification(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, &types.GetLedgerVerificationResponse{
+		OK:                false,
+		Postings:          10,
+		UnbalancedEntries: []int{},
+		Mismatches:        []types.LedgerBalanceMismatch{{User: "Avito", Stored: 900, Posted: 1000}},
+	}, resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestPostApplyReconciliation_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewReconciliation(t)
+	h := NewAdminHandler(testutils.NewDummyLogger(), nil, nil, nil, svc)
+
+	uID := 2
+	rec := domain.Reconciliation{
+		ID:            7,
+		Checked:       3,
+		AppliedAt:     time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		AppliedBy:     uID,
+		Discrepancies: []domain.BalanceDiscrepancy{{Account: 3, Name: "Avito", Stored: 900, Expected: 1000, Correction: 15}},
+	}
+
+	httpReq := testutils.NewMockRequestWithURLParam("id", "7")
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On("Apply", mock.Anything, rec.ID, uID).Return(rec, nil)
+
+	resp := h.postApplyReconciliation(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, &types.ReconciliationResponse{
+		ID:        7,
+		Checked:   3,
+		Applied:   true,
+		AppliedAt: &rec.AppliedAt,
+		Discrepancies: []types.BalanceDiscrepancy{
+			{User: "Avito", Stored: 900, Expected: 1000, Diff: 100, CorrectionID: 15},
+		},
+	}, resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestPostApplyReconciliation_ServiceErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Err     error
+		ExpCode int
+	}{
+		{"Not found", domain.ErrReconciliationNotFound, http.StatusNotFound},
+		{"Already applied", domain.ErrReconciliationAlreadyApplied, http.StatusConflict},
+		{"Unexpected DBError", errors.New("unexpected DBError"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		svc := mocks.NewReconciliation(t)
+		h := NewAdminHandler(testutils.NewDummyLogger(), nil, nil, nil, svc)
+
+		httpReq := testutils.NewMockRequestWithURLParam("id", "7")
+		httpReq = testutils.AddUserIDToRequestContext(httpReq, 2)
+
+		svc.On("Apply", mock.Anything, 7, 2).Return(domain.Reconciliation{}, test.Err)
+
+		resp := h.postApplyReconciliation(httpReq)
+
+		require.Equal(t, test.ExpCode, resp.StatusCode(), test.Name)
+		svc.AssertExpectations(t)
+	}
+}
+
+func TestPostApplyReconciliation_BadID(t *testing.T) {
+	t.Parallel()
+
+	h := NewAdminHandler(testutils.NewDummyLogger(), nil, nil, nil, nil)
+
+	httpReq := testutils.NewMockRequestWithURLParam("id", "latest")
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, 2)
+
+	resp := h.postApplyReconciliation(httpReq)
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+}
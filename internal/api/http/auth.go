@@ -41,8 +41,9 @@ func (h *AuthHandler) WithAuthHandlers() handlers.RouterOption {
 // @Success	200		{object}	types.PostAuthResponse	"Успешная аутентификация"
 // @Failure	400		{object}	responses.ErrorResponse	"Неверный запрос"
 // @Failure	401		{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure	409		{object}	responses.ErrorResponse	"Пользователь уже создан параллельным запросом"
 // @Failure	500		{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
-// @Router		/api/auth [post]
+// @Router		/auth [post]
 func (h *AuthHandler) postAuth(r *http.Request) resp.Response {
 	const op = "AuthHandler.postAuth"
 
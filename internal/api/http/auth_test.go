@@ -73,7 +73,7 @@ func TestPostAuth_ServiceErrors(t *testing.T) {
 		{"Unexpected DBError", errors.New("unexpected DBError"), http.StatusInternalServerError},
 		{"Hashed password mismatch", domain.ErrUnauthorized, http.StatusUnauthorized},
 		{"Token generation error", errors.New("token generation error"), http.StatusInternalServerError},
-		{"User exist(on concurrent write could occur)", domain.ErrUserExists, http.StatusUnauthorized},
+		{"User exist(on concurrent write could occur)", domain.ErrUserExists, http.StatusConflict},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/http/handlers"
+	resp "avito_shop/pkg/http/responses"
+	pkglog "avito_shop/pkg/log"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type CoinRequestHandler struct {
+	logger  *slog.Logger
+	service usecases.CoinRequest
+}
+
+func NewCoinRequestHandler(logger *slog.Logger, service usecases.CoinRequest) *CoinRequestHandler {
+	return &CoinRequestHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+const (
+	postCoinRequestPath         = "/coinRequests"
+	getIncomingCoinRequestsPath = "/coinRequests/incoming"
+	getOutgoingCoinRequestsPath = "/coinRequests/outgoing"
+	postAcceptCoinRequestPath   = "/coinRequests/{id}/accept"
+	postDeclineCoinRequestPath  = "/coinRequests/{id}/decline"
+)
+
+func (h *CoinRequestHandler) WithSecuredCoinRequestHandlers(authService usecases.Auth) handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(libmiddleware.WithTokenAuth(authService))
+			handlers.AddHandler(r.Post, postCoinRequestPath, h.postCoinRequest)
+			handlers.AddHandler(r.Get, getIncomingCoinRequestsPath, h.getIncomingCoinRequests)
+			handlers.AddHandler(r.Get, getOutgoingCoinRequestsPath, h.getOutgoingCoinRequests)
+			handlers.AddHandler(r.Post, postAcceptCoinRequestPath, h.postAcceptCoinRequest)
+			handlers.AddHandler(r.Post, postDeclineCoinRequestPath, h.postDeclineCoinRequest)
+		})
+	}
+}
+
+// @Summary	Запросить монеты у другого пользователя
+// @Security	BearerAuth
+// @Accept		json
+// @Produce	json
+// @Param		body	body		types.PostCoinRequestRequest	true	"Данные запроса монет"
+// @Success	200		{object}	types.CoinRequest				"Созданный запрос"
+// @Failure	400		{object}	responses.ErrorResponse			"Неверный запрос"
+// @Failure	401		{object}	responses.ErrorResponse			"Неавторизован"
+// @Failure	500		{object}	responses.ErrorResponse			"Внутренняя ошибка сервера"
+// @Router		/coinRequests [post]
+func (h *CoinRequestHandler) postCoinRequest(r *http.Request) resp.Response {
+	const op = "CoinRequestHandler.postCoinRequest"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreatePostCoinRequestRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(domain.ErrBadRequest, nil)
+	}
+
+	created, err := h.service.CreateByName(r.Context(),
+		domain.CoinRequest{
+			Requester: uid,
+			Amount:    req.Amount,
+		},
+		req.FromUser,
+	)
+	if err != nil {
+		log.Warn("error while creating coin request", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateCoinRequestResponse(created))
+}
+
+// @Summary	Получить входящие запросы монет
+// @Security	BearerAuth
+// @Produce	json
+// @Success	200	{object}	types.GetCoinRequestsResponse	"Успешный ответ"
+// @Failure	401	{object}	responses.ErrorResponse			"Неавторизован"
+// @Failure	500	{object}	responses.ErrorResponse			"Внутренняя ошибка сервера"
+// @Router		/coinRequests/incoming [get]
+func (h *CoinRequestHandler) getIncomingCoinRequests(r *http.Request) resp.Response {
+	const op = "CoinRequestHandler.getIncomingCoinRequests"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	reqs, err := h.service.ListIncoming(r.Context(), uid)
+	if err != nil {
+		log.Error("error while listing incoming coin requests", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateGetCoinRequestsResponse(reqs))
+}
+
+// @Summary	Получить исходящие запросы монет
+// @Security	BearerAuth
+// @Produce	json
+// @Success	200	{object}	types.GetCoinRequestsResponse	"Успешный ответ"
+// @Failure	401	{object}	responses.ErrorResponse			"Неавторизован"
+// @Failure	500	{object}	responses.ErrorResponse			"Внутренняя ошибка сервера"
+// @Router		/coinRequests/outgoing [get]
+func (h *CoinRequestHandler) getOutgoingCoinRequests(r *http.Request) resp.Response {
+	const op = "CoinRequestHandler.getOutgoingCoinRequests"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	reqs, err := h.service.ListOutgoing(r.Context(), uid)
+	if err != nil {
+		log.Error("error while listing outgoing coin requests", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateGetCoinRequestsResponse(reqs))
+}
+
+// @Summary	Принять запрос монет и перевести их запросившему
+// @Security	BearerAuth
+// @Produce	json
+// @Param		id	path	int	true	"Идентификатор запроса"
+// @Success	200	"Успешный ответ"
+// @Failure	400	{object}	responses.ErrorResponse	"Неверный запрос"
+// @Failure	401	{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure	500	{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
+// @Router		/coinRequests/{id}/accept [post]
+func (h *CoinRequestHandler) postAcceptCoinRequest(r *http.Request) resp.Response {
+	const op = "CoinRequestHandler.postAcceptCoinRequest"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreateCoinRequestIDRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	err = h.service.Accept(r.Context(), uid, req.ID)
+	if err != nil {
+		log.Warn("error while accepting coin request", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, nil)
+}
+
+// @Summary	Отклонить запрос монет
+// @Security	BearerAuth
+// @Produce	json
+// @Param		id	path	int	true	"Идентификатор запроса"
+// @Success	200	"Успешный ответ"
+// @Failure	400	{object}	responses.ErrorResponse	"Неверный запрос"
+// @Failure	401	{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure	500	{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
+// @Router		/coinRequests/{id}/decline [post]
+func (h *CoinRequestHandler) postDeclineCoinRequest(r *http.Request) resp.Response {
+	const op = "CoinRequestHandler.postDeclineCoinRequest"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreateCoinRequestIDRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	err = h.service.Decline(r.Context(), uid, req.ID)
+	if err != nil {
+		log.Warn("error while declining coin request", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, nil)
+}
@@ -0,0 +1,208 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/testutils"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostCoinRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewCoinRequest(t)
+	h := NewCoinRequestHandler(testutils.NewDummyLogger(), svc)
+
+	uID := 2
+	req := types.PostCoinRequestRequest{
+		FromUser: "Avito",
+		Amount:   100,
+	}
+	created := domain.CoinRequest{
+		ID:            1,
+		Requester:     uID,
+		RequesterName: "Requester",
+		PayerName:     req.FromUser,
+		Amount:        req.Amount,
+		Status:        domain.CoinRequestPending,
+	}
+
+	httpReq := testutils.NewMockJSONRequest(t, req)
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On(
+		"CreateByName",
+		mock.Anything,
+		domain.CoinRequest{Requester: uID, Amount: req.Amount},
+		req.FromUser).Return(created, nil)
+
+	resp := h.postCoinRequest(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, types.CreateCoinRequestResponse(created), resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestPostCoinRequest_EmptyContextVal(t *testing.T) {
+	t.Parallel()
+
+	h := NewCoinRequestHandler(testutils.NewDummyLogger(), nil)
+
+	httpReq := testutils.NewMockRequest()
+
+	resp := h.postCoinRequest(httpReq)
+
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode())
+}
+
+func TestPostCoinRequest_ServiceErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Err     error
+		ExpCode int
+	}{
+		{"FromUser doesn't exist", domain.ErrUserNotFound, http.StatusNotFound},
+		{"Requesting coins from yourself", domain.ErrSelfRequesting, http.StatusUnprocessableEntity},
+		{"Unexpected DBError", errors.New("unexpected DBError"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		svc := mocks.NewCoinRequest(t)
+		h := NewCoinRequestHandler(testutils.NewDummyLogger(), svc)
+
+		uID := 2
+		req := types.PostCoinRequestRequest{FromUser: "Avito", Amount: 100}
+		httpReq := testutils.NewMockJSONRequest(t, req)
+		httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+		svc.On("CreateByName", mock.Anything, mock.Anything, req.FromUser).
+			Return(domain.CoinRequest{}, test.Err)
+
+		resp := h.postCoinRequest(httpReq)
+
+		require.Equal(t, test.ExpCode, resp.StatusCode())
+		svc.AssertExpectations(t)
+	}
+}
+
+func TestGetIncomingCoinRequests_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewCoinRequest(t)
+	h := NewCoinRequestHandler(testutils.NewDummyLogger(), svc)
+
+	uID := 2
+	reqs := []domain.CoinRequest{
+		{ID: 1, RequesterName: "Avito", Amount: 100, Status: domain.CoinRequestPending},
+	}
+
+	httpReq := testutils.NewMockRequest()
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On("ListIncoming", mock.Anything, uID).Return(reqs, nil)
+
+	resp := h.getIncomingCoinRequests(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, types.CreateGetCoinRequestsResponse(reqs), resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestPostAcceptCoinRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewCoinRequest(t)
+	h := NewCoinRequestHandler(testutils.NewDummyLogger(), svc)
+
+	uID := 2
+	httpReq := testutils.NewMockRequestWithURLParam("id", "1")
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On("Accept", mock.Anything, uID, 1).Return(nil)
+
+	resp := h.postAcceptCoinRequest(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	svc.AssertExpectations(t)
+}
+
+func TestPostAcceptCoinRequest_BadRequestCases(t *testing.T) {
+	t.Parallel()
+
+	uID := 2
+	tests := []struct {
+		Name string
+		ID   string
+	}{
+		{"Empty ID", ""},
+		{"Not a number", "abc"},
+	}
+
+	for _, test := range tests {
+		h := NewCoinRequestHandler(testutils.NewDummyLogger(), nil)
+
+		httpReq := testutils.NewMockRequestWithURLParam("id", test.ID)
+		httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+		resp := h.postAcceptCoinRequest(httpReq)
+
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	}
+}
+
+func TestPostAcceptCoinRequest_ServiceErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Err     error
+		ExpCode int
+	}{
+		{"Request doesn't exist", domain.ErrCoinRequestNotFound, http.StatusNotFound},
+		{"Request already resolved", domain.ErrCoinRequestNotActive, http.StatusConflict},
+		{"Low balance", domain.ErrLowBalance, http.StatusUnprocessableEntity},
+		{"Unexpected DBError", errors.New("unexpected DBError"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		svc := mocks.NewCoinRequest(t)
+		h := NewCoinRequestHandler(testutils.NewDummyLogger(), svc)
+
+		uID := 2
+		httpReq := testutils.NewMockRequestWithURLParam("id", "1")
+		httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+		svc.On("Accept", mock.Anything, uID, 1).Return(test.Err)
+
+		resp := h.postAcceptCoinRequest(httpReq)
+
+		require.Equal(t, test.ExpCode, resp.StatusCode())
+		svc.AssertExpectations(t)
+	}
+}
+
+func TestPostDeclineCoinRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewCoinRequest(t)
+	h := NewCoinRequestHandler(testutils.NewDummyLogger(), svc)
+
+	uID := 2
+	httpReq := testutils.NewMockRequestWithURLParam("id", "1")
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On("Decline", mock.Anything, uID, 1).Return(nil)
+
+	resp := h.postDeclineCoinRequest(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	svc.AssertExpectations(t)
+}
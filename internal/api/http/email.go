@@ -0,0 +1,114 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/http/handlers"
+	resp "avito_shop/pkg/http/responses"
+	pkglog "avito_shop/pkg/log"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type EmailHandler struct {
+	logger  *slog.Logger
+	service usecases.Email
+}
+
+func NewEmailHandler(logger *slog.Logger, service usecases.Email) *EmailHandler {
+	return &EmailHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+const emailPreferencesPath = "/notifications/email"
+
+func (h *EmailHandler) WithSecuredEmailHandlers(authService usecases.Auth) handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(libmiddleware.WithTokenAuth(authService))
+			handlers.AddHandler(r.Get, emailPreferencesPath, h.getEmailPreferences)
+			handlers.AddHandler(r.Put, emailPreferencesPath, h.putEmailPreferences)
+		})
+	}
+}
+
+// @Summary	Получить настройки email-уведомлений
+// @Security	BearerAuth
+// @Produce	json
+// @Success	200	{object}	types.EmailPreferences	"Успешный ответ"
+// @Failure	401	{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure	500	{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
+// @Router		/notifications/email [get]
+func (h *EmailHandler) getEmailPreferences(r *http.Request) resp.Response {
+	const op = "EmailHandler.getEmailPreferences"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	prefs, err := h.service.GetPreferences(r.Context(), uid)
+	if err != nil {
+		log.Error("error while getting email preferences", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateEmailPreferencesResponse(prefs))
+}
+
+// @Summary	Изменить настройки email-уведомлений
+// @Description	Пустой email отключает письма. Адрес должен быть в корпоративном домене (EMAIL_DOMAIN).
+// @Description	Письмо «заказ готов» (merchPurchased) отправляется при покупке: мерч выдается сразу.
+// @Security	BearerAuth
+// @Accept		json
+// @Produce	json
+// @Param		body	body	types.EmailPreferences	true	"Адрес и типы уведомлений"
+// @Success	200		"Успешный ответ"
+// @Failure	400		{object}	responses.ErrorResponse	"Неверный запрос"
+// @Failure	401		{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure	500		{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
+// @Router		/notifications/email [put]
+func (h *EmailHandler) putEmailPreferences(r *http.Request) resp.Response {
+	const op = "EmailHandler.putEmailPreferences"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreatePutEmailPreferencesRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(domain.ErrBadRequest, nil)
+	}
+
+	err = h.service.SetPreferences(r.Context(), domain.EmailPreferences{
+		User:           uid,
+		Address:        req.Email,
+		CoinsReceived:  req.CoinsReceived,
+		MerchPurchased: req.MerchPurchased,
+	})
+	if err != nil {
+		log.Warn("error while setting email preferences", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, nil)
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/testutils"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEmailPreferences_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewEmail(t)
+	h := NewEmailHandler(testutils.NewDummyLogger(), svc)
+
+	prefs := domain.EmailPreferences{User: 2, Address: "bob@example.com", CoinsReceived: true}
+
+	httpReq := testutils.AddUserIDToRequestContext(testutils.NewMockRequest(), 2)
+
+	svc.On("GetPreferences", mock.Anything, 2).Return(prefs, nil)
+
+	resp := h.getEmailPreferences(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, &types.EmailPreferences{Email: "bob@example.com", CoinsReceived: true}, resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestPutEmailPreferences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Err     error
+		ExpCode int
+	}{
+		{"Success", nil, http.StatusOK},
+		{"Invalid address", domain.ErrBadRequest, http.StatusBadRequest},
+		{"Unexpected DBError", errors.New("unexpected DBError"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		svc := mocks.NewEmail(t)
+		h := NewEmailHandler(testutils.NewDummyLogger(), svc)
+
+		httpReq := testutils.NewMockJSONRequest(t, types.EmailPreferences{Email: "bob@example.com", MerchPurchased: true})
+		httpReq = testutils.AddUserIDToRequestContext(httpReq, 2)
+
+		svc.On("SetPreferences", mock.Anything, domain.EmailPreferences{
+			User:           2,
+			Address:        "bob@example.com",
+			MerchPurchased: true,
+		}).Return(test.Err)
+
+		resp := h.putEmailPreferences(httpReq)
+
+		require.Equal(t, test.ExpCode, resp.StatusCode(), test.Name)
+		svc.AssertExpectations(t)
+	}
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"avito_shop/internal/domain"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/http/handlers"
+	pkglog "avito_shop/pkg/log"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type EventHandler struct {
+	logger    *slog.Logger
+	service   usecases.EventStream
+	heartbeat time.Duration
+}
+
+func NewEventHandler(logger *slog.Logger, service usecases.EventStream, heartbeat time.Duration) *EventHandler {
+	return &EventHandler{
+		logger:    logger,
+		service:   service,
+		heartbeat: heartbeat,
+	}
+}
+
+const getEventsPath = "/events"
+
+func (h *EventHandler) WithSecuredEventHandlers(authService usecases.Auth) handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(libmiddleware.WithTokenAuth(authService))
+			r.Get(getEventsPath, h.getEvents)
+		})
+	}
+}
+
+// @Summary		Поток событий пользователя (Server-Sent Events)
+// @Description	События coinsReceived, coinsSent, merchPurchased, coinRequestCreated и coinRequestDeclined,
+// @Description	поле id совпадает с id события.
+// @Description	Соединение периодически получает комментарий-пинг, при разрыве клиент переподключается.
+// @Security		BearerAuth
+// @Produce		text/event-stream
+// @Success		200	"Поток событий"
+// @Failure		401	{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure		500	{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
+// @Router			/events [get]
+func (h *EventHandler) getEvents(w http.ResponseWriter, r *http.Request) {
+	const op = "EventHandler.getEvents"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		handlers.WriteResponse(w, r, domain.HandleResult(err, nil))
+		return
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	// the stream outlives the server write timeout, which is meant for regular requests
+	rc := http.NewResponseController(w)
+	if err = rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Warn("error while clearing write deadline", pkglog.Err(err))
+	}
+
+	stream := h.service.Subscribe(r.Context(), uid)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			_, err = io.WriteString(w, ": ping\n\n")
+		case e, ok := <-stream:
+			if !ok {
+				log.Info("event stream closed")
+				return
+			}
+			err = writeStreamEvent(w, e)
+		}
+
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			log.Info("error while writing event stream", pkglog.Err(err))
+			return
+		}
+	}
+}
+
+func writeStreamEvent(w io.Writer, e domain.StreamEvent) error {
+	// a newline would end the data field early
+	var data bytes.Buffer
+	if err := json.Compact(&data, e.Data); err != nil {
+		return fmt.Errorf("writeStreamEvent: %w", err)
+	}
+
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Name, data.Bytes())
+	if err != nil {
+		return fmt.Errorf("writeStreamEvent: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/testutils"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEvents_StreamsEvents(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewEventStream(t)
+	h := NewEventHandler(testutils.NewDummyLogger(), svc, time.Hour)
+
+	stream := make(chan domain.StreamEvent, 2)
+	stream <- domain.StreamEvent{
+		ID:   42,
+		Name: domain.StreamCoinsReceived,
+		Data: json.RawMessage("{\"fromName\": \"alice\",\n \"amount\": 50}"),
+	}
+	stream <- domain.StreamEvent{ID: 43, Name: domain.StreamMerchPurchased, Data: json.RawMessage(`{"item":"cup"}`)}
+	close(stream)
+
+	httpReq := testutils.AddUserIDToRequestContext(testutils.NewMockRequest(), 2)
+	rec := httptest.NewRecorder()
+
+	svc.On("Subscribe", mock.Anything, 2).Return((<-chan domain.StreamEvent)(stream))
+
+	h.getEvents(rec, httpReq)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	require.Equal(t,
+		"id: 42\nevent: coinsReceived\ndata: {\"fromName\":\"alice\",\"amount\":50}\n\n"+
+			"id: 43\nevent: merchPurchased\ndata: {\"item\":\"cup\"}\n\n",
+		rec.Body.String(),
+	)
+	svc.AssertExpectations(t)
+}
+
+func TestGetEvents_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewEventStream(t)
+	h := NewEventHandler(testutils.NewDummyLogger(), svc, time.Hour)
+
+	rec := httptest.NewRecorder()
+
+	h.getEvents(rec, testutils.NewMockRequest())
+
+	require.NotEqual(t, http.StatusOK, rec.Code)
+	svc.AssertNotCalled(t, "Subscribe", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/http/handlers"
+	resp "avito_shop/pkg/http/responses"
+	pkglog "avito_shop/pkg/log"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type LanguageHandler struct {
+	logger  *slog.Logger
+	service usecases.User
+}
+
+func NewLanguageHandler(logger *slog.Logger, service usecases.User) *LanguageHandler {
+	return &LanguageHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+const languagePath = "/preferences/language"
+
+func (h *LanguageHandler) WithSecuredLanguageHandlers(authService usecases.Auth) handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(libmiddleware.WithTokenAuth(authService))
+			handlers.AddHandler(r.Get, languagePath, h.getLanguage)
+			handlers.AddHandler(r.Put, languagePath, h.putLanguage)
+		})
+	}
+}
+
+// @Summary	Получить выбранный язык ответов
+// @Description	Пустой язык означает, что язык выбирается по заголовку Accept-Language.
+// @Security	BearerAuth
+// @Produce	json
+// @Success	200	{object}	types.LanguagePreference	"Успешный ответ"
+// @Failure	401	{object}	responses.ErrorResponse		"Неавторизован"
+// @Failure	404	{object}	responses.ErrorResponse		"Пользователь не найден"
+// @Failure	500	{object}	responses.ErrorResponse		"Внутренняя ошибка сервера"
+// @Router		/preferences/language [get]
+func (h *LanguageHandler) getLanguage(r *http.Request) resp.Response {
+	const op = "LanguageHandler.getLanguage"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	lang, err := h.service.GetLanguage(r.Context(), uid)
+	if err != nil {
+		log.Error("error while getting language", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, &types.LanguagePreference{Language: lang})
+}
+
+// @Summary	Выбрать язык ответов
+// @Description	Язык (en, ru) используется для сообщений об ошибках и каталога мерча вместо Accept-Language,
+// @Description	пустой язык возвращает выбор по Accept-Language.
+// @Security	BearerAuth
+// @Accept		json
+// @Produce	json
+// @Param		body	body	types.LanguagePreference	true	"Язык"
+// @Success	200		"Успешный ответ"
+// @Failure	400		{object}	responses.ErrorResponse	"Неподдерживаемый язык"
+// @Failure	401		{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure	404		{object}	responses.ErrorResponse	"Пользователь не найден"
+// @Failure	500		{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
+// @Router		/preferences/language [put]
+func (h *LanguageHandler) putLanguage(r *http.Request) resp.Response {
+	const op = "LanguageHandler.putLanguage"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreatePutLanguageRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(domain.ErrBadRequest, nil)
+	}
+
+	err = h.service.SetLanguage(r.Context(), uid, req.Language)
+	if err != nil {
+		log.Warn("error while setting language", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, nil)
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/testutils"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLanguage_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewUser(t)
+	h := NewLanguageHandler(testutils.NewDummyLogger(), svc)
+
+	httpReq := testutils.AddUserIDToRequestContext(testutils.NewMockRequest(), 2)
+
+	svc.On("GetLanguage", mock.Anything, 2).Return(domain.LanguageRussian, nil)
+
+	resp := h.getLanguage(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, &types.LanguagePreference{Language: domain.LanguageRussian}, resp.GetPayload())
+}
+
+func TestPutLanguage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name      string
+		Err       error
+		ExpStatus int
+	}{
+		{"Success", nil, http.StatusOK},
+		{"Unsupported language", domain.ErrBadRequest, http.StatusBadRequest},
+		{"User doesn't exist", domain.ErrUserNotFound, http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		svc := mocks.NewUser(t)
+		h := NewLanguageHandler(testutils.NewDummyLogger(), svc)
+
+		httpReq := testutils.NewMockJSONRequest(t, types.LanguagePreference{Language: "ru"})
+		httpReq = testutils.AddUserIDToRequestContext(httpReq, 2)
+
+		svc.On("SetLanguage", mock.Anything, 2, "ru").Return(test.Err)
+
+		resp := h.putLanguage(httpReq)
+
+		require.Equal(t, test.ExpStatus, resp.StatusCode(), test.Name)
+	}
+}
+
+func TestPutLanguage_BrokenJSON(t *testing.T) {
+	t.Parallel()
+
+	h := NewLanguageHandler(testutils.NewDummyLogger(), nil)
+
+	httpReq := testutils.NewMockJSONRequest(t, []byte(`{"language":`))
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, 2)
+
+	resp := h.putLanguage(httpReq)
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/http/handlers"
+	resp "avito_shop/pkg/http/responses"
+	"avito_shop/pkg/i18n"
+	pkglog "avito_shop/pkg/log"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type MerchHandler struct {
+	logger  *slog.Logger
+	service usecases.Merch
+}
+
+func NewMerchHandler(logger *slog.Logger, service usecases.Merch) *MerchHandler {
+	return &MerchHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+const getMerchPath = "/merch"
+
+func (h *MerchHandler) WithSecuredMerchHandlers(authService usecases.Auth) handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(libmiddleware.WithTokenAuth(authService))
+			handlers.AddHandler(r.Get, getMerchPath, h.getMerch)
+		})
+	}
+}
+
+// @Summary	Получить каталог мерча
+// @Description	Названия и описания переводятся на язык пользователя или из Accept-Language.
+// @Security	BearerAuth
+// @Produce	json
+// @Param		Accept-Language	header	string	false	"Язык ответа (en, ru)"
+// @Success	200	{array}		types.MerchItem			"Товары, сначала самые дешёвые"
+// @Failure	401	{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure	500	{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
+// @Router		/merch [get]
+func (h *MerchHandler) getMerch(r *http.Request) resp.Response {
+	const op = "MerchHandler.getMerch"
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+	)
+
+	items, err := h.service.List(r.Context())
+	if err != nil {
+		log.Error("error while listing merch", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateGetMerchResponse(items, i18n.FromContext(r.Context())))
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"avito_shop/internal/locales"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/i18n"
+	"avito_shop/pkg/testutils"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMerch_Localized(t *testing.T) {
+	t.Parallel()
+
+	items := []domain.Merch{
+		{ID: 4, Name: "pen", Price: 10},
+		{ID: 11, Name: "sticker", Price: 5},
+	}
+
+	tests := []struct {
+		Name string
+		Lang domain.Language
+		Exp  []types.MerchItem
+	}{
+		{"English", domain.LanguageEnglish, []types.MerchItem{
+			{Name: "pen", DisplayName: "Pen", Description: "Ballpoint pen with the company logo.", Price: 10},
+			{Name: "sticker", DisplayName: "sticker", Price: 5},
+		}},
+		{"Russian", domain.LanguageRussian, []types.MerchItem{
+			{Name: "pen", DisplayName: "Ручка", Description: "Шариковая ручка с логотипом компании.", Price: 10},
+			{Name: "sticker", DisplayName: "sticker", Price: 5},
+		}},
+	}
+
+	bundle := locales.MustBundle()
+
+	for _, test := range tests {
+		svc := mocks.NewMerch(t)
+		h := NewMerchHandler(testutils.NewDummyLogger(), svc)
+
+		httpReq := testutils.AddUserIDToRequestContext(testutils.NewMockRequest(), 2)
+		localizer := i18n.NewLocalizer(bundle, func() string { return test.Lang })
+		httpReq = httpReq.WithContext(i18n.WithLocalizer(httpReq.Context(), localizer))
+
+		svc.On("List", mock.Anything).Return(items, nil)
+
+		resp := h.getMerch(httpReq)
+
+		require.Equal(t, http.StatusOK, resp.StatusCode(), test.Name)
+		require.Equal(t, test.Exp, resp.GetPayload(), test.Name)
+	}
+}
+
+func TestGetMerch_ServiceError(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewMerch(t)
+	h := NewMerchHandler(testutils.NewDummyLogger(), svc)
+
+	svc.On("List", mock.Anything).Return(nil, errors.New("db is down"))
+
+	resp := h.getMerch(testutils.NewMockRequest())
+
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode())
+}
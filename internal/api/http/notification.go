@@ -0,0 +1,309 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/http/handlers"
+	resp "avito_shop/pkg/http/responses"
+	pkglog "avito_shop/pkg/log"
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsWriteTimeout   = 10 * time.Second
+	wsMaxMessageSize = 4096
+)
+
+type NotificationHandler struct {
+	logger    *slog.Logger
+	service   usecases.Notification
+	stream    usecases.EventStream
+	heartbeat time.Duration
+	upgrader  websocket.Upgrader
+}
+
+func NewNotificationHandler(
+	logger *slog.Logger,
+	service usecases.Notification,
+	stream usecases.EventStream,
+	heartbeat time.Duration,
+) *NotificationHandler {
+	return &NotificationHandler{
+		logger:    logger,
+		service:   service,
+		stream:    stream,
+		heartbeat: heartbeat,
+	}
+}
+
+const (
+	getNotificationsPath     = "/notifications"
+	postAckNotificationsPath = "/notifications/ack"
+	getWSPath                = "/ws"
+)
+
+func (h *NotificationHandler) WithSecuredNotificationHandlers(authService usecases.Auth) handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(libmiddleware.WithTokenAuth(authService))
+			handlers.AddHandler(r.Get, getNotificationsPath, h.getNotifications)
+			handlers.AddHandler(r.Post, postAckNotificationsPath, h.postAckNotifications)
+			r.Get(getWSPath, h.getWS)
+		})
+	}
+}
+
+// @Summary	Получить непрочитанные уведомления
+// @Security	BearerAuth
+// @Produce	json
+// @Param		after	query		int	false	"Вернуть уведомления с id события больше указанного"
+// @Success	200		{object}	types.GetNotificationsResponse	"Успешный ответ"
+// @Failure	400		{object}	responses.ErrorResponse			"Неверный запрос"
+// @Failure	401		{object}	responses.ErrorResponse			"Неавторизован"
+// @Failure	500		{object}	responses.ErrorResponse			"Внутренняя ошибка сервера"
+// @Router		/notifications [get]
+func (h *NotificationHandler) getNotifications(r *http.Request) resp.Response {
+	const op = "NotificationHandler.getNotifications"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreateAfterEventRequest(r, "after")
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	notifications, err := h.service.ListUnread(r.Context(), uid, req.After)
+	if err != nil {
+		log.Error("error while listing notifications", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateGetNotificationsResponse(notifications))
+}
+
+// @Summary	Отметить уведомления прочитанными
+// @Security	BearerAuth
+// @Accept		json
+// @Produce	json
+// @Param		body	body		types.PostAckNotificationsRequest	true	"Id событий уведомлений"
+// @Success	200		{object}	types.PostAckNotificationsResponse	"Успешный ответ"
+// @Failure	400		{object}	responses.ErrorResponse				"Неверный запрос"
+// @Failure	401		{object}	responses.ErrorResponse				"Неавторизован"
+// @Failure	500		{object}	responses.ErrorResponse				"Внутренняя ошибка сервера"
+// @Router		/notifications/ack [post]
+func (h *NotificationHandler) postAckNotifications(r *http.Request) resp.Response {
+	const op = "NotificationHandler.postAckNotifications"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreatePostAckNotificationsRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(domain.ErrBadRequest, nil)
+	}
+
+	acked, err := h.service.Ack(r.Context(), uid, req.IDs)
+	if err != nil {
+		log.Warn("error while acking notifications", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, &types.PostAckNotificationsResponse{Acked: acked})
+}
+
+// @Summary		Канал уведомлений по WebSocket
+// @Description	Сервер присылает {"type":"notification",...} с id события,
+// @Description	клиент подтверждает прочтение сообщением {"type":"ack","ids":[...]}.
+// @Description	После переподключения с lastEventId приходят непрочитанные уведомления после него, затем новые.
+// @Security		BearerAuth
+// @Param			lastEventId	query	int	false	"Id последнего полученного события"
+// @Success		101	"Соединение установлено"
+// @Failure		400	{object}	responses.ErrorResponse	"Неверный запрос"
+// @Failure		401	{object}	responses.ErrorResponse	"Неавторизован"
+// @Router			/ws [get]
+func (h *NotificationHandler) getWS(w http.ResponseWriter, r *http.Request) {
+	const op = "NotificationHandler.getWS"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		handlers.WriteResponse(w, r, domain.HandleResult(err, nil))
+		return
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreateAfterEventRequest(r, "lastEventId")
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		handlers.WriteResponse(w, r, domain.HandleResult(err, nil))
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Warn("error while upgrading connection", pkglog.Err(err))
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// subscribing before reading the backlog, so nothing written in between is missed
+	live := h.stream.Subscribe(ctx, uid)
+
+	sent, err := h.sendBacklog(ctx, conn, uid, req.After)
+	if err != nil {
+		log.Warn("error while sending notifications backlog", pkglog.Err(err))
+		_ = writeWS(conn, types.WSErrorMessage{Type: types.WSMessageError, Error: "internal error"})
+		return
+	}
+
+	replies := make(chan any)
+	go func() {
+		defer cancel()
+		h.readWS(ctx, conn, uid, replies, log)
+	}()
+
+	ping := time.NewTicker(h.heartbeat)
+	defer ping.Stop()
+
+	for {
+		var msg any
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ping.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+		case e, ok := <-live:
+			if !ok {
+				closing := websocket.FormatCloseMessage(websocket.CloseGoingAway, "stream closed")
+				_ = conn.WriteControl(websocket.CloseMessage, closing, time.Now().Add(wsWriteTimeout))
+				return
+			}
+			if _, dup := sent[e.ID]; dup || !domain.IsNotification(e) {
+				continue
+			}
+			msg = types.CreateWSNotificationMessage(e)
+		case msg = <-replies:
+		}
+
+		if msg != nil {
+			err = writeWS(conn, msg)
+		}
+		if err != nil {
+			log.Info("error while writing to websocket", pkglog.Err(err))
+			return
+		}
+	}
+}
+
+// sendBacklog sends every unread notification after the given event id
+// and returns the ids it sent, so they aren't sent twice when they arrive live.
+func (h *NotificationHandler) sendBacklog(
+	ctx context.Context,
+	conn *websocket.Conn,
+	uid domain.UserID,
+	after domain.EventID,
+) (map[domain.EventID]struct{}, error) {
+	sent := make(map[domain.EventID]struct{})
+
+	for {
+		page, err := h.service.ListUnread(ctx, uid, after)
+		if err != nil || len(page) == 0 {
+			return sent, err
+		}
+
+		for _, e := range page {
+			if err = writeWS(conn, types.CreateWSNotificationMessage(e)); err != nil {
+				return sent, err
+			}
+			sent[e.ID] = struct{}{}
+			after = e.ID
+		}
+	}
+}
+
+// readWS handles client messages until the connection breaks, the replies are written
+// by the caller, a websocket connection supports only one concurrent writer.
+func (h *NotificationHandler) readWS(
+	ctx context.Context,
+	conn *websocket.Conn,
+	uid domain.UserID,
+	replies chan<- any,
+	log *slog.Logger,
+) {
+	conn.SetReadLimit(wsMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	})
+
+	for {
+		var msg types.WSClientMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Info("error while reading from websocket", pkglog.Err(err))
+			}
+			return
+		}
+
+		var reply any
+		switch msg.Type {
+		case types.WSMessageAck:
+			acked, err := h.service.Ack(ctx, uid, msg.IDs)
+			if err != nil {
+				log.Warn("error while acking notifications", pkglog.Err(err))
+				reply = types.WSErrorMessage{Type: types.WSMessageError, Error: "ack failed"}
+			} else {
+				reply = types.WSAckedMessage{Type: types.WSMessageAcked, IDs: msg.IDs, Acked: acked}
+			}
+		default:
+			reply = types.WSErrorMessage{Type: types.WSMessageError, Error: "unknown message type"}
+		}
+
+		select {
+		case replies <- reply:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func writeWS(conn *websocket.Conn, msg any) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+
+	return conn.WriteJSON(msg)
+}
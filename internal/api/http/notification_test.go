@@ -0,0 +1,128 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/testutils"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotifications_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewNotification(t)
+	h := NewNotificationHandler(testutils.NewDummyLogger(), svc, nil, time.Hour)
+
+	unread := []domain.StreamEvent{
+		{ID: 42, Name: domain.StreamCoinRequestCreated, Data: json.RawMessage(`{"requestId":7}`)},
+	}
+
+	httpReq := httptest.NewRequest(http.MethodGet, "/notifications?after=41", nil)
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, 2)
+
+	svc.On("ListUnread", mock.Anything, 2, int64(41)).Return(unread, nil)
+
+	resp := h.getNotifications(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, types.CreateGetNotificationsResponse(unread), resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestGetNotifications_ServiceError(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewNotification(t)
+	h := NewNotificationHandler(testutils.NewDummyLogger(), svc, nil, time.Hour)
+
+	httpReq := testutils.AddUserIDToRequestContext(testutils.NewMockRequest(), 2)
+
+	svc.On("ListUnread", mock.Anything, 2, int64(0)).Return(nil, errors.New("unexpected DBError"))
+
+	resp := h.getNotifications(httpReq)
+
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode())
+	svc.AssertExpectations(t)
+}
+
+func TestPostAckNotifications_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewNotification(t)
+	h := NewNotificationHandler(testutils.NewDummyLogger(), svc, nil, time.Hour)
+
+	httpReq := testutils.NewMockJSONRequest(t, types.PostAckNotificationsRequest{IDs: []domain.EventID{41, 42}})
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, 2)
+
+	svc.On("Ack", mock.Anything, 2, []domain.EventID{41, 42}).Return(2, nil)
+
+	resp := h.postAckNotifications(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, &types.PostAckNotificationsResponse{Acked: 2}, resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestGetWS_ResumesAndAcks(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewNotification(t)
+	stream := mocks.NewEventStream(t)
+	h := NewNotificationHandler(testutils.NewDummyLogger(), svc, stream, time.Hour)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.getWS(w, testutils.AddUserIDToRequestContext(r, 2))
+	}))
+	defer srv.Close()
+
+	backlog := []domain.StreamEvent{
+		{ID: 41, Name: domain.StreamCoinRequestCreated, Data: json.RawMessage(`{"requestId":7}`)},
+		{ID: 42, Name: domain.StreamCoinsReceived, Data: json.RawMessage(`{"amount":50}`)},
+	}
+	live := make(chan domain.StreamEvent, 3)
+
+	stream.On("Subscribe", mock.Anything, 2).Return((<-chan domain.StreamEvent)(live))
+	svc.On("ListUnread", mock.Anything, 2, int64(40)).Return(backlog, nil)
+	svc.On("ListUnread", mock.Anything, 2, int64(42)).Return(nil, nil)
+	svc.On("Ack", mock.Anything, 2, []domain.EventID{41, 42, 44}).Return(3, nil)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws?lastEventId=40"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	var msg types.WSNotificationMessage
+	for _, exp := range backlog {
+		require.NoError(t, conn.ReadJSON(&msg))
+		require.Equal(t, *types.CreateWSNotificationMessage(exp), msg)
+	}
+
+	// own transfers aren't notifications and the backlog isn't sent twice
+	live <- domain.StreamEvent{ID: 43, Name: domain.StreamCoinsSent, Data: json.RawMessage(`{}`)}
+	live <- backlog[1]
+	live <- domain.StreamEvent{ID: 44, Name: domain.StreamMerchPurchased, Data: json.RawMessage(`{"item":"cup"}`)}
+
+	require.NoError(t, conn.ReadJSON(&msg))
+	require.Equal(t, domain.EventID(44), msg.ID)
+	require.Equal(t, domain.StreamMerchPurchased, msg.Kind)
+
+	require.NoError(t, conn.WriteJSON(types.WSClientMessage{Type: types.WSMessageAck, IDs: []domain.EventID{41, 42, 44}}))
+
+	var acked types.WSAckedMessage
+	require.NoError(t, conn.ReadJSON(&acked))
+	require.Equal(t, types.WSAckedMessage{Type: types.WSMessageAcked, IDs: []domain.EventID{41, 42, 44}, Acked: 3}, acked)
+
+	svc.AssertExpectations(t)
+	stream.AssertExpectations(t)
+}
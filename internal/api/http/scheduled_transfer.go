@@ -0,0 +1,197 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/http/handlers"
+	resp "avito_shop/pkg/http/responses"
+	pkglog "avito_shop/pkg/log"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type ScheduledTransferHandler struct {
+	logger  *slog.Logger
+	service usecases.ScheduledTransfer
+}
+
+func NewScheduledTransferHandler(logger *slog.Logger, service usecases.ScheduledTransfer) *ScheduledTransferHandler {
+	return &ScheduledTransferHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+const (
+	scheduledTransfersPath       = "/scheduledTransfers"
+	scheduledTransferPath        = "/scheduledTransfers/{id}"
+	getScheduledTransferRunsPath = "/scheduledTransfers/{id}/runs"
+)
+
+func (h *ScheduledTransferHandler) WithSecuredScheduledTransferHandlers(
+	authService usecases.Auth,
+) handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(libmiddleware.WithTokenAuth(authService))
+			handlers.AddHandler(r.Post, scheduledTransfersPath, h.postScheduledTransfer)
+			handlers.AddHandler(r.Get, scheduledTransfersPath, h.getScheduledTransfers)
+			handlers.AddHandler(r.Delete, scheduledTransferPath, h.deleteScheduledTransfer)
+			handlers.AddHandler(r.Get, getScheduledTransferRunsPath, h.getScheduledTransferRuns)
+		})
+	}
+}
+
+// @Summary	Запланировать разовый или регулярный перевод монет
+// @Security	BearerAuth
+// @Accept		json
+// @Produce	json
+// @Param		body	body		types.PostScheduledTransferRequest	true	"Параметры перевода"
+// @Success	200		{object}	types.ScheduledTransfer				"Созданный перевод"
+// @Failure	400		{object}	responses.ErrorResponse				"Неверный запрос"
+// @Failure	401		{object}	responses.ErrorResponse				"Неавторизован"
+// @Failure	500		{object}	responses.ErrorResponse				"Внутренняя ошибка сервера"
+// @Router		/scheduledTransfers [post]
+func (h *ScheduledTransferHandler) postScheduledTransfer(r *http.Request) resp.Response {
+	const op = "ScheduledTransferHandler.postScheduledTransfer"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreatePostScheduledTransferRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(domain.ErrBadRequest, nil)
+	}
+
+	created, err := h.service.CreateByName(r.Context(),
+		domain.ScheduledTransfer{
+			From:      uid,
+			Amount:    req.Amount,
+			Interval:  req.RunInterval,
+			NextRunAt: req.StartAt,
+		},
+		req.ToUser,
+	)
+	if err != nil {
+		log.Warn("error while scheduling transfer", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateScheduledTransferResponse(created))
+}
+
+// @Summary	Получить запланированные переводы
+// @Security	BearerAuth
+// @Produce	json
+// @Success	200	{object}	types.GetScheduledTransfersResponse	"Успешный ответ"
+// @Failure	401	{object}	responses.ErrorResponse				"Неавторизован"
+// @Failure	500	{object}	responses.ErrorResponse				"Внутренняя ошибка сервера"
+// @Router		/scheduledTransfers [get]
+func (h *ScheduledTransferHandler) getScheduledTransfers(r *http.Request) resp.Response {
+	const op = "ScheduledTransferHandler.getScheduledTransfers"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	sts, err := h.service.List(r.Context(), uid)
+	if err != nil {
+		log.Error("error while listing scheduled transfers", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateGetScheduledTransfersResponse(sts))
+}
+
+// @Summary	Отменить запланированный перевод
+// @Security	BearerAuth
+// @Produce	json
+// @Param		id	path	int	true	"Идентификатор перевода"
+// @Success	200	"Успешный ответ"
+// @Failure	400	{object}	responses.ErrorResponse	"Неверный запрос"
+// @Failure	401	{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure	500	{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
+// @Router		/scheduledTransfers/{id} [delete]
+func (h *ScheduledTransferHandler) deleteScheduledTransfer(r *http.Request) resp.Response {
+	const op = "ScheduledTransferHandler.deleteScheduledTransfer"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreateScheduledTransferIDRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	err = h.service.Cancel(r.Context(), uid, req.ID)
+	if err != nil {
+		log.Warn("error while cancelling scheduled transfer", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, nil)
+}
+
+// @Summary	Получить историю запусков запланированного перевода
+// @Security	BearerAuth
+// @Produce	json
+// @Param		id	path		int										true	"Идентификатор перевода"
+// @Success	200	{object}	types.GetScheduledTransferRunsResponse	"Успешный ответ"
+// @Failure	400	{object}	responses.ErrorResponse					"Неверный запрос"
+// @Failure	401	{object}	responses.ErrorResponse					"Неавторизован"
+// @Failure	500	{object}	responses.ErrorResponse					"Внутренняя ошибка сервера"
+// @Router		/scheduledTransfers/{id}/runs [get]
+func (h *ScheduledTransferHandler) getScheduledTransferRuns(r *http.Request) resp.Response {
+	const op = "ScheduledTransferHandler.getScheduledTransferRuns"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreateScheduledTransferIDRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	runs, err := h.service.ListRuns(r.Context(), uid, req.ID)
+	if err != nil {
+		log.Error("error while listing scheduled transfer runs", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateGetScheduledTransferRunsResponse(runs))
+}
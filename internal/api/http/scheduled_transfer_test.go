@@ -0,0 +1,135 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/testutils"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostScheduledTransfer_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewScheduledTransfer(t)
+	h := NewScheduledTransferHandler(testutils.NewDummyLogger(), svc)
+
+	uID := 2
+	req := types.PostScheduledTransferRequest{
+		ToUser:   "Avito",
+		Amount:   50,
+		Interval: "168h",
+	}
+	created := domain.ScheduledTransfer{ID: 1, ToName: req.ToUser, Amount: req.Amount, Active: true}
+
+	httpReq := testutils.NewMockJSONRequest(t, req)
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On(
+		"CreateByName",
+		mock.Anything,
+		domain.ScheduledTransfer{From: uID, Amount: req.Amount, Interval: 7 * 24 * time.Hour},
+		req.ToUser).Return(created, nil)
+
+	resp := h.postScheduledTransfer(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, types.CreateScheduledTransferResponse(created), resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestPostScheduledTransfer_ServiceErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Err     error
+		ExpCode int
+	}{
+		{"ToUser doesn't exist", domain.ErrUserNotFound, http.StatusNotFound},
+		{"Sending money to yourself", domain.ErrSelfSending, http.StatusUnprocessableEntity},
+		{"Unexpected DBError", errors.New("unexpected DBError"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		svc := mocks.NewScheduledTransfer(t)
+		h := NewScheduledTransferHandler(testutils.NewDummyLogger(), svc)
+
+		uID := 2
+		req := types.PostScheduledTransferRequest{ToUser: "Avito", Amount: 50}
+		httpReq := testutils.NewMockJSONRequest(t, req)
+		httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+		svc.On("CreateByName", mock.Anything, mock.Anything, req.ToUser).
+			Return(domain.ScheduledTransfer{}, test.Err)
+
+		resp := h.postScheduledTransfer(httpReq)
+
+		require.Equal(t, test.ExpCode, resp.StatusCode())
+		svc.AssertExpectations(t)
+	}
+}
+
+func TestDeleteScheduledTransfer_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewScheduledTransfer(t)
+	h := NewScheduledTransferHandler(testutils.NewDummyLogger(), svc)
+
+	uID := 2
+	httpReq := testutils.NewMockRequestWithURLParam("id", "1")
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On("Cancel", mock.Anything, uID, 1).Return(nil)
+
+	resp := h.deleteScheduledTransfer(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	svc.AssertExpectations(t)
+}
+
+func TestDeleteScheduledTransfer_NotFound(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewScheduledTransfer(t)
+	h := NewScheduledTransferHandler(testutils.NewDummyLogger(), svc)
+
+	uID := 2
+	httpReq := testutils.NewMockRequestWithURLParam("id", "1")
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On("Cancel", mock.Anything, uID, 1).Return(domain.ErrScheduledTransferNotFound)
+
+	resp := h.deleteScheduledTransfer(httpReq)
+
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	svc.AssertExpectations(t)
+}
+
+func TestGetScheduledTransferRuns_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewScheduledTransfer(t)
+	h := NewScheduledTransferHandler(testutils.NewDummyLogger(), svc)
+
+	uID := 2
+	runs := []domain.ScheduledTransferRun{
+		{TransferID: 1, Status: domain.ScheduledTransferFailed, Reason: domain.ErrLowBalance.Error()},
+	}
+	httpReq := testutils.NewMockRequestWithURLParam("id", "1")
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On("ListRuns", mock.Anything, uID, 1).Return(runs, nil)
+
+	resp := h.getScheduledTransferRuns(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, types.CreateGetScheduledTransferRunsResponse(runs), resp.GetPayload())
+	svc.AssertExpectations(t)
+}
@@ -50,8 +50,10 @@ func (h *TransactionHandler) WithSecuredTransactionHandlers(authService usecases
 // @Success	200		"Успешный ответ"
 // @Failure	400		{object}	responses.ErrorResponse	"Неверный запрос"
 // @Failure	401		{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure	404		{object}	responses.ErrorResponse	"Получатель не найден"
+// @Failure	422		{object}	responses.ErrorResponse	"Недостаточно монет или перевод самому себе"
 // @Failure	500		{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
-// @Router		/api/sendCoin [post]
+// @Router		/sendCoin [post]
 func (h *TransactionHandler) postSendCoin(r *http.Request) resp.Response {
 	const op = "TransactionHandler.postSendCoin"
 	uid, err := libmiddleware.GetUserIDFromContext(r)
@@ -94,8 +96,10 @@ func (h *TransactionHandler) postSendCoin(r *http.Request) resp.Response {
 // @Success	200		"Успешный ответ"
 // @Failure	400		{object}	responses.ErrorResponse	"Неверный запрос"
 // @Failure	401		{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure	404		{object}	responses.ErrorResponse	"Товар не найден"
+// @Failure	422		{object}	responses.ErrorResponse	"Недостаточно монет"
 // @Failure	500		{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
-// @Router		/api/buy/{item} [get]
+// @Router		/buy/{item} [get]
 func (h *TransactionHandler) getBuyItem(r *http.Request) resp.Response {
 	const op = "TransactionHandler.getBuyItem"
 	uid, err := libmiddleware.GetUserIDFromContext(r)
@@ -87,9 +87,9 @@ func TestPostSendCoin_ServiceErrors(t *testing.T) {
 		Err     error
 		ExpCode int
 	}{
-		{"ToUser doesn't exist", domain.ErrUserNotFound, http.StatusBadRequest},
-		{"Sending money to yourself", domain.ErrSelfSending, http.StatusBadRequest},
-		{"Low balance", domain.ErrLowBalance, http.StatusBadRequest},
+		{"ToUser doesn't exist", domain.ErrUserNotFound, http.StatusNotFound},
+		{"Sending money to yourself", domain.ErrSelfSending, http.StatusUnprocessableEntity},
+		{"Low balance", domain.ErrLowBalance, http.StatusUnprocessableEntity},
 		{"Unexpected DBError", errors.New("unexpected DBError"), http.StatusInternalServerError},
 	}
 
@@ -186,8 +186,8 @@ func TestGetBuyItemCoin_ServiceErrors(t *testing.T) {
 		Err     error
 		ExpCode int
 	}{
-		{"Item doesn't exist or was deleted", domain.ErrMerchNotFound, http.StatusBadRequest},
-		{"Low balance", domain.ErrLowBalance, http.StatusBadRequest},
+		{"Item doesn't exist or was deleted", domain.ErrMerchNotFound, http.StatusNotFound},
+		{"Low balance", domain.ErrLowBalance, http.StatusUnprocessableEntity},
 		{"Unexpected DBError", errors.New("unexpected DBError"), http.StatusInternalServerError},
 	}
 
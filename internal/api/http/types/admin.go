@@ -0,0 +1,232 @@
+package types
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/pkg/http/handlers"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PostReverseTransactionRequest struct {
+	ID     domain.TransactionID `json:"-"`
+	Reason string               `json:"reason"`
+	Force  bool                 `json:"force"`
+}
+
+func CreatePostReverseTransactionRequest(r *http.Request) (*PostReverseTransactionRequest, error) {
+	const queryParamName = "id"
+	id, err := strconv.Atoi(chi.URLParam(r, queryParamName))
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("CreatePostReverseTransactionRequest: invalid query provided: %w", domain.ErrBadRequest)
+	}
+
+	var req PostReverseTransactionRequest
+	err = handlers.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePostReverseTransactionRequest: error while decoding json: %w", err)
+	}
+
+	if len(req.Reason) == 0 {
+		return nil, errors.New("CreatePostReverseTransactionRequest: request field is missed")
+	}
+	req.ID = id
+
+	return &req, nil
+}
+
+type ReversalTransaction struct {
+	ID            domain.TransactionID `json:"id"`
+	Amount        int                  `json:"amount"`
+	CoveredByShop bool                 `json:"coveredByShop"`
+}
+
+type PostReverseTransactionResponse struct {
+	Transactions []ReversalTransaction `json:"transactions"`
+}
+
+func CreatePostReverseTransactionResponse(txs []domain.Transaction) *PostReverseTransactionResponse {
+	resp := &PostReverseTransactionResponse{
+		Transactions: make([]ReversalTransaction, 0, len(txs)),
+	}
+
+	for _, tx := range txs {
+		resp.Transactions = append(resp.Transactions, ReversalTransaction{
+			ID:            tx.ID,
+			Amount:        tx.Amount,
+			CoveredByShop: tx.From == repository.ShopDBID,
+		})
+	}
+
+	return resp
+}
+
+type PostMintRequest struct {
+	ToUser domain.UserName `json:"toUser"`
+	Amount int             `json:"amount"`
+	Reason string          `json:"reason"`
+}
+
+func CreatePostMintRequest(r *http.Request) (*PostMintRequest, error) {
+	var req PostMintRequest
+	err := handlers.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePostMintRequest: error while decoding json: %w", err)
+	}
+
+	if len(req.ToUser) == 0 || len(req.Reason) == 0 || req.Amount <= 0 {
+		return nil, errors.New("CreatePostMintRequest: invalid request field")
+	}
+
+	return &req, nil
+}
+
+// PostBurnRequest burns either Amount coins or, with All set, the whole balance.
+type PostBurnRequest struct {
+	FromUser   domain.UserName `json:"fromUser"`
+	Amount     int             `json:"amount"`
+	All        bool            `json:"all"`
+	Reason     string          `json:"reason"`
+	Deactivate bool            `json:"deactivate"`
+}
+
+func CreatePostBurnRequest(r *http.Request) (*PostBurnRequest, error) {
+	var req PostBurnRequest
+	err := handlers.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePostBurnRequest: error while decoding json: %w", err)
+	}
+
+	if len(req.FromUser) == 0 || len(req.Reason) == 0 {
+		return nil, errors.New("CreatePostBurnRequest: request field is missed")
+	}
+
+	if req.All == (req.Amount != 0) || req.Amount < 0 {
+		return nil, errors.New("CreatePostBurnRequest: either positive amount or all must be provided")
+	}
+
+	return &req, nil
+}
+
+type CoinAdjustmentResponse struct {
+	TransactionID domain.TransactionID `json:"transactionId"`
+	Amount        int                  `json:"amount"`
+}
+
+func CreateCoinAdjustmentResponse(tx domain.Transaction) *CoinAdjustmentResponse {
+	return &CoinAdjustmentResponse{
+		TransactionID: tx.ID,
+		Amount:        tx.Amount,
+	}
+}
+
+type PostAllowanceResponse struct {
+	Period  domain.AllowancePeriod `json:"period"`
+	Granted int                    `json:"granted"`
+}
+
+func CreatePostAllowanceResponse(grant domain.AllowanceGrant) *PostAllowanceResponse {
+	return &PostAllowanceResponse{
+		Period:  grant.Period,
+		Granted: grant.Granted,
+	}
+}
+
+type LedgerBalanceMismatch struct {
+	User   domain.UserName `json:"user"`
+	Stored int             `json:"stored"`
+	Posted int             `json:"posted"`
+}
+
+type GetLedgerVerificationResponse struct {
+	OK                bool                    `json:"ok"`
+	Postings          int                     `json:"postings"`
+	Total             int                     `json:"total"`
+	UnbalancedEntries []int                   `json:"unbalancedEntries"`
+	Mismatches        []LedgerBalanceMismatch `json:"mismatches"`
+}
+
+func CreateGetLedgerVerificationResponse(res domain.LedgerVerification) *GetLedgerVerificationResponse {
+	resp := &GetLedgerVerificationResponse{
+		OK:                res.OK(),
+		Postings:          res.Postings,
+		Total:             res.Total,
+		UnbalancedEntries: make([]int, 0, len(res.UnbalancedEntries)),
+		Mismatches:        make([]LedgerBalanceMismatch, 0, len(res.Mismatches)),
+	}
+
+	resp.UnbalancedEntries = append(resp.UnbalancedEntries, res.UnbalancedEntries...)
+	for _, m := range res.Mismatches {
+		resp.Mismatches = append(resp.Mismatches, LedgerBalanceMismatch{
+			User:   m.Name,
+			Stored: m.Stored,
+			Posted: m.Posted,
+		})
+	}
+
+	return resp
+}
+
+type ReconciliationIDRequest struct {
+	ID domain.ReconciliationID
+}
+
+func CreateReconciliationIDRequest(r *http.Request) (*ReconciliationIDRequest, error) {
+	const queryParamName = "id"
+	id, err := strconv.Atoi(chi.URLParam(r, queryParamName))
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("CreateReconciliationIDRequest: invalid query provided: %w", domain.ErrBadRequest)
+	}
+
+	return &ReconciliationIDRequest{ID: id}, nil
+}
+
+type BalanceDiscrepancy struct {
+	User     domain.UserName `json:"user"`
+	Stored   int             `json:"stored"`
+	Expected int             `json:"expected"`
+	Diff     int             `json:"diff"`
+	// CorrectionID is omitted until the report is applied and for the balances it left alone,
+	// the ones that changed since the dry run or are expected to be negative.
+	CorrectionID domain.TransactionID `json:"correctionId,omitempty"`
+}
+
+type ReconciliationResponse struct {
+	ID            domain.ReconciliationID `json:"id"`
+	StartedAt     time.Time               `json:"startedAt"`
+	Checked       int                     `json:"checked"`
+	Applied       bool                    `json:"applied"`
+	AppliedAt     *time.Time              `json:"appliedAt,omitempty"`
+	Discrepancies []BalanceDiscrepancy    `json:"discrepancies"`
+}
+
+func CreateReconciliationResponse(rec domain.Reconciliation) *ReconciliationResponse {
+	resp := &ReconciliationResponse{
+		ID:            rec.ID,
+		StartedAt:     rec.StartedAt,
+		Checked:       rec.Checked,
+		Applied:       rec.Applied(),
+		Discrepancies: make([]BalanceDiscrepancy, 0, len(rec.Discrepancies)),
+	}
+
+	if rec.Applied() {
+		resp.AppliedAt = &rec.AppliedAt
+	}
+
+	for _, d := range rec.Discrepancies {
+		resp.Discrepancies = append(resp.Discrepancies, BalanceDiscrepancy{
+			User:         d.Name,
+			Stored:       d.Stored,
+			Expected:     d.Expected,
+			Diff:         d.Diff(),
+			CorrectionID: d.Correction,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"avito_shop/pkg/testutils"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePostReverseTransactionRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	req := &PostReverseTransactionRequest{
+		Reason: "wrong recipient",
+		Force:  true,
+	}
+
+	httpReq := testutils.NewMockJSONRequest(t, req)
+	httpReq = testutils.AddURLParamToRequest(httpReq, "id", "7")
+
+	result, err := CreatePostReverseTransactionRequest(httpReq)
+
+	require.NoError(t, err)
+	require.Equal(t, 7, result.ID)
+	require.Equal(t, req.Reason, result.Reason)
+	require.True(t, result.Force)
+}
+
+func TestCreatePostReverseTransactionRequest_BadRequestCases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name string
+		Req  interface{}
+		ID   string
+	}{
+		{"Empty reason", PostReverseTransactionRequest{}, "7"},
+		{"Invalid id", PostReverseTransactionRequest{Reason: "wrong recipient"}, "abc"},
+		{"Broken JSON", []byte("{\"reason\":\"oops\""), "7"},
+	}
+
+	for _, test := range tests {
+		httpReq := testutils.NewMockJSONRequest(t, test.Req)
+		httpReq = testutils.AddURLParamToRequest(httpReq, "id", test.ID)
+
+		_, err := CreatePostReverseTransactionRequest(httpReq)
+
+		require.Error(t, err, test.Name)
+	}
+}
+
+func TestCreatePostMintRequest_BadRequestCases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name string
+		Req  interface{}
+	}{
+		{"Empty toUser", PostMintRequest{Amount: 100, Reason: "bonus"}},
+		{"Empty reason", PostMintRequest{ToUser: "Avito", Amount: 100}},
+		{"Negative amount", PostMintRequest{ToUser: "Avito", Amount: -100, Reason: "bonus"}},
+		{"Broken JSON", []byte("{\"toUser\":\"avito\"")},
+	}
+
+	for _, test := range tests {
+		httpReq := testutils.NewMockJSONRequest(t, test.Req)
+
+		_, err := CreatePostMintRequest(httpReq)
+
+		require.Error(t, err, test.Name)
+	}
+}
+
+func TestCreatePostBurnRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	tests := []PostBurnRequest{
+		{FromUser: "Avito", Amount: 100, Reason: "penalty"},
+		{FromUser: "Avito", All: true, Reason: "leaving", Deactivate: true},
+	}
+
+	for _, test := range tests {
+		httpReq := testutils.NewMockJSONRequest(t, test)
+
+		result, err := CreatePostBurnRequest(httpReq)
+
+		require.NoError(t, err)
+		require.Equal(t, &test, result)
+	}
+}
+
+func TestCreatePostBurnRequest_BadRequestCases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name string
+		Req  interface{}
+	}{
+		{"Neither amount nor all", PostBurnRequest{FromUser: "Avito", Reason: "leaving"}},
+		{"Both amount and all", PostBurnRequest{FromUser: "Avito", Amount: 10, All: true, Reason: "leaving"}},
+		{"Negative amount", PostBurnRequest{FromUser: "Avito", Amount: -10, Reason: "leaving"}},
+		{"Empty reason", PostBurnRequest{FromUser: "Avito", Amount: 10}},
+	}
+
+	for _, test := range tests {
+		httpReq := testutils.NewMockJSONRequest(t, test.Req)
+
+		_, err := CreatePostBurnRequest(httpReq)
+
+		require.Error(t, err, test.Name)
+	}
+}
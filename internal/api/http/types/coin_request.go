@@ -0,0 +1,84 @@
+package types
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/pkg/http/handlers"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PostCoinRequestRequest struct {
+	FromUser domain.UserName `json:"fromUser"`
+	Amount   int             `json:"amount"`
+}
+
+func CreatePostCoinRequestRequest(r *http.Request) (*PostCoinRequestRequest, error) {
+	var req PostCoinRequestRequest
+	err := handlers.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePostCoinRequestRequest: error while decoding json: %w", err)
+	}
+
+	if len(req.FromUser) == 0 || req.Amount <= 0 {
+		return nil, errors.New("CreatePostCoinRequestRequest: invalid request field")
+	}
+
+	return &req, nil
+}
+
+type CoinRequestIDRequest struct {
+	ID domain.CoinRequestID
+}
+
+func CreateCoinRequestIDRequest(r *http.Request) (*CoinRequestIDRequest, error) {
+	const queryParamName = "id"
+	id, err := strconv.Atoi(chi.URLParam(r, queryParamName))
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("CreateCoinRequestIDRequest: invalid query provided: %w", domain.ErrBadRequest)
+	}
+
+	return &CoinRequestIDRequest{ID: id}, nil
+}
+
+type CoinRequest struct {
+	ID        domain.CoinRequestID     `json:"id"`
+	Requester domain.UserName          `json:"requester"`
+	Payer     domain.UserName          `json:"payer"`
+	Amount    int                      `json:"amount"`
+	Status    domain.CoinRequestStatus `json:"status"`
+	CreatedAt time.Time                `json:"createdAt"`
+	ExpiresAt time.Time                `json:"expiresAt"`
+}
+
+func CreateCoinRequestResponse(req domain.CoinRequest) *CoinRequest {
+	return &CoinRequest{
+		ID:        req.ID,
+		Requester: req.RequesterName,
+		Payer:     req.PayerName,
+		Amount:    req.Amount,
+		Status:    req.Status,
+		CreatedAt: req.CreatedAt,
+		ExpiresAt: req.ExpiresAt,
+	}
+}
+
+type GetCoinRequestsResponse struct {
+	CoinRequests []CoinRequest `json:"coinRequests"`
+}
+
+func CreateGetCoinRequestsResponse(reqs []domain.CoinRequest) *GetCoinRequestsResponse {
+	resp := &GetCoinRequestsResponse{
+		CoinRequests: make([]CoinRequest, 0, len(reqs)),
+	}
+
+	for _, req := range reqs {
+		resp.CoinRequests = append(resp.CoinRequests, *CreateCoinRequestResponse(req))
+	}
+
+	return resp
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"avito_shop/pkg/testutils"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePostCoinRequestRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	req := &PostCoinRequestRequest{
+		FromUser: "Avito",
+		Amount:   100,
+	}
+
+	httpReq := testutils.NewMockJSONRequest(t, req)
+
+	result, err := CreatePostCoinRequestRequest(httpReq)
+
+	require.NoError(t, err)
+	require.Equal(t, req, result)
+}
+
+func TestCreatePostCoinRequestRequest_EmptyFromUser(t *testing.T) {
+	t.Parallel()
+
+	req := &PostCoinRequestRequest{
+		Amount: 100,
+	}
+
+	httpReq := testutils.NewMockJSONRequest(t, req)
+
+	_, err := CreatePostCoinRequestRequest(httpReq)
+
+	require.Error(t, err)
+}
+
+func TestCreatePostCoinRequestRequest_NegativeAmount(t *testing.T) {
+	t.Parallel()
+
+	req := &PostCoinRequestRequest{
+		FromUser: "Avito",
+		Amount:   -100,
+	}
+
+	httpReq := testutils.NewMockJSONRequest(t, req)
+
+	_, err := CreatePostCoinRequestRequest(httpReq)
+
+	require.Error(t, err)
+}
+
+func TestCreatePostCoinRequestRequest_BrokenJSON(t *testing.T) {
+	t.Parallel()
+
+	brokenJSON := []byte("{\"fromUser\":\"avito\",\"amount\":100")
+
+	httpReq := testutils.NewMockJSONRequest(t, brokenJSON)
+
+	_, err := CreatePostCoinRequestRequest(httpReq)
+
+	require.Error(t, err)
+}
+
+func TestCreateCoinRequestIDRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	httpReq := testutils.NewMockRequestWithURLParam("id", "42")
+
+	result, err := CreateCoinRequestIDRequest(httpReq)
+
+	require.NoError(t, err)
+	require.Equal(t, 42, result.ID)
+}
+
+func TestCreateCoinRequestIDRequest_InvalidID(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{"", "abc", "0", "-1"}
+
+	for _, id := range tests {
+		httpReq := testutils.NewMockRequestWithURLParam("id", id)
+
+		_, err := CreateCoinRequestIDRequest(httpReq)
+
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/locales"
+	"avito_shop/pkg/i18n"
+)
+
+type MerchItem struct {
+	Name        domain.MerchName `json:"name" example:"t-shirt"`
+	DisplayName string           `json:"displayName" example:"Футболка"`
+	Description string           `json:"description,omitempty"`
+	Price       int              `json:"price" example:"80"`
+}
+
+// CreateGetMerchResponse shows the items in the localizer's language. An item without a translation
+// is shown under its name, so a new item can go on sale before it's translated.
+func CreateGetMerchResponse(items []domain.Merch, localizer *i18n.Localizer) []MerchItem {
+	res := make([]MerchItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, MerchItem{
+			Name:        item.Name,
+			DisplayName: localizer.T(locales.MerchNameKey(item.Name), item.Name),
+			Description: localizer.T(locales.MerchDescriptionKey(item.Name), ""),
+			Price:       item.Price,
+		})
+	}
+
+	return res
+}
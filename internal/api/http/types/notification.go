@@ -0,0 +1,149 @@
+package types
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/pkg/http/handlers"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// AfterEventRequest reads the optional event id a client has already seen,
+// named after for pages and lastEventId for resumed connections.
+type AfterEventRequest struct {
+	After domain.EventID
+}
+
+func CreateAfterEventRequest(r *http.Request, queryParamName string) (*AfterEventRequest, error) {
+	param := r.URL.Query().Get(queryParamName)
+	if param == "" {
+		return &AfterEventRequest{}, nil
+	}
+
+	after, err := strconv.ParseInt(param, 10, 64)
+	if err != nil || after < 0 {
+		return nil, fmt.Errorf("CreateAfterEventRequest: invalid query provided: %w", domain.ErrBadRequest)
+	}
+
+	return &AfterEventRequest{After: after}, nil
+}
+
+type PostAckNotificationsRequest struct {
+	IDs []domain.EventID `json:"ids" example:"41,42"`
+}
+
+func CreatePostAckNotificationsRequest(r *http.Request) (*PostAckNotificationsRequest, error) {
+	var req PostAckNotificationsRequest
+	err := handlers.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePostAckNotificationsRequest: error while decoding json: %w", err)
+	}
+
+	if len(req.IDs) == 0 {
+		return nil, errors.New("CreatePostAckNotificationsRequest: request field is missed")
+	}
+
+	return &req, nil
+}
+
+type Notification struct {
+	ID        domain.EventID         `json:"id"`
+	Kind      domain.StreamEventName `json:"kind" example:"coinsReceived"`
+	Data      json.RawMessage        `json:"data" swaggertype:"object"`
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+func CreateNotificationResponse(e domain.StreamEvent) *Notification {
+	return &Notification{
+		ID:        e.ID,
+		Kind:      e.Name,
+		Data:      e.Data,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+type GetNotificationsResponse struct {
+	Notifications []Notification `json:"notifications"`
+}
+
+func CreateGetNotificationsResponse(notifications []domain.StreamEvent) *GetNotificationsResponse {
+	resp := &GetNotificationsResponse{
+		Notifications: make([]Notification, 0, len(notifications)),
+	}
+
+	for _, e := range notifications {
+		resp.Notifications = append(resp.Notifications, *CreateNotificationResponse(e))
+	}
+
+	return resp
+}
+
+type PostAckNotificationsResponse struct {
+	Acked int `json:"acked"`
+}
+
+const (
+	WSMessageNotification = "notification"
+	WSMessageAck          = "ack"
+	WSMessageAcked        = "acked"
+	WSMessageError        = "error"
+)
+
+// WSClientMessage is what the client sends over /api/ws, only acks for now.
+type WSClientMessage struct {
+	Type string           `json:"type"`
+	IDs  []domain.EventID `json:"ids"`
+}
+
+type WSNotificationMessage struct {
+	Type string `json:"type"`
+	Notification
+}
+
+func CreateWSNotificationMessage(e domain.StreamEvent) *WSNotificationMessage {
+	return &WSNotificationMessage{
+		Type:         WSMessageNotification,
+		Notification: *CreateNotificationResponse(e),
+	}
+}
+
+type WSAckedMessage struct {
+	Type  string           `json:"type"`
+	IDs   []domain.EventID `json:"ids"`
+	Acked int              `json:"acked"`
+}
+
+type WSErrorMessage struct {
+	Type  string `json:"type"`
+	Error string `json:"error"`
+}
+
+// EmailPreferences with an empty email turns notification emails off.
+type EmailPreferences struct {
+	// Email must be in the corporate domain.
+	Email         string `json:"email" example:"alice@avito.ru"`
+	CoinsReceived bool   `json:"coinsReceived"`
+	// MerchPurchased is the "order is ready" email, merch is handed out right after the purchase.
+	MerchPurchased bool `json:"merchPurchased"`
+}
+
+func CreatePutEmailPreferencesRequest(r *http.Request) (*EmailPreferences, error) {
+	var req EmailPreferences
+	err := handlers.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePutEmailPreferencesRequest: error while decoding json: %w", err)
+	}
+
+	return &req, nil
+}
+
+func CreateEmailPreferencesResponse(prefs domain.EmailPreferences) *EmailPreferences {
+	return &EmailPreferences{
+		Email:          prefs.Address,
+		CoinsReceived:  prefs.CoinsReceived,
+		MerchPurchased: prefs.MerchPurchased,
+	}
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/pkg/testutils"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAfterEventRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name     string
+		Query    string
+		ExpAfter domain.EventID
+		ExpErr   bool
+	}{
+		{"Missing", "", 0, false},
+		{"Valid", "?lastEventId=42", 42, false},
+		{"Negative", "?lastEventId=-1", 0, true},
+		{"Not a number", "?lastEventId=abc", 0, true},
+	}
+
+	for _, test := range tests {
+		req, err := CreateAfterEventRequest(httptest.NewRequest("GET", "/ws"+test.Query, nil), "lastEventId")
+
+		if test.ExpErr {
+			require.ErrorIs(t, err, domain.ErrBadRequest, test.Name)
+			continue
+		}
+		require.NoError(t, err, test.Name)
+		require.Equal(t, test.ExpAfter, req.After, test.Name)
+	}
+}
+
+func TestCreatePostAckNotificationsRequest_BadRequestCases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name string
+		Req  interface{}
+	}{
+		{"No ids", PostAckNotificationsRequest{}},
+		{"Broken JSON", []byte("{\"ids\":[1,")},
+	}
+
+	for _, test := range tests {
+		_, err := CreatePostAckNotificationsRequest(testutils.NewMockJSONRequest(t, test.Req))
+
+		require.Error(t, err, test.Name)
+	}
+}
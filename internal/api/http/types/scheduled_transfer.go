@@ -0,0 +1,128 @@
+package types
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/pkg/http/handlers"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const MinScheduledTransferInterval = time.Minute
+
+type PostScheduledTransferRequest struct {
+	ToUser   domain.UserName `json:"toUser"`
+	Amount   int             `json:"amount"`
+	StartAt  time.Time       `json:"startAt"`
+	Interval string          `json:"interval" example:"168h"`
+
+	RunInterval time.Duration `json:"-"`
+}
+
+func CreatePostScheduledTransferRequest(r *http.Request) (*PostScheduledTransferRequest, error) {
+	var req PostScheduledTransferRequest
+	err := handlers.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePostScheduledTransferRequest: error while decoding json: %w", err)
+	}
+
+	if len(req.ToUser) == 0 || req.Amount <= 0 {
+		return nil, errors.New("CreatePostScheduledTransferRequest: invalid request field")
+	}
+
+	if req.Interval != "" {
+		req.RunInterval, err = time.ParseDuration(req.Interval)
+		if err != nil || req.RunInterval < MinScheduledTransferInterval {
+			return nil, errors.New("CreatePostScheduledTransferRequest: invalid interval")
+		}
+	}
+
+	return &req, nil
+}
+
+type ScheduledTransferIDRequest struct {
+	ID domain.ScheduledTransferID
+}
+
+func CreateScheduledTransferIDRequest(r *http.Request) (*ScheduledTransferIDRequest, error) {
+	const queryParamName = "id"
+	id, err := strconv.Atoi(chi.URLParam(r, queryParamName))
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("CreateScheduledTransferIDRequest: invalid query provided: %w", domain.ErrBadRequest)
+	}
+
+	return &ScheduledTransferIDRequest{ID: id}, nil
+}
+
+type ScheduledTransfer struct {
+	ID        domain.ScheduledTransferID `json:"id"`
+	ToUser    domain.UserName            `json:"toUser"`
+	Amount    int                        `json:"amount"`
+	Interval  string                     `json:"interval,omitempty"`
+	NextRunAt time.Time                  `json:"nextRunAt"`
+	Active    bool                       `json:"active"`
+	CreatedAt time.Time                  `json:"createdAt"`
+}
+
+func CreateScheduledTransferResponse(st domain.ScheduledTransfer) *ScheduledTransfer {
+	resp := &ScheduledTransfer{
+		ID:        st.ID,
+		ToUser:    st.ToName,
+		Amount:    st.Amount,
+		NextRunAt: st.NextRunAt,
+		Active:    st.Active,
+		CreatedAt: st.CreatedAt,
+	}
+
+	if st.Interval > 0 {
+		resp.Interval = st.Interval.String()
+	}
+
+	return resp
+}
+
+type GetScheduledTransfersResponse struct {
+	ScheduledTransfers []ScheduledTransfer `json:"scheduledTransfers"`
+}
+
+func CreateGetScheduledTransfersResponse(sts []domain.ScheduledTransfer) *GetScheduledTransfersResponse {
+	resp := &GetScheduledTransfersResponse{
+		ScheduledTransfers: make([]ScheduledTransfer, 0, len(sts)),
+	}
+
+	for _, st := range sts {
+		resp.ScheduledTransfers = append(resp.ScheduledTransfers, *CreateScheduledTransferResponse(st))
+	}
+
+	return resp
+}
+
+type ScheduledTransferRun struct {
+	Status domain.ScheduledTransferRunStatus `json:"status"`
+	Reason string                            `json:"reason,omitempty"`
+	RunAt  time.Time                         `json:"runAt"`
+}
+
+type GetScheduledTransferRunsResponse struct {
+	Runs []ScheduledTransferRun `json:"runs"`
+}
+
+func CreateGetScheduledTransferRunsResponse(runs []domain.ScheduledTransferRun) *GetScheduledTransferRunsResponse {
+	resp := &GetScheduledTransferRunsResponse{
+		Runs: make([]ScheduledTransferRun, 0, len(runs)),
+	}
+
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, ScheduledTransferRun{
+			Status: run.Status,
+			Reason: run.Reason,
+			RunAt:  run.RunAt,
+		})
+	}
+
+	return resp
+}
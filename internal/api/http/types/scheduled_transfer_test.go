@@ -0,0 +1,65 @@
+package types
+
+import (
+	"avito_shop/pkg/testutils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePostScheduledTransferRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	req := &PostScheduledTransferRequest{
+		ToUser:   "Avito",
+		Amount:   50,
+		Interval: "168h",
+	}
+
+	httpReq := testutils.NewMockJSONRequest(t, req)
+
+	result, err := CreatePostScheduledTransferRequest(httpReq)
+
+	require.NoError(t, err)
+	require.Equal(t, 7*24*time.Hour, result.RunInterval)
+}
+
+func TestCreatePostScheduledTransferRequest_OneOff(t *testing.T) {
+	t.Parallel()
+
+	req := &PostScheduledTransferRequest{
+		ToUser: "Avito",
+		Amount: 50,
+	}
+
+	httpReq := testutils.NewMockJSONRequest(t, req)
+
+	result, err := CreatePostScheduledTransferRequest(httpReq)
+
+	require.NoError(t, err)
+	require.Zero(t, result.RunInterval)
+}
+
+func TestCreatePostScheduledTransferRequest_BadRequestCases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name string
+		Req  interface{}
+	}{
+		{"Empty toUser", PostScheduledTransferRequest{Amount: 50}},
+		{"Negative amount", PostScheduledTransferRequest{ToUser: "Avito", Amount: -50}},
+		{"Broken interval", PostScheduledTransferRequest{ToUser: "Avito", Amount: 50, Interval: "weekly"}},
+		{"Too short interval", PostScheduledTransferRequest{ToUser: "Avito", Amount: 50, Interval: "1s"}},
+		{"Broken JSON", []byte("{\"toUser\":\"avito\",\"amount\":50")},
+	}
+
+	for _, test := range tests {
+		httpReq := testutils.NewMockJSONRequest(t, test.Req)
+
+		_, err := CreatePostScheduledTransferRequest(httpReq)
+
+		require.Error(t, err, test.Name)
+	}
+}
@@ -1,6 +1,11 @@
 package types
 
-import "avito_shop/internal/domain"
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/pkg/http/handlers"
+	"fmt"
+	"net/http"
+)
 
 type GetInfoResponse struct {
 	Coins       int                `json:"coins"`
@@ -16,11 +21,13 @@ type CoinHistory struct {
 type CoinHistoryIncoming struct {
 	FromUser domain.UserName `json:"fromUser"`
 	Amount   int             `json:"amount"`
+	Reason   string          `json:"reason,omitempty"`
 }
 
 type CoinHistoryUpcoming struct {
 	ToUser domain.UserName `json:"ToUser"`
 	Amount int             `json:"amount"`
+	Reason string          `json:"reason,omitempty"`
 }
 
 func CreateGetInfoResponse(info domain.UserInfo) *GetInfoResponse {
@@ -32,12 +39,14 @@ func CreateGetInfoResponse(info domain.UserInfo) *GetInfoResponse {
 			recTx := CoinHistoryIncoming{
 				FromUser: tx.OtherUser,
 				Amount:   tx.Amount,
+				Reason:   tx.Reason,
 			}
 			incoming = append(incoming, recTx)
 		} else {
 			sentTx := CoinHistoryUpcoming{
 				ToUser: tx.OtherUser,
 				Amount: tx.Amount,
+				Reason: tx.Reason,
 			}
 			upcoming = append(upcoming, sentTx)
 		}
@@ -52,3 +61,18 @@ func CreateGetInfoResponse(info domain.UserInfo) *GetInfoResponse {
 		},
 	}
 }
+
+type LanguagePreference struct {
+	// Empty when the user follows Accept-Language.
+	Language domain.Language `json:"language" example:"ru"`
+}
+
+func CreatePutLanguageRequest(r *http.Request) (*LanguagePreference, error) {
+	var req LanguagePreference
+	err := handlers.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePutLanguageRequest: error while decoding json: %w", err)
+	}
+
+	return &req, nil
+}
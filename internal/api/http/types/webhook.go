@@ -0,0 +1,165 @@
+package types
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/pkg/http/handlers"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PostWebhookRequest struct {
+	URL        string             `json:"url" example:"https://chat.example.com/hooks/shop"`
+	Secret     string             `json:"secret"`
+	EventTypes []domain.EventType `json:"eventTypes" example:"CoinsSent,MerchPurchased"`
+}
+
+func CreatePostWebhookRequest(r *http.Request) (*PostWebhookRequest, error) {
+	var req PostWebhookRequest
+	err := handlers.DecodeRequest(r, &req)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePostWebhookRequest: error while decoding json: %w", err)
+	}
+
+	if len(req.URL) == 0 || len(req.Secret) == 0 || len(req.EventTypes) == 0 {
+		return nil, errors.New("CreatePostWebhookRequest: request field is missed")
+	}
+
+	return &req, nil
+}
+
+type WebhookIDRequest struct {
+	ID domain.WebhookID
+}
+
+func CreateWebhookIDRequest(r *http.Request) (*WebhookIDRequest, error) {
+	const queryParamName = "id"
+	id, err := strconv.Atoi(chi.URLParam(r, queryParamName))
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("CreateWebhookIDRequest: invalid query provided: %w", domain.ErrBadRequest)
+	}
+
+	return &WebhookIDRequest{ID: id}, nil
+}
+
+type WebhookDeliveryIDRequest struct {
+	ID domain.WebhookDeliveryID
+}
+
+func CreateWebhookDeliveryIDRequest(r *http.Request) (*WebhookDeliveryIDRequest, error) {
+	const queryParamName = "id"
+	id, err := strconv.ParseInt(chi.URLParam(r, queryParamName), 10, 64)
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("CreateWebhookDeliveryIDRequest: invalid query provided: %w", domain.ErrBadRequest)
+	}
+
+	return &WebhookDeliveryIDRequest{ID: id}, nil
+}
+
+// Webhook never carries the secret back.
+type Webhook struct {
+	ID         domain.WebhookID   `json:"id"`
+	URL        string             `json:"url"`
+	EventTypes []domain.EventType `json:"eventTypes"`
+	CreatedAt  time.Time          `json:"createdAt"`
+}
+
+func CreateWebhookResponse(sub domain.WebhookSubscription) *Webhook {
+	return &Webhook{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: sub.EventTypes,
+		CreatedAt:  sub.CreatedAt,
+	}
+}
+
+type GetWebhooksResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+func CreateGetWebhooksResponse(subs []domain.WebhookSubscription) *GetWebhooksResponse {
+	resp := &GetWebhooksResponse{
+		Webhooks: make([]Webhook, 0, len(subs)),
+	}
+
+	for _, sub := range subs {
+		resp.Webhooks = append(resp.Webhooks, *CreateWebhookResponse(sub))
+	}
+
+	return resp
+}
+
+type WebhookAttempt struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"durationMs"`
+}
+
+type WebhookDelivery struct {
+	ID            domain.WebhookDeliveryID     `json:"id"`
+	EventID       domain.EventID               `json:"eventId"`
+	EventType     domain.EventType             `json:"eventType"`
+	Payload       json.RawMessage              `json:"payload" swaggertype:"object"`
+	Status        domain.WebhookDeliveryStatus `json:"status"`
+	Attempts      int                          `json:"attempts"`
+	NextAttemptAt *time.Time                   `json:"nextAttemptAt,omitempty"`
+	LastError     string                       `json:"lastError,omitempty"`
+	CreatedAt     time.Time                    `json:"createdAt"`
+	DeliveredAt   *time.Time                   `json:"deliveredAt,omitempty"`
+	AttemptLog    []WebhookAttempt             `json:"attemptLog"`
+}
+
+func CreateWebhookDeliveryResponse(d domain.WebhookDelivery) *WebhookDelivery {
+	resp := &WebhookDelivery{
+		ID:         d.ID,
+		EventID:    d.Event.ID,
+		EventType:  d.Event.Type,
+		Payload:    d.Event.Payload,
+		Status:     d.Status,
+		Attempts:   d.Attempts,
+		LastError:  d.LastError,
+		CreatedAt:  d.CreatedAt,
+		AttemptLog: make([]WebhookAttempt, 0, len(d.Log)),
+	}
+
+	if d.Status == domain.WebhookDeliveryPending {
+		resp.NextAttemptAt = &d.NextAttemptAt
+	}
+
+	if d.Status == domain.WebhookDeliveryDelivered {
+		resp.DeliveredAt = &d.DeliveredAt
+	}
+
+	for _, a := range d.Log {
+		resp.AttemptLog = append(resp.AttemptLog, WebhookAttempt{
+			At:         a.At,
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+			DurationMS: a.Duration.Milliseconds(),
+		})
+	}
+
+	return resp
+}
+
+type GetWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+func CreateGetWebhookDeliveriesResponse(deliveries []domain.WebhookDelivery) *GetWebhookDeliveriesResponse {
+	resp := &GetWebhookDeliveriesResponse{
+		Deliveries: make([]WebhookDelivery, 0, len(deliveries)),
+	}
+
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, *CreateWebhookDeliveryResponse(d))
+	}
+
+	return resp
+}
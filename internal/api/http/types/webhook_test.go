@@ -0,0 +1,62 @@
+package types
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/pkg/testutils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePostWebhookRequest_BadRequestCases(t *testing.T) {
+	t.Parallel()
+
+	eventTypes := []domain.EventType{domain.EventCoinsSent}
+
+	tests := []struct {
+		Name string
+		Req  interface{}
+	}{
+		{"Empty URL", PostWebhookRequest{Secret: "s3cr3t", EventTypes: eventTypes}},
+		{"Empty secret", PostWebhookRequest{URL: "https://example.com", EventTypes: eventTypes}},
+		{"No event types", PostWebhookRequest{URL: "https://example.com", Secret: "s3cr3t"}},
+		{"Broken JSON", []byte("{\"url\":\"https://example.com\"")},
+	}
+
+	for _, test := range tests {
+		httpReq := testutils.NewMockJSONRequest(t, test.Req)
+
+		_, err := CreatePostWebhookRequest(httpReq)
+
+		require.Error(t, err, test.Name)
+	}
+}
+
+func TestCreateWebhookDeliveryResponse(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	d := domain.WebhookDelivery{
+		ID:            7,
+		Event:         domain.Event{ID: 42, Type: domain.EventCoinsSent},
+		Status:        domain.WebhookDeliveryDelivered,
+		Attempts:      2,
+		NextAttemptAt: at,
+		CreatedAt:     at,
+		DeliveredAt:   at.Add(time.Minute),
+		Log: []domain.WebhookAttempt{
+			{At: at, StatusCode: 503, Error: "unexpected status", Duration: 120 * time.Millisecond},
+			{At: at.Add(time.Minute), StatusCode: 200, Duration: 80 * time.Millisecond},
+		},
+	}
+
+	resp := CreateWebhookDeliveryResponse(d)
+
+	require.Nil(t, resp.NextAttemptAt)
+	require.Equal(t, d.DeliveredAt, *resp.DeliveredAt)
+	require.Len(t, resp.AttemptLog, 2)
+	require.Equal(t, int64(120), resp.AttemptLog[0].DurationMS)
+	require.Empty(t, resp.AttemptLog[1].Error)
+}
@@ -39,14 +39,17 @@ func (h *UserHandler) WithSecuredUserHandlers(authService usecases.Auth) handler
 }
 
 // @Summary Получить информацию о монетах, инвентаре и истории транзакций
+// @Description Заменён на /api/v2/info.
+// @Tags v1
 // @Security BearerAuth
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} types.GetInfoResponse "Успешный ответ"
 // @Failure 400 {object} responses.ErrorResponse "Неверный запрос"
 // @Failure 401 {object} responses.ErrorResponse "Неавторизован"
+// @Failure 404 {object} responses.ErrorResponse "Пользователь не найден"
 // @Failure 500 {object} responses.ErrorResponse "Внутренняя ошибка сервера"
-// @Router /api/info [get]
+// @Router /info [get]
 func (h *UserHandler) getInfo(r *http.Request) resp.Response {
 	const op = "UserHandler.getInfo"
 	uid, err := libmiddleware.GetUserIDFromContext(r)
@@ -61,7 +61,7 @@ func TestGetInfo_UserDeletedBefore(t *testing.T) {
 
 	resp := h.getInfo(httpReq)
 
-	require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
 	svc.AssertExpectations(t)
 }
 
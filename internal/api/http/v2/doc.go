@@ -0,0 +1,13 @@
+// Package v2 serves the routes whose shapes changed in /api/v2, the rest of v2 reuses the v1 handlers.
+// Its docs are generated apart from v1, see the generate_docs target of the Makefile.
+package v2
+
+//	@title						API Avito Shop
+//	@version					2.0.0
+//	@description				Serves the v1 routes under /api/v2, GET /info answers in the shapes fixed in v2.
+//	@schemes					http
+//	@host						localhost:8080
+//	@BasePath					/api/v2
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
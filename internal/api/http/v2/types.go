@@ -0,0 +1,69 @@
+package v2
+
+import (
+	"avito_shop/internal/domain"
+	"time"
+)
+
+// GetInfoResponse differs from v1 in the casing of CoinHistoryOutgoing.ToUser
+// and in the time of every history entry.
+type GetInfoResponse struct {
+	Coins       int                `json:"coins"`
+	Inventory   []domain.Inventory `json:"inventory"`
+	CoinHistory CoinHistory        `json:"coinHistory"`
+}
+
+type CoinHistory struct {
+	Received []CoinHistoryIncoming `json:"received"`
+	Sent     []CoinHistoryOutgoing `json:"sent"`
+}
+
+type CoinHistoryIncoming struct {
+	FromUser  domain.UserName `json:"fromUser"`
+	Amount    int             `json:"amount"`
+	Reason    string          `json:"reason,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type CoinHistoryOutgoing struct {
+	ToUser    domain.UserName `json:"toUser"`
+	Amount    int             `json:"amount"`
+	Reason    string          `json:"reason,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// CreateGetInfoResponse answers empty lists rather than nulls, v1 clients had to handle both.
+func CreateGetInfoResponse(info domain.UserInfo) *GetInfoResponse {
+	resp := &GetInfoResponse{
+		Coins:     info.Coins,
+		Inventory: info.Inventory,
+		CoinHistory: CoinHistory{
+			Received: []CoinHistoryIncoming{},
+			Sent:     []CoinHistoryOutgoing{},
+		},
+	}
+
+	if resp.Inventory == nil {
+		resp.Inventory = []domain.Inventory{}
+	}
+
+	for _, tx := range info.Transactions {
+		if tx.Direction == domain.Received {
+			resp.CoinHistory.Received = append(resp.CoinHistory.Received, CoinHistoryIncoming{
+				FromUser:  tx.OtherUser,
+				Amount:    tx.Amount,
+				Reason:    tx.Reason,
+				CreatedAt: tx.CreatedAt,
+			})
+		} else {
+			resp.CoinHistory.Sent = append(resp.CoinHistory.Sent, CoinHistoryOutgoing{
+				ToUser:    tx.OtherUser,
+				Amount:    tx.Amount,
+				Reason:    tx.Reason,
+				CreatedAt: tx.CreatedAt,
+			})
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,42 @@
+package v2
+
+import (
+	"avito_shop/internal/domain"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGetInfoResponse(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	info := domain.UserInfo{
+		Coins: 900,
+		Transactions: []domain.UserTransaction{
+			{OtherUser: "alice", Amount: 100, Direction: domain.Sent, CreatedAt: at},
+			{OtherUser: "bob", Amount: 50, Direction: domain.Received, CreatedAt: at},
+		},
+	}
+
+	expected := &GetInfoResponse{
+		Coins:     900,
+		Inventory: []domain.Inventory{},
+		CoinHistory: CoinHistory{
+			Received: []CoinHistoryIncoming{{FromUser: "bob", Amount: 50, CreatedAt: at}},
+			Sent:     []CoinHistoryOutgoing{{ToUser: "alice", Amount: 100, CreatedAt: at}},
+		},
+	}
+	require.Equal(t, expected, CreateGetInfoResponse(info))
+}
+
+func TestCreateGetInfoResponse_EmptyLists(t *testing.T) {
+	t.Parallel()
+
+	body, err := json.Marshal(CreateGetInfoResponse(domain.UserInfo{Coins: 1000}))
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"coins":1000,"inventory":[],"coinHistory":{"received":[],"sent":[]}}`, string(body))
+}
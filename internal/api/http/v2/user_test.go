@@ -0,0 +1,57 @@
+package v2
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/testutils"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetInfo_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewUser(t)
+	h := NewUserHandler(testutils.NewDummyLogger(), svc)
+
+	uID := 2
+	httpReq := testutils.AddUserIDToRequestContext(testutils.NewMockRequest(), uID)
+
+	svc.On("GetInfoByID", mock.Anything, uID).
+		Return(domain.UserInfo{Coins: 100}, nil)
+
+	resp := h.getInfo(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, CreateGetInfoResponse(domain.UserInfo{Coins: 100}), resp.GetPayload())
+}
+
+func TestGetInfo_UserNotFound(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewUser(t)
+	h := NewUserHandler(testutils.NewDummyLogger(), svc)
+
+	uID := 2
+	httpReq := testutils.AddUserIDToRequestContext(testutils.NewMockRequest(), uID)
+
+	svc.On("GetInfoByID", mock.Anything, uID).
+		Return(domain.UserInfo{}, domain.ErrUserNotFound)
+
+	resp := h.getInfo(httpReq)
+
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+}
+
+func TestGetInfo_NoUser(t *testing.T) {
+	t.Parallel()
+
+	h := NewUserHandler(testutils.NewDummyLogger(), nil)
+
+	resp := h.getInfo(testutils.NewMockRequest())
+
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode())
+}
@@ -0,0 +1,251 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/http/handlers"
+	resp "avito_shop/pkg/http/responses"
+	pkglog "avito_shop/pkg/log"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type WebhookHandler struct {
+	logger  *slog.Logger
+	service usecases.Webhook
+}
+
+func NewWebhookHandler(logger *slog.Logger, service usecases.Webhook) *WebhookHandler {
+	return &WebhookHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+const (
+	webhooksPath             = "/admin/webhooks"
+	webhookPath              = "/admin/webhooks/{id}"
+	getWebhookDeliveriesPath = "/admin/webhooks/{id}/deliveries"
+	postRedeliverWebhookPath = "/admin/webhooks/deliveries/{id}/redeliver"
+)
+
+func (h *WebhookHandler) WithWebhookHandlers(authService usecases.Auth) handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(libmiddleware.WithTokenAuth(authService))
+			r.Use(libmiddleware.WithAdminAuth(authService))
+			handlers.AddHandler(r.Post, webhooksPath, h.postWebhook)
+			handlers.AddHandler(r.Get, webhooksPath, h.getWebhooks)
+			handlers.AddHandler(r.Delete, webhookPath, h.deleteWebhook)
+			handlers.AddHandler(r.Get, getWebhookDeliveriesPath, h.getWebhookDeliveries)
+			handlers.AddHandler(r.Post, postRedeliverWebhookPath, h.postRedeliverWebhook)
+		})
+	}
+}
+
+// @Summary		Подписать URL на события магазина
+// @Description	Каждое событие отправляется POST-запросом с JSON {id, type, createdAt, payload}.
+// @Description	Заголовок X-Webhook-Signature содержит "sha256=" и hex HMAC-SHA256 строки
+// @Description	"<X-Webhook-Timestamp>.<тело запроса>", посчитанный на секрете подписки.
+// @Description	Неуспешные доставки повторяются с экспоненциальной задержкой.
+// @Security		BearerAuth
+// @Accept			json
+// @Produce		json
+// @Param			body	body		types.PostWebhookRequest	true	"URL, секрет и типы событий (CoinsSent, MerchPurchased)"
+// @Success		200		{object}	types.Webhook				"Созданная подписка"
+// @Failure		400		{object}	responses.ErrorResponse		"Неверный запрос"
+// @Failure		401		{object}	responses.ErrorResponse		"Неавторизован"
+// @Failure		403		{object}	responses.ErrorResponse		"Недостаточно прав"
+// @Failure		500		{object}	responses.ErrorResponse		"Внутренняя ошибка сервера"
+// @Router			/admin/webhooks [post]
+func (h *WebhookHandler) postWebhook(r *http.Request) resp.Response {
+	const op = "WebhookHandler.postWebhook"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreatePostWebhookRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(domain.ErrBadRequest, nil)
+	}
+
+	sub, err := h.service.Create(r.Context(), domain.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		CreatedBy:  uid,
+	})
+	if err != nil {
+		log.Warn("error while creating webhook", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	log.Info("webhook created", slog.Int("webhook_id", sub.ID), slog.Any("event_types", sub.EventTypes))
+
+	return domain.HandleResult(nil, types.CreateWebhookResponse(sub))
+}
+
+// @Summary	Получить список подписок на события
+// @Security	BearerAuth
+// @Produce	json
+// @Success	200	{object}	types.GetWebhooksResponse	"Успешный ответ"
+// @Failure	401	{object}	responses.ErrorResponse		"Неавторизован"
+// @Failure	403	{object}	responses.ErrorResponse		"Недостаточно прав"
+// @Failure	500	{object}	responses.ErrorResponse		"Внутренняя ошибка сервера"
+// @Router		/admin/webhooks [get]
+func (h *WebhookHandler) getWebhooks(r *http.Request) resp.Response {
+	const op = "WebhookHandler.getWebhooks"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	subs, err := h.service.List(r.Context())
+	if err != nil {
+		log.Error("error while listing webhooks", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateGetWebhooksResponse(subs))
+}
+
+// @Summary	Удалить подписку вместе с журналом доставок
+// @Security	BearerAuth
+// @Produce	json
+// @Param		id	path	int	true	"Идентификатор подписки"
+// @Success	200	"Успешный ответ"
+// @Failure	400	{object}	responses.ErrorResponse	"Неверный запрос"
+// @Failure	401	{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure	403	{object}	responses.ErrorResponse	"Недостаточно прав"
+// @Failure	500	{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
+// @Router		/admin/webhooks/{id} [delete]
+func (h *WebhookHandler) deleteWebhook(r *http.Request) resp.Response {
+	const op = "WebhookHandler.deleteWebhook"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreateWebhookIDRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	err = h.service.Delete(r.Context(), req.ID)
+	if err != nil {
+		log.Warn("error while deleting webhook", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	log.Info("webhook deleted", slog.Int("webhook_id", req.ID))
+
+	return domain.HandleResult(nil, nil)
+}
+
+// @Summary	Получить журнал последних доставок подписки
+// @Security	BearerAuth
+// @Produce	json
+// @Param		id	path		int									true	"Идентификатор подписки"
+// @Success	200	{object}	types.GetWebhookDeliveriesResponse	"Доставки с попытками, от новых к старым"
+// @Failure	400	{object}	responses.ErrorResponse				"Неверный запрос"
+// @Failure	401	{object}	responses.ErrorResponse				"Неавторизован"
+// @Failure	403	{object}	responses.ErrorResponse				"Недостаточно прав"
+// @Failure	500	{object}	responses.ErrorResponse				"Внутренняя ошибка сервера"
+// @Router		/admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) getWebhookDeliveries(r *http.Request) resp.Response {
+	const op = "WebhookHandler.getWebhookDeliveries"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreateWebhookIDRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	deliveries, err := h.service.ListDeliveries(r.Context(), req.ID)
+	if err != nil {
+		log.Warn("error while listing webhook deliveries", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	return domain.HandleResult(nil, types.CreateGetWebhookDeliveriesResponse(deliveries))
+}
+
+// @Summary		Повторно отправить событие доставки
+// @Description	Создаёт новую доставку того же события, она будет отправлена асинхронно.
+// @Security		BearerAuth
+// @Produce		json
+// @Param			id	path		int						true	"Идентификатор доставки"
+// @Success		200	{object}	types.WebhookDelivery	"Новая доставка"
+// @Failure		400	{object}	responses.ErrorResponse	"Неверный запрос"
+// @Failure		401	{object}	responses.ErrorResponse	"Неавторизован"
+// @Failure		403	{object}	responses.ErrorResponse	"Недостаточно прав"
+// @Failure		500	{object}	responses.ErrorResponse	"Внутренняя ошибка сервера"
+// @Router			/admin/webhooks/deliveries/{id}/redeliver [post]
+func (h *WebhookHandler) postRedeliverWebhook(r *http.Request) resp.Response {
+	const op = "WebhookHandler.postRedeliverWebhook"
+	uid, err := libmiddleware.GetUserIDFromContext(r)
+	if err != nil {
+		return domain.HandleResult(err, nil)
+	}
+
+	log := h.logger.With(
+		slog.String("op", op),
+		slog.String("request_id", middleware.GetReqID(r.Context())),
+		slog.Int("user_id", uid),
+	)
+
+	req, err := types.CreateWebhookDeliveryIDRequest(r)
+	if err != nil {
+		log.Warn("error while forming request", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	d, err := h.service.Redeliver(r.Context(), req.ID)
+	if err != nil {
+		log.Warn("error while redelivering webhook", pkglog.Err(err))
+		return domain.HandleResult(err, nil)
+	}
+
+	log.Info("webhook delivery queued again",
+		slog.Int64("delivery_id", req.ID),
+		slog.Int64("new_delivery_id", d.ID),
+	)
+
+	return domain.HandleResult(nil, types.CreateWebhookDeliveryResponse(d))
+}
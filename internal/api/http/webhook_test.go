@@ -0,0 +1,122 @@
+package http
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/testutils"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostWebhook_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewWebhook(t)
+	h := NewWebhookHandler(testutils.NewDummyLogger(), svc)
+
+	uID := 1
+	req := types.PostWebhookRequest{
+		URL:        "https://chat.example.com/hooks/shop",
+		Secret:     "s3cr3t",
+		EventTypes: []domain.EventType{domain.EventCoinsSent},
+	}
+	sub := domain.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		CreatedBy:  uID,
+	}
+	created := sub
+	created.ID = 3
+	created.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	httpReq := testutils.NewMockJSONRequest(t, req)
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, uID)
+
+	svc.On("Create", mock.Anything, sub).Return(created, nil)
+
+	resp := h.postWebhook(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, types.CreateWebhookResponse(created), resp.GetPayload())
+	svc.AssertExpectations(t)
+}
+
+func TestPostWebhook_ServiceErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name    string
+		Err     error
+		ExpCode int
+	}{
+		{"Invalid subscription", domain.ErrBadRequest, http.StatusBadRequest},
+		{"Unexpected DBError", errors.New("unexpected DBError"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		svc := mocks.NewWebhook(t)
+		h := NewWebhookHandler(testutils.NewDummyLogger(), svc)
+
+		httpReq := testutils.NewMockJSONRequest(t, types.PostWebhookRequest{
+			URL:        "https://chat.example.com/hooks/shop",
+			Secret:     "s3cr3t",
+			EventTypes: []domain.EventType{"CoinsBurnt"},
+		})
+		httpReq = testutils.AddUserIDToRequestContext(httpReq, 1)
+
+		svc.On("Create", mock.Anything, mock.Anything).Return(domain.WebhookSubscription{}, test.Err)
+
+		resp := h.postWebhook(httpReq)
+
+		require.Equal(t, test.ExpCode, resp.StatusCode(), test.Name)
+		svc.AssertExpectations(t)
+	}
+}
+
+func TestGetWebhookDeliveries_NotFound(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewWebhook(t)
+	h := NewWebhookHandler(testutils.NewDummyLogger(), svc)
+
+	httpReq := testutils.NewMockRequestWithURLParam("id", "9")
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, 1)
+
+	svc.On("ListDeliveries", mock.Anything, 9).Return(nil, domain.ErrWebhookNotFound)
+
+	resp := h.getWebhookDeliveries(httpReq)
+
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	svc.AssertExpectations(t)
+}
+
+func TestPostRedeliverWebhook_Success(t *testing.T) {
+	t.Parallel()
+
+	svc := mocks.NewWebhook(t)
+	h := NewWebhookHandler(testutils.NewDummyLogger(), svc)
+
+	d := domain.WebhookDelivery{
+		ID:     12,
+		Event:  domain.Event{ID: 42, Type: domain.EventMerchPurchased},
+		Status: domain.WebhookDeliveryPending,
+	}
+
+	httpReq := testutils.NewMockRequestWithURLParam("id", "7")
+	httpReq = testutils.AddUserIDToRequestContext(httpReq, 1)
+
+	svc.On("Redeliver", mock.Anything, int64(7)).Return(d, nil)
+
+	resp := h.postRedeliverWebhook(httpReq)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, types.CreateWebhookDeliveryResponse(d), resp.GetPayload())
+	svc.AssertExpectations(t)
+}
@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	apigrpc "avito_shop/internal/api/grpc"
+	"avito_shop/internal/config"
+	"avito_shop/internal/lib/interceptors"
+	"avito_shop/internal/usecases"
+	shopv1 "avito_shop/pkg/api/shop/v1"
+	pkginterceptors "avito_shop/pkg/grpc/interceptors"
+	"context"
+	"log/slog"
+	"net"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+type App struct {
+	log     *slog.Logger
+	server  *grpc.Server
+	address string
+}
+
+func New(
+	log *slog.Logger,
+	authService usecases.Auth,
+	userService usecases.User,
+	txService usecases.Transaction,
+	cfg config.GRPCConfig,
+) *App {
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			pkginterceptors.NewRecoveryInterceptor(log),
+			pkginterceptors.NewLoggingInterceptor(log),
+			interceptors.WithTokenAuth(authService, shopv1.AuthService_Login_FullMethodName),
+		),
+		grpc.ConnectionTimeout(cfg.ConnectionTimeout),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle: cfg.IdleTimeout,
+		}),
+	)
+
+	shopv1.RegisterAuthServiceServer(server, apigrpc.NewAuthServer(log, authService))
+	shopv1.RegisterShopServiceServer(server, apigrpc.NewShopServer(log, userService, txService))
+
+	return &App{
+		log:     log,
+		server:  server,
+		address: cfg.Address,
+	}
+}
+
+func (a *App) Run() error {
+	const op = "grpc.App"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("address", a.address),
+	)
+
+	lis, err := net.Listen("tcp", a.address)
+	if err != nil {
+		return err
+	}
+
+	log.Info("gRPC server starting")
+	return a.server.Serve(lis)
+}
+
+// Stop waits for the running calls to finish, the ones still running when ctx is done are cancelled.
+func (a *App) Stop(ctx context.Context) error {
+	const op = "grpc.Stop"
+	log := a.log.With(slog.String("op", op))
+
+	log.Info("gRPC server shutting down", slog.String("addr", a.address))
+
+	stopped := make(chan struct{})
+	go func() {
+		a.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		a.server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"avito_shop/internal/config"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/http/handlers"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const debugPath = "/debug"
+
+// newAdminServer serves the debug endpoints on a listener of their own, so they are never exposed
+// together with the API. Bound to a loopback address they are open to anyone on the host,
+// bound to any other address they require an admin token.
+func newAdminServer(log *slog.Logger, authService usecases.Auth, cfg config.HTTPConfig) *http.Server {
+	opts := []handlers.RouterOption{
+		handlers.WithRecover(),
+		handlers.WithLogging(log),
+	}
+
+	if !isLoopback(cfg.AdminAddress) {
+		opts = append(opts, func(r chi.Router) {
+			r.Use(libmiddleware.WithTokenAuth(authService))
+			r.Use(libmiddleware.WithAdminAuth(authService))
+		})
+	}
+
+	opts = append(opts, handlers.WithDebugHandlers(time.Now()))
+
+	// profiles and traces take as long as they are asked to, so there is no write timeout
+	return &http.Server{
+		Addr:        cfg.AdminAddress,
+		Handler:     handlers.NewHandler(debugPath, opts...),
+		ReadTimeout: cfg.ReadTimeout,
+		IdleTimeout: cfg.IdleTimeout,
+	}
+}
+
+func isLoopback(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
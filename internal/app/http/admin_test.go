@@ -0,0 +1,74 @@
+package http
+
+import (
+	"avito_shop/internal/config"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/testutils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func serveAdmin(t *testing.T, srv *http.Server, path, token string) int {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+func TestAdminServer_Loopback(t *testing.T) {
+	t.Parallel()
+
+	srv := newAdminServer(testutils.NewDummyLogger(), mocks.NewAuth(t), config.HTTPConfig{
+		AdminAddress: "127.0.0.1:6060",
+	})
+
+	for _, path := range []string{"/debug/pprof/", "/debug/vars", "/debug/build", "/debug/runtime"} {
+		require.Equal(t, http.StatusOK, serveAdmin(t, srv, path, ""), path)
+	}
+}
+
+func TestAdminServer_RequiresAdmin(t *testing.T) {
+	t.Parallel()
+
+	auth := mocks.NewAuth(t)
+	srv := newAdminServer(testutils.NewDummyLogger(), auth, config.HTTPConfig{
+		AdminAddress: ":6060",
+	})
+
+	auth.On("ParseToken", "admin").Return(1, nil)
+	auth.On("ParseToken", "user").Return(2, nil)
+	auth.On("IsAdmin", mock.Anything, 1).Return(true, nil)
+	auth.On("IsAdmin", mock.Anything, 2).Return(false, nil)
+
+	require.Equal(t, http.StatusUnauthorized, serveAdmin(t, srv, "/debug/runtime", ""))
+	require.Equal(t, http.StatusForbidden, serveAdmin(t, srv, "/debug/runtime", "user"))
+	require.Equal(t, http.StatusOK, serveAdmin(t, srv, "/debug/runtime", "admin"))
+	require.Equal(t, http.StatusOK, serveAdmin(t, srv, "/debug/pprof/cmdline", "admin"))
+}
+
+func TestIsLoopback(t *testing.T) {
+	t.Parallel()
+
+	for address, want := range map[string]bool{
+		"localhost:6060": true,
+		"127.0.0.1:6060": true,
+		"[::1]:6060":     true,
+		":6060":          false,
+		"0.0.0.0:6060":   false,
+		"10.0.0.5:6060":  false,
+		"localhost":      false,
+	} {
+		require.Equal(t, want, isLoopback(address), address)
+	}
+}
@@ -1,18 +1,36 @@
 package http
 
 import (
+	"avito_shop/docs"
+	docsv2 "avito_shop/docs/v2"
+	apigraphql "avito_shop/internal/api/graphql"
 	apihttp "avito_shop/internal/api/http"
+	apihttpv2 "avito_shop/internal/api/http/v2"
 	"avito_shop/internal/config"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/locales"
 	"avito_shop/internal/usecases"
+	"avito_shop/pkg/health"
 	"avito_shop/pkg/http/handlers"
+	pkgmiddleware "avito_shop/pkg/http/middleware"
+	"avito_shop/pkg/idempotency"
+	"avito_shop/pkg/ratelimit"
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type App struct {
-	log    *slog.Logger
-	server *http.Server
+	log        *slog.Logger
+	server     *http.Server
+	admin      *http.Server
+	checker    *health.Checker
+	drainDelay time.Duration
 }
 
 func New(
@@ -21,66 +39,210 @@ func New(
 	authService usecases.Auth,
 	userService usecases.User,
 	txService usecases.Transaction,
+	coinRequestService usecases.CoinRequest,
+	scheduledTransferService usecases.ScheduledTransfer,
+	treasuryService usecases.Treasury,
+	ledgerService usecases.Ledger,
+	reconService usecases.Reconciliation,
+	webhookService usecases.Webhook,
+	eventStreamService usecases.EventStream,
+	notificationService usecases.Notification,
+	emailService usecases.Email,
+	merchService usecases.Merch,
+	historyService usecases.History,
+	checker *health.Checker,
+	limiter ratelimit.Limiter,
+	idempotencyStore idempotency.Store,
 	cfg config.HTTPConfig,
 ) *App {
-	authHandler := apihttp.NewAuthHandler(
+	authHandler := apihttp.NewAuthHandler(log, authService)
+
+	userHandler := apihttp.NewUserHandler(log, userService)
+
+	languageHandler := apihttp.NewLanguageHandler(log, userService)
+
+	txHandler := apihttp.NewTransactionHandler(log, txService)
+
+	coinRequestHandler := apihttp.NewCoinRequestHandler(log, coinRequestService)
+
+	scheduledTransferHandler := apihttp.NewScheduledTransferHandler(log, scheduledTransferService)
+
+	adminHandler := apihttp.NewAdminHandler(
 		log,
-		authService,
+		txService,
+		treasuryService,
+		ledgerService,
+		reconService,
 	)
 
-	userHandler := apihttp.NewUserHandler(
+	webhookHandler := apihttp.NewWebhookHandler(log, webhookService)
+
+	eventHandler := apihttp.NewEventHandler(
 		log,
-		userService,
+		eventStreamService,
+		cfg.StreamHeartbeat,
+	)
+
+	notificationHandler := apihttp.NewNotificationHandler(
+		log,
+		notificationService,
+		eventStreamService,
+		cfg.StreamHeartbeat,
 	)
 
-	txHandler := apihttp.NewTransactionHandler(
+	emailHandler := apihttp.NewEmailHandler(log, emailService)
+
+	merchHandler := apihttp.NewMerchHandler(log, merchService)
+
+	graphqlHandler := apigraphql.NewHandler(
 		log,
+		userService,
 		txService,
+		merchService,
+		historyService,
+		cfg.GraphQL,
 	)
 
-	publicHandler := handlers.NewHandler(
-		apiPath,
-		handlers.WithRequestID(),
-		handlers.WithRecover(),
-		handlers.WithLogging(log),
-		handlers.WithProfilerHandlers(),
-		handlers.WithHealthHandler(),
-		handlers.WithSwagger(),
-		userHandler.WithSecuredUserHandlers(authService),
+	shared := []handlers.RouterOption{
+		languageHandler.WithSecuredLanguageHandlers(authService),
 		txHandler.WithSecuredTransactionHandlers(authService),
+		coinRequestHandler.WithSecuredCoinRequestHandlers(authService),
+		scheduledTransferHandler.WithSecuredScheduledTransferHandlers(authService),
+		eventHandler.WithSecuredEventHandlers(authService),
+		notificationHandler.WithSecuredNotificationHandlers(authService),
+		emailHandler.WithSecuredEmailHandlers(authService),
+		merchHandler.WithSecuredMerchHandlers(authService),
+		graphqlHandler.WithSecuredGraphQLHandlers(authService),
 		authHandler.WithAuthHandlers(),
+		adminHandler.WithAdminHandlers(authService),
+		webhookHandler.WithWebhookHandlers(authService),
+	}
+
+	publicHandler := handlers.NewHandler(
+		apiPath,
+		withMiddlewares(log, authService, userService, limiter, idempotencyStore, cfg),
+		handlers.WithHealthHandlers(checker),
+		handlers.WithSwagger(docs.SwaggerInfo.InstanceName()),
+		withAPIVersions(
+			apiPath,
+			cfg.V1,
+			userHandler.WithSecuredUserHandlers(authService),
+			apihttpv2.NewUserHandler(log, userService).WithSecuredUserHandlers(authService),
+			shared,
+		),
 	)
 
 	srv := &http.Server{
 		Addr:         cfg.Address,
-		Handler:      publicHandler,
+		Handler:      withMetricsEndpoint(publicHandler),
 		ReadTimeout:  cfg.ReadTimeout,
 		WriteTimeout: cfg.WriteTimeout,
 		IdleTimeout:  cfg.IdleTimeout,
 	}
 
 	return &App{
-		log:    log,
-		server: srv,
+		log:        log,
+		server:     srv,
+		admin:      newAdminServer(log, authService, cfg),
+		checker:    checker,
+		drainDelay: cfg.DrainDelay,
 	}
 }
 
+// withMiddlewares are what every request goes through, the language, the rate limit and the idempotency keys
+// depend on the user the optional auth identifies.
+func withMiddlewares(
+	log *slog.Logger,
+	authService usecases.Auth,
+	userService usecases.User,
+	limiter ratelimit.Limiter,
+	idempotencyStore idempotency.Store,
+	cfg config.HTTPConfig,
+) handlers.RouterOption {
+	return handlers.RouterOptions(
+		handlers.WithRequestID(),
+		handlers.WithMetrics(),
+		handlers.WithTracing(),
+		handlers.WithRecover(),
+		handlers.WithLogging(log),
+		handlers.WithMiddlewares(libmiddleware.WithOptionalTokenAuth(authService)),
+		handlers.WithLanguage(locales.MustBundle(), libmiddleware.LanguagePreference(userService)),
+		handlers.WithRateLimit(log, limiter, libmiddleware.RateLimitKey, cfg.RateLimit),
+		handlers.WithIdempotency(log, idempotencyStore, libmiddleware.RateLimitKey, cfg.Idempotency),
+	)
+}
+
+// withAPIVersions serves v1 from the base path itself, deprecated in favour of v2 served under /v2.
+// Both serve the shared routes as they are, v1 and v2 hold the routes the versions serve differently.
+func withAPIVersions(
+	apiPath string,
+	cfg config.APIV1Config,
+	v1, v2 handlers.RouterOption,
+	shared []handlers.RouterOption,
+) handlers.RouterOption {
+	deprecation := pkgmiddleware.Deprecation{
+		Since:     cfg.Since,
+		Sunset:    cfg.Sunset,
+		Successor: apiPath + "/v2",
+	}
+
+	return handlers.RouterOptions(
+		handlers.WithVersion("", append([]handlers.RouterOption{handlers.WithDeprecation(deprecation), v1}, shared...)...),
+		handlers.WithVersion("/v2", append([]handlers.RouterOption{
+			handlers.WithSwagger(docsv2.SwaggerInfov2.InstanceName()),
+			v2,
+		}, shared...)...),
+	)
+}
+
+// withMetricsEndpoint serves the metrics from the root, next to the API rather than inside it,
+// so scrapes neither go through the API middlewares nor show up in the request metrics.
+func withMetricsEndpoint(api http.Handler) http.Handler {
+	root := chi.NewRouter()
+	root.Handle("/metrics", promhttp.Handler())
+	root.Mount("/", api)
+
+	return root
+}
+
+// Run serves the API and the admin endpoints, it returns as soon as either server fails.
 func (a *App) Run() error {
 	const op = "http.App"
 
 	log := a.log.With(
 		slog.String("op", op),
 		slog.String("address", a.server.Addr),
+		slog.String("admin_address", a.admin.Addr),
 	)
 
 	log.Info("HTTP server starting")
-	return a.server.ListenAndServe()
+
+	errs := make(chan error, 2)
+	go func() { errs <- a.server.ListenAndServe() }()
+	go func() { errs <- a.admin.ListenAndServe() }()
+
+	return <-errs
 }
 
 func (a *App) Stop(ctx context.Context) error {
 	const op = "http.Stop"
 	log := a.log.With(slog.String("op", op))
 
+	// load balancers see the instance not ready and stop sending traffic before the listener is closed
+	a.checker.Drain()
+	log.Info("HTTP server draining", slog.String("addr", a.server.Addr), slog.Duration("delay", a.drainDelay))
+
+	timer := time.NewTimer(a.drainDelay)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+	case <-timer.C:
+	}
+
 	log.Info("HTTP server shutting down", slog.String("addr", a.server.Addr))
-	return a.server.Shutdown(ctx)
+
+	// the admin endpoints stay up while the API drains, so a stuck shutdown can still be profiled
+	err := a.server.Shutdown(ctx)
+
+	return errors.Join(err, a.admin.Shutdown(ctx))
 }
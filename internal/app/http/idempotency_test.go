@@ -0,0 +1,159 @@
+package http
+
+import (
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/http/handlers"
+	"avito_shop/pkg/http/responses"
+	"avito_shop/pkg/idempotency"
+	"avito_shop/pkg/testutils"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+var testIdempotencyConfig = idempotency.Config{Enabled: true, TTL: time.Hour, Lease: time.Minute}
+
+func newIdempotentHandler(t *testing.T, handler http.HandlerFunc) http.Handler {
+	t.Helper()
+
+	auth := mocks.NewAuth(t)
+	auth.On("ParseToken", "alice").Return(1, nil).Maybe()
+	auth.On("ParseToken", "bob").Return(2, nil).Maybe()
+
+	return handlers.NewHandler(
+		"/api",
+		handlers.WithMiddlewares(libmiddleware.WithOptionalTokenAuth(auth)),
+		handlers.WithIdempotency(
+			testutils.NewDummyLogger(),
+			idempotency.NewMemoryStore(),
+			libmiddleware.RateLimitKey,
+			testIdempotencyConfig,
+		),
+		func(r chi.Router) {
+			r.Post("/sendCoin", handler)
+			r.Get("/buy/{item}", handler)
+		},
+	)
+}
+
+func doIdempotent(h http.Handler, method, path, token, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", token)
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestIdempotency_Replay(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	h := newIdempotentHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		n := runs.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"run":` + strconv.Itoa(int(n)) + `}`))
+	})
+
+	body := `{"toUser":"bob","amount":10}`
+
+	rec := doIdempotent(h, http.MethodPost, "/api/sendCoin", "alice", "k1", body)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Header().Get("Idempotent-Replayed"))
+
+	// the retry gets the first response without running the request again
+	rec = doIdempotent(h, http.MethodPost, "/api/sendCoin", "alice", "k1", body)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "true", rec.Header().Get("Idempotent-Replayed"))
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"run":1}`, rec.Body.String())
+	require.Equal(t, int32(1), runs.Load())
+
+	// the keys of another user are their own, requests without a key always run
+	require.JSONEq(t, `{"run":2}`, doIdempotent(h, http.MethodPost, "/api/sendCoin", "bob", "k1", body).Body.String())
+	require.JSONEq(t, `{"run":3}`, doIdempotent(h, http.MethodPost, "/api/sendCoin", "alice", "", body).Body.String())
+
+	// GET /buy/{item} changes state too, the key is taken on it as well
+	doIdempotent(h, http.MethodGet, "/api/buy/cup", "alice", "k2", "")
+	rec = doIdempotent(h, http.MethodGet, "/api/buy/cup", "alice", "k2", "")
+	require.Equal(t, "true", rec.Header().Get("Idempotent-Replayed"))
+	require.Equal(t, int32(4), runs.Load())
+}
+
+func TestIdempotency_KeyReused(t *testing.T) {
+	t.Parallel()
+
+	h := newIdempotentHandler(t, func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	require.Equal(t, http.StatusOK,
+		doIdempotent(h, http.MethodPost, "/api/sendCoin", "alice", "k1", `{"amount":10}`).Code)
+
+	rec := doIdempotent(h, http.MethodPost, "/api/sendCoin", "alice", "k1", `{"amount":20}`)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var problem responses.ErrorResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	require.Equal(t, responses.CodeIdempotencyKeyReused, problem.Code)
+
+	rec = doIdempotent(h, http.MethodPost, "/api/sendCoin", "alice", strings.Repeat("k", 256), `{}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestIdempotency_FailedRequestRunsAgain(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+	h := newIdempotentHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		if runs.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	require.Equal(t, http.StatusServiceUnavailable,
+		doIdempotent(h, http.MethodPost, "/api/sendCoin", "alice", "k1", `{}`).Code)
+	require.Equal(t, http.StatusOK,
+		doIdempotent(h, http.MethodPost, "/api/sendCoin", "alice", "k1", `{}`).Code)
+	require.Equal(t, int32(2), runs.Load())
+}
+
+func TestIdempotency_InFlight(t *testing.T) {
+	t.Parallel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	h := newIdempotentHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	first := make(chan int)
+	go func() {
+		first <- doIdempotent(h, http.MethodPost, "/api/sendCoin", "alice", "k1", `{}`).Code
+	}()
+	<-started
+
+	rec := doIdempotent(h, http.MethodPost, "/api/sendCoin", "alice", "k1", `{}`)
+	require.Equal(t, http.StatusConflict, rec.Code)
+
+	var problem responses.ErrorResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	require.Equal(t, responses.CodeIdempotencyKeyInUse, problem.Code)
+
+	close(release)
+	require.Equal(t, http.StatusOK, <-first)
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"avito_shop/internal/domain"
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/locales"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/http/handlers"
+	"avito_shop/pkg/http/responses"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguage_Problem(t *testing.T) {
+	t.Parallel()
+
+	auth := mocks.NewAuth(t)
+	auth.On("ParseToken", "alice").Return(1, nil).Maybe()
+	auth.On("ParseToken", "bob").Return(2, nil).Maybe()
+
+	users := mocks.NewUser(t)
+	users.On("GetLanguage", mock.Anything, 1).Return(domain.LanguageEnglish, nil).Once()
+	users.On("GetLanguage", mock.Anything, 2).Return("", nil).Once()
+
+	h := handlers.NewHandler(
+		"/api",
+		handlers.WithMiddlewares(libmiddleware.WithOptionalTokenAuth(auth)),
+		handlers.WithLanguage(locales.MustBundle(), libmiddleware.LanguagePreference(users)),
+		func(r chi.Router) {
+			handlers.AddHandler(r.Get, "/fail", func(*http.Request) responses.Response {
+				return domain.HandleResult(domain.ErrLowBalance, nil)
+			})
+			handlers.AddHandler(r.Get, "/ok", func(*http.Request) responses.Response {
+				return domain.HandleResult(nil, nil)
+			})
+		},
+	)
+
+	tests := []struct {
+		Name      string
+		Path      string
+		Token     string
+		ExpDetail string
+	}{
+		{"Anonymous", "/api/fail", "", "Недостаточно монет на балансе"},
+		{"Preference wins", "/api/fail", "alice", domain.ErrLowBalance.Error()},
+		{"No preference", "/api/fail", "bob", "Недостаточно монет на балансе"},
+		// the preference is not looked up when nothing is translated, GetLanguage would be called once more
+		{"Nothing translated", "/api/ok", "alice", ""},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, test.Path, nil)
+		req.Header.Set("Accept-Language", "ru-RU,ru;q=0.9,en;q=0.8")
+		if test.Token != "" {
+			req.Header.Set("Authorization", test.Token)
+		}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		require.Contains(t, rec.Header().Values("Vary"), "Accept-Language", test.Name)
+		if test.ExpDetail == "" {
+			require.Equal(t, http.StatusOK, rec.Code, test.Name)
+			continue
+		}
+
+		var problem responses.ErrorResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem), test.Name)
+		require.Equal(t, "LOW_BALANCE", problem.Code, test.Name)
+		require.Equal(t, test.ExpDetail, problem.Detail, test.Name)
+	}
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	libmiddleware "avito_shop/internal/lib/middleware"
+	"avito_shop/internal/usecases/mocks"
+	"avito_shop/pkg/http/handlers"
+	"avito_shop/pkg/ratelimit"
+	"avito_shop/pkg/testutils"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitedHandler(t *testing.T, cfg ratelimit.Config) http.Handler {
+	t.Helper()
+
+	auth := mocks.NewAuth(t)
+	auth.On("ParseToken", "alice").Return(1, nil).Maybe()
+	auth.On("ParseToken", "bob").Return(2, nil).Maybe()
+	auth.On("ParseToken", "forged").Return(0, errors.New("invalid token")).Maybe()
+
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	return handlers.NewHandler(
+		"/api",
+		handlers.WithMiddlewares(libmiddleware.WithOptionalTokenAuth(auth)),
+		handlers.WithRateLimit(testutils.NewDummyLogger(), ratelimit.NewMemoryLimiter(), libmiddleware.RateLimitKey, cfg),
+		func(r chi.Router) {
+			r.Get("/info", ok)
+			r.Get("/buy/{item}", ok)
+		},
+	)
+}
+
+func doRateLimited(h http.Handler, path, token, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remoteAddr
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestRateLimit_PerUserAndIP(t *testing.T) {
+	t.Parallel()
+
+	h := newRateLimitedHandler(t, ratelimit.Config{Enabled: true, Rate: 1, Burst: 2})
+
+	rec := doRateLimited(h, "/api/info", "alice", "10.0.0.1:5000")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "1", rec.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "1", rec.Header().Get("RateLimit-Reset"))
+
+	// the user is the same from any address
+	require.Equal(t, http.StatusOK, doRateLimited(h, "/api/info", "alice", "10.0.0.2:5000").Code)
+
+	rec = doRateLimited(h, "/api/info", "alice", "10.0.0.3:5000")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "1", rec.Header().Get("Retry-After"))
+	require.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+
+	// other users and anonymous clients have buckets of their own, a forged token counts as anonymous
+	require.Equal(t, http.StatusOK, doRateLimited(h, "/api/info", "bob", "10.0.0.1:5000").Code)
+	require.Equal(t, http.StatusOK, doRateLimited(h, "/api/info", "", "10.0.0.1:5000").Code)
+	require.Equal(t, http.StatusOK, doRateLimited(h, "/api/info", "forged", "10.0.0.1:5000").Code)
+	require.Equal(t, http.StatusTooManyRequests, doRateLimited(h, "/api/info", "", "10.0.0.1:6000").Code)
+	require.Equal(t, http.StatusOK, doRateLimited(h, "/api/info", "", "10.0.0.9:5000").Code)
+}
+
+func TestRateLimit_RouteOverride(t *testing.T) {
+	t.Parallel()
+
+	h := newRateLimitedHandler(t, ratelimit.Config{
+		Enabled: true,
+		Rate:    1,
+		Burst:   5,
+		Routes: map[string]ratelimit.Limit{
+			"GET /api/buy/{item}": {Rate: 1, Burst: 1},
+		},
+	})
+
+	// every item goes to the same route bucket
+	require.Equal(t, http.StatusOK, doRateLimited(h, "/api/buy/cup", "alice", "10.0.0.1:5000").Code)
+	rec := doRateLimited(h, "/api/buy/pen", "alice", "10.0.0.1:5000")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+
+	// the override doesn't spend the default bucket
+	rec = doRateLimited(h, "/api/info", "alice", "10.0.0.1:5000")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "4", rec.Header().Get("RateLimit-Remaining"))
+}
+
+func TestRateLimit_Disabled(t *testing.T) {
+	t.Parallel()
+
+	h := newRateLimitedHandler(t, ratelimit.Config{Enabled: false, Rate: 1, Burst: 1})
+
+	for range 3 {
+		rec := doRateLimited(h, "/api/info", "alice", "10.0.0.1:5000")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	}
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"avito_shop/internal/config"
+	"avito_shop/pkg/http/handlers"
+	"avito_shop/pkg/ratelimit"
+	"avito_shop/pkg/testutils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func newVersionedHandler(t *testing.T, cfg config.APIV1Config, limits ratelimit.Config) http.Handler {
+	t.Helper()
+
+	respond := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte(body)) }
+	}
+
+	return handlers.NewHandler(
+		"/api",
+		handlers.WithRateLimit(testutils.NewDummyLogger(), ratelimit.NewMemoryLimiter(), func(r *http.Request) string {
+			return r.RemoteAddr
+		}, limits),
+		withAPIVersions(
+			"/api",
+			cfg,
+			func(r chi.Router) { r.Get("/info", respond("v1")) },
+			func(r chi.Router) { r.Get("/info", respond("v2")) },
+			[]handlers.RouterOption{func(r chi.Router) { r.Get("/buy/{item}", respond("shared")) }},
+		),
+	)
+}
+
+func TestVersions_Deprecation(t *testing.T) {
+	t.Parallel()
+
+	h := newVersionedHandler(t, config.APIV1Config{
+		Since:  time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, ratelimit.Config{})
+
+	tests := []struct {
+		Name           string
+		Path           string
+		ExpBody        string
+		ExpDeprecation string
+		ExpSunset      string
+		ExpLink        string
+	}{
+		{
+			"v1 info", "/api/info", "v1",
+			"@1792368000", "Thu, 01 Apr 2027 00:00:00 GMT", `</api/v2/info>; rel="successor-version"`,
+		},
+		{
+			"v1 shared", "/api/buy/cup", "shared",
+			"@1792368000", "Thu, 01 Apr 2027 00:00:00 GMT", `</api/v2/buy/cup>; rel="successor-version"`,
+		},
+		{"v2 info", "/api/v2/info", "v2", "", "", ""},
+		{"v2 shared", "/api/v2/buy/cup", "shared", "", "", ""},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, test.Path, nil))
+
+		require.Equal(t, http.StatusOK, rec.Code, test.Name)
+		require.Equal(t, test.ExpBody, rec.Body.String(), test.Name)
+		require.Equal(t, test.ExpDeprecation, rec.Header().Get("Deprecation"), test.Name)
+		require.Equal(t, test.ExpSunset, rec.Header().Get("Sunset"), test.Name)
+		require.Equal(t, test.ExpLink, rec.Header().Get("Link"), test.Name)
+	}
+}
+
+func TestVersions_NoSunset(t *testing.T) {
+	t.Parallel()
+
+	h := newVersionedHandler(t, config.APIV1Config{
+		Since: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+	}, ratelimit.Config{})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/info", nil))
+
+	require.Equal(t, "@1792368000", rec.Header().Get("Deprecation"))
+	require.Empty(t, rec.Header().Get("Sunset"))
+}
+
+func TestVersions_RateLimitRoutes(t *testing.T) {
+	t.Parallel()
+
+	h := newVersionedHandler(t, config.APIV1Config{}, ratelimit.Config{
+		Enabled: true,
+		Rate:    1,
+		Burst:   5,
+		Routes: map[string]ratelimit.Limit{
+			"GET /api/v2/buy/{item}": {Rate: 1, Burst: 1},
+		},
+	})
+
+	do := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	// the route keys hold the version, v1 keeps the default limit
+	require.Equal(t, http.StatusOK, do("/api/v2/buy/cup").Code)
+	require.Equal(t, http.StatusTooManyRequests, do("/api/v2/buy/pen").Code)
+	require.Equal(t, http.StatusOK, do("/api/buy/pen").Code)
+}
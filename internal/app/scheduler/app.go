@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	pkglog "avito_shop/pkg/log"
+	"context"
+	"log/slog"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Job is a periodic background task. Each job runs in its own goroutine,
+// so a slow job never delays the others.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context, log *slog.Logger) error
+}
+
+type App struct {
+	log  *slog.Logger
+	jobs []Job
+}
+
+func New(log *slog.Logger, jobs ...Job) *App {
+	return &App{
+		log:  log,
+		jobs: jobs,
+	}
+}
+
+// Run starts every job and blocks until ctx is cancelled.
+func (a *App) Run(ctx context.Context) error {
+	const op = "scheduler.App"
+
+	log := a.log.With(slog.String("op", op))
+	log.Info("Scheduler starting", slog.Int("jobs", len(a.jobs)))
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, job := range a.jobs {
+		g.Go(func() error {
+			a.loop(ctx, log.With(slog.String("job", job.Name)), job)
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	log.Info("Scheduler stopped")
+
+	return err
+}
+
+func (a *App) loop(ctx context.Context, log *slog.Logger, job Job) {
+	log.Info("Job scheduled", slog.String("interval", job.Interval.String()))
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job.Run(ctx, log); err != nil {
+				log.Error("error while running job", pkglog.Err(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package scheduler
+
+import (
+	"avito_shop/internal/usecases"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ScheduledTransfersJob drains due transfers batch by batch, so a backlog
+// doesn't have to wait for the next tick.
+func ScheduledTransfersJob(service usecases.ScheduledTransfer, interval time.Duration) Job {
+	return Job{
+		Name:     "scheduled_transfers",
+		Interval: interval,
+		Run: func(ctx context.Context, log *slog.Logger) error {
+			for ctx.Err() == nil {
+				processed, err := service.RunDue(ctx)
+				if err != nil {
+					return err
+				}
+
+				if processed == 0 {
+					return nil
+				}
+				log.Debug("scheduled transfers processed", slog.Int("count", processed))
+			}
+
+			return nil
+		},
+	}
+}
+
+func AllowanceJob(service usecases.Treasury, interval time.Duration) Job {
+	return Job{
+		Name:     "allowance",
+		Interval: interval,
+		Run: func(ctx context.Context, log *slog.Logger) error {
+			grant, err := service.GrantAllowance(ctx, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if grant.Granted > 0 {
+				log.Info("allowance granted",
+					slog.String("period", grant.Period),
+					slog.Int("employees", grant.Granted),
+				)
+			}
+
+			return nil
+		},
+	}
+}
+
+// CoinExpiryJob drains expired coin lots batch by batch, like ScheduledTransfersJob.
+func CoinExpiryJob(service usecases.Treasury, interval time.Duration) Job {
+	return Job{
+		Name:     "coin_expiry",
+		Interval: interval,
+		Run: func(ctx context.Context, log *slog.Logger) error {
+			for ctx.Err() == nil {
+				expired, err := service.ExpireCoins(ctx)
+				if err != nil {
+					return err
+				}
+
+				if expired == 0 {
+					return nil
+				}
+				log.Info("expired coin lots collected", slog.Int("count", expired))
+			}
+
+			return nil
+		},
+	}
+}
+
+// ReconciliationJob runs a dry-run balance reconciliation and logs every discrepancy,
+// corrections are left to an admin reviewing the report.
+func ReconciliationJob(service usecases.Reconciliation, interval time.Duration) Job {
+	return Job{
+		Name:     "reconciliation",
+		Interval: interval,
+		Run: func(ctx context.Context, log *slog.Logger) error {
+			rec, err := service.Run(ctx)
+			if err != nil {
+				return err
+			}
+
+			log = log.With(slog.Int("reconciliation_id", rec.ID))
+			for _, d := range rec.Discrepancies {
+				log.Warn("balance discrepancy",
+					slog.Int("account", d.Account),
+					slog.String("user", d.Name),
+					slog.Int("stored", d.Stored),
+					slog.Int("expected", d.Expected),
+					slog.Int("diff", d.Diff()),
+				)
+			}
+			log.Info("balances reconciled",
+				slog.Int("checked", rec.Checked),
+				slog.Int("discrepancies", len(rec.Discrepancies)),
+			)
+
+			return nil
+		},
+	}
+}
+
+// OutboxRelayJob drains pending events batch by batch, like ScheduledTransfersJob.
+func OutboxRelayJob(service usecases.Outbox, interval time.Duration) Job {
+	return Job{
+		Name:     "outbox_relay",
+		Interval: interval,
+		Run: func(ctx context.Context, log *slog.Logger) error {
+			for ctx.Err() == nil {
+				relay, err := service.Relay(ctx)
+				if err != nil {
+					return err
+				}
+
+				if relay.Total() == 0 {
+					return nil
+				}
+
+				if relay.Failed > 0 {
+					log.Warn("events not delivered, will retry", slog.Int("count", relay.Failed))
+				}
+				log.Debug("events published", slog.Int("count", relay.Published))
+			}
+
+			return nil
+		},
+	}
+}
+
+// WebhookDeliveryJob drains due webhook deliveries batch by batch, like ScheduledTransfersJob.
+func WebhookDeliveryJob(service usecases.Webhook, interval time.Duration) Job {
+	return Job{
+		Name:     "webhook_delivery",
+		Interval: interval,
+		Run: func(ctx context.Context, log *slog.Logger) error {
+			for ctx.Err() == nil {
+				attempted, err := service.DeliverDue(ctx)
+				if err != nil {
+					return err
+				}
+
+				if attempted == 0 {
+					return nil
+				}
+				log.Debug("webhook deliveries attempted", slog.Int("count", attempted))
+			}
+
+			return nil
+		},
+	}
+}
+
+// EmailJob drains due notification emails batch by batch, like ScheduledTransfersJob.
+func EmailJob(service usecases.Email, interval time.Duration) Job {
+	return Job{
+		Name:     "email",
+		Interval: interval,
+		Run: func(ctx context.Context, log *slog.Logger) error {
+			for ctx.Err() == nil {
+				attempted, err := service.SendDue(ctx)
+				if err != nil {
+					return err
+				}
+
+				if attempted == 0 {
+					return nil
+				}
+				log.Debug("emails attempted", slog.Int("count", attempted))
+			}
+
+			return nil
+		},
+	}
+}
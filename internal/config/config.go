@@ -1,9 +1,14 @@
 package config
 
 import (
+	"avito_shop/pkg/idempotency"
 	"avito_shop/pkg/infra"
 	"avito_shop/pkg/infra/cache/redis"
+	"avito_shop/pkg/infra/events"
+	"avito_shop/pkg/infra/mail"
 	pkglog "avito_shop/pkg/log"
+	"avito_shop/pkg/ratelimit"
+	"avito_shop/pkg/tracing"
 	"time"
 )
 
@@ -12,14 +17,120 @@ type HTTPConfig struct {
 	ReadTimeout  time.Duration `env:"SERVER_READ_TIMEOUT" yaml:"read_timeout" env-default:"5s"`
 	WriteTimeout time.Duration `env:"SERVER_WRITE_TIMEOUT" yaml:"write_timeout" env-default:"5s"`
 	IdleTimeout  time.Duration `env:"SERVER_IDLE_TIMEOUT" yaml:"idle_timeout" env-default:"30s"`
+	// AdminAddress serves pprof, expvar, the build info and the runtime stats. On a loopback address
+	// they are open, on any other one they require an admin token.
+	AdminAddress string `env:"SERVER_ADMIN_ADDRESS" yaml:"admin_address" env-default:"localhost:6060"`
+	// StreamHeartbeat keeps idle event streams from being closed by proxies
+	StreamHeartbeat time.Duration `env:"SERVER_STREAM_HEARTBEAT" yaml:"stream_heartbeat" env-default:"15s"`
+	// ReadinessTimeout bounds every dependency check of /health/ready. On shutdown the server reports
+	// not ready for DrainDelay before it stops accepting connections, the delay must leave time
+	// for the requests in flight within the 5s shutdown timeout.
+	ReadinessTimeout time.Duration      `env:"SERVER_READINESS_TIMEOUT" yaml:"readiness_timeout" env-default:"1s"`
+	DrainDelay       time.Duration      `env:"SERVER_DRAIN_DELAY" yaml:"drain_delay" env-default:"2s"`
+	GraphQL          GraphQLConfig      `yaml:"graphql"`
+	RateLimit        ratelimit.Config   `yaml:"rate_limit"`
+	Idempotency      idempotency.Config `yaml:"idempotency"`
+	V1               APIV1Config        `yaml:"v1"`
+}
+
+// APIV1Config dates the deprecation of the unversioned /api routes in favour of /api/v2.
+// The Sunset header is only sent once the sunset date is set.
+type APIV1Config struct {
+	Since  time.Time `env:"API_V1_DEPRECATED_AT" yaml:"deprecated_at" env-layout:"2006-01-02" env-default:"2026-10-19"`
+	Sunset time.Time `env:"API_V1_SUNSET" yaml:"sunset" env-layout:"2006-01-02"`
+}
+
+// GraphQLConfig.MaxComplexity counts every requested field, fields of list items count once per
+// requested item. MaxParallelism caps the resolvers a single query runs at once, loaders only
+// batch the keys of resolvers running together.
+// BatchWait is how long a loader collects keys before it queries them at once.
+type GraphQLConfig struct {
+	MaxDepth       int           `env:"GRAPHQL_MAX_DEPTH" yaml:"max_depth" env-default:"8"`
+	MaxComplexity  int           `env:"GRAPHQL_MAX_COMPLEXITY" yaml:"max_complexity" env-default:"1000"`
+	MaxParallelism int           `env:"GRAPHQL_MAX_PARALLELISM" yaml:"max_parallelism" env-default:"10"`
+	BatchWait      time.Duration `env:"GRAPHQL_BATCH_WAIT" yaml:"batch_wait" env-default:"2ms"`
+}
+
+type GRPCConfig struct {
+	Address           string        `env:"GRPC_ADDRESS" yaml:"address" env-default:":9090"`
+	ConnectionTimeout time.Duration `env:"GRPC_CONNECTION_TIMEOUT" yaml:"connection_timeout" env-default:"5s"`
+	IdleTimeout       time.Duration `env:"GRPC_IDLE_TIMEOUT" yaml:"idle_timeout" env-default:"5m"`
+}
+
+type SchedulerConfig struct {
+	PollInterval time.Duration `env:"SCHEDULER_POLL_INTERVAL" yaml:"poll_interval" env-default:"10s"`
+	BatchSize    int           `env:"SCHEDULER_BATCH_SIZE" yaml:"batch_size" env-default:"100"`
+	Lease        time.Duration `env:"SCHEDULER_LEASE" yaml:"lease" env-default:"1m"`
+}
+
+type AllowanceConfig struct {
+	Amount        int           `env:"ALLOWANCE_AMOUNT" yaml:"amount" env-default:"0"`
+	CheckInterval time.Duration `env:"ALLOWANCE_CHECK_INTERVAL" yaml:"check_interval" env-default:"1h"`
+}
+
+type CoinExpiryConfig struct {
+	CheckInterval time.Duration `env:"COIN_EXPIRY_CHECK_INTERVAL" yaml:"check_interval" env-default:"1h"`
+	BatchSize     int           `env:"COIN_EXPIRY_BATCH_SIZE" yaml:"batch_size" env-default:"500"`
+}
+
+type ReconciliationConfig struct {
+	Interval time.Duration `env:"RECONCILIATION_INTERVAL" yaml:"interval" env-default:"24h"`
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" yaml:"poll_interval" env-default:"1s"`
+	BatchSize    int           `env:"OUTBOX_BATCH_SIZE" yaml:"batch_size" env-default:"100"`
+	Lease        time.Duration `env:"OUTBOX_LEASE" yaml:"lease" env-default:"30s"`
+	MaxBackoff   time.Duration `env:"OUTBOX_MAX_BACKOFF" yaml:"max_backoff" env-default:"10m"`
+	Sink         events.Config `yaml:"sink"`
+}
+
+// WebhookConfig.Lease must outlast a whole batch of timed out deliveries,
+// otherwise another replica may send the rest of the batch once more.
+type WebhookConfig struct {
+	PollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL" yaml:"poll_interval" env-default:"1s"`
+	BatchSize    int           `env:"WEBHOOK_BATCH_SIZE" yaml:"batch_size" env-default:"50"`
+	Lease        time.Duration `env:"WEBHOOK_LEASE" yaml:"lease" env-default:"5m"`
+	Timeout      time.Duration `env:"WEBHOOK_TIMEOUT" yaml:"timeout" env-default:"5s"`
+	MaxAttempts  int           `env:"WEBHOOK_MAX_ATTEMPTS" yaml:"max_attempts" env-default:"8"`
+	MaxBackoff   time.Duration `env:"WEBHOOK_MAX_BACKOFF" yaml:"max_backoff" env-default:"1h"`
+}
+
+// EmailConfig.Domain is the corporate domain users' addresses must be in.
+type EmailConfig struct {
+	Domain       string        `env:"EMAIL_DOMAIN" yaml:"domain" env-default:"avito.ru"`
+	PollInterval time.Duration `env:"EMAIL_POLL_INTERVAL" yaml:"poll_interval" env-default:"5s"`
+	BatchSize    int           `env:"EMAIL_BATCH_SIZE" yaml:"batch_size" env-default:"50"`
+	Lease        time.Duration `env:"EMAIL_LEASE" yaml:"lease" env-default:"5m"`
+	MaxAttempts  int           `env:"EMAIL_MAX_ATTEMPTS" yaml:"max_attempts" env-default:"5"`
+	MaxBackoff   time.Duration `env:"EMAIL_MAX_BACKOFF" yaml:"max_backoff" env-default:"1h"`
+	SMTP         mail.Config   `yaml:"smtp"`
+}
+
+// StreamConfig.BufferSize is how many events a subscriber may lag behind before it is dropped.
+type StreamConfig struct {
+	Channel    string `env:"STREAM_CHANNEL" yaml:"channel" env-default:"shop:events"`
+	BufferSize int    `env:"STREAM_BUFFER_SIZE" yaml:"buffer_size" env-default:"16"`
 }
 
 type Config struct {
-	HTTPServer HTTPConfig           `yaml:"http_server" env-required:"true"`
-	PG         infra.PostgresConfig `yaml:"postgres" env-required:"true"`
-	Redis      redis.Config         `yaml:"redis" env-required:"true"`
-	Logger     pkglog.Config        `yaml:"logger" env-required:"true"`
-	AuthSecret string               `env:"AUTH_SECRET" env-required:"true"`
+	HTTPServer     HTTPConfig           `yaml:"http_server" env-required:"true"`
+	GRPCServer     GRPCConfig           `yaml:"grpc_server"`
+	PG             infra.PostgresConfig `yaml:"postgres" env-required:"true"`
+	Redis          redis.Config         `yaml:"redis" env-required:"true"`
+	Logger         pkglog.Config        `yaml:"logger" env-required:"true"`
+	Tracing        tracing.Config       `yaml:"tracing"`
+	Scheduler      SchedulerConfig      `yaml:"scheduler"`
+	Allowance      AllowanceConfig      `yaml:"allowance"`
+	CoinExpiry     CoinExpiryConfig     `yaml:"coin_expiry"`
+	Reconciliation ReconciliationConfig `yaml:"reconciliation"`
+	Outbox         OutboxConfig         `yaml:"outbox"`
+	Webhook        WebhookConfig        `yaml:"webhook"`
+	Stream         StreamConfig         `yaml:"stream"`
+	Email          EmailConfig          `yaml:"email"`
+	AuthSecret     string               `env:"AUTH_SECRET" env-required:"true"`
+
+	CoinRequestTTL time.Duration `env:"COIN_REQUEST_TTL" yaml:"coin_request_ttl" env-default:"72h"`
 }
 
 func (c Config) Redact() Config {
@@ -34,5 +145,13 @@ func (c Config) Redact() Config {
 
 	c.AuthSecret = privateData
 
+	if c.Email.SMTP.Password != "" {
+		c.Email.SMTP.Password = privateData
+	}
+
+	if c.Outbox.Sink.WebhookURL != "" {
+		c.Outbox.Sink.WebhookURL = privateData
+	}
+
 	return c
 }
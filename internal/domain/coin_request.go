@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+type CoinRequestID = int
+
+type CoinRequestStatus = string
+
+const (
+	CoinRequestPending  CoinRequestStatus = "pending"
+	CoinRequestAccepted CoinRequestStatus = "accepted"
+	CoinRequestDeclined CoinRequestStatus = "declined"
+	CoinRequestExpired  CoinRequestStatus = "expired"
+)
+
+type CoinRequest struct {
+	ID            CoinRequestID
+	Requester     UserID
+	RequesterName UserName
+	Payer         UserID
+	PayerName     UserName
+	Amount        int
+	Status        CoinRequestStatus
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+type EmailID = int64
+
+type EmailStatus = string
+
+const (
+	EmailPending EmailStatus = "pending"
+	EmailSent    EmailStatus = "sent"
+	EmailFailed  EmailStatus = "failed"
+)
+
+// EmailPreferences of a user without an address are all off, users opt in by setting one.
+type EmailPreferences struct {
+	User          UserID
+	Address       string
+	CoinsReceived bool
+	// MerchPurchased is the "order is ready" email: orders have no separate ready status,
+	// merch is ready to be handed out as soon as it's bought.
+	MerchPurchased bool
+}
+
+// Email is a queued notification email. Address is taken when the email is queued,
+// so a later change of preferences doesn't touch emails already queued.
+type Email struct {
+	ID            EmailID
+	User          UserID
+	Address       string
+	Event         StreamEvent
+	Status        EmailStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
@@ -4,6 +4,8 @@ import (
 	resp "avito_shop/pkg/http/responses"
 	pkgerr "avito_shop/pkg/pkgerror"
 	"errors"
+	"net/http"
+	"slices"
 )
 
 var (
@@ -15,8 +17,81 @@ var (
 	ErrUserExists       = errors.New("user already exist")
 	ErrInvalidAuthToken = errors.New("invalid auth token")
 	ErrUnauthorized     = errors.New("unauthorized")
+	ErrForbidden        = errors.New("forbidden")
+
+	ErrSelfRequesting       = errors.New("can't request coins from yourself")
+	ErrCoinRequestNotFound  = errors.New("coin request not found")
+	ErrCoinRequestNotActive = errors.New("coin request is already resolved or expired")
+
+	ErrScheduledTransferNotFound = errors.New("scheduled transfer not found")
+	ErrScheduledTransferNotDue   = errors.New("scheduled transfer is not due")
+
+	ErrTransactionNotFound        = errors.New("transaction not found")
+	ErrTransactionNotReversible   = errors.New("transaction can't be reversed")
+	ErrTransactionAlreadyReversed = errors.New("transaction is already reversed")
+
+	ErrReconciliationNotFound       = errors.New("reconciliation not found")
+	ErrReconciliationAlreadyApplied = errors.New("reconciliation is already applied")
+
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
 )
 
+// ErrorSpec ties a domain error to the stable code clients switch on and the HTTP status it's served with.
+// The codes are part of the API contract: add new ones freely, never rename or reuse them.
+type ErrorSpec struct {
+	Err    error
+	Code   string
+	Status int
+}
+
+var errorRegistry = []ErrorSpec{
+	{ErrBadRequest, "BAD_REQUEST", http.StatusBadRequest},
+	{ErrUnauthorized, "UNAUTHORIZED", http.StatusUnauthorized},
+	{ErrInvalidAuthToken, "INVALID_AUTH_TOKEN", http.StatusUnauthorized},
+	{ErrForbidden, "FORBIDDEN", http.StatusForbidden},
+
+	{ErrUserNotFound, "USER_NOT_FOUND", http.StatusNotFound},
+	{ErrUserExists, "USER_EXISTS", http.StatusConflict},
+	{ErrMerchNotFound, "MERCH_NOT_FOUND", http.StatusNotFound},
+	{ErrLowBalance, "LOW_BALANCE", http.StatusUnprocessableEntity},
+	{ErrSelfSending, "SELF_SENDING", http.StatusUnprocessableEntity},
+
+	{ErrSelfRequesting, "SELF_REQUESTING", http.StatusUnprocessableEntity},
+	{ErrCoinRequestNotFound, "COIN_REQUEST_NOT_FOUND", http.StatusNotFound},
+	{ErrCoinRequestNotActive, "COIN_REQUEST_NOT_ACTIVE", http.StatusConflict},
+
+	{ErrScheduledTransferNotFound, "SCHEDULED_TRANSFER_NOT_FOUND", http.StatusNotFound},
+
+	{ErrTransactionNotFound, "TRANSACTION_NOT_FOUND", http.StatusNotFound},
+	{ErrTransactionNotReversible, "TRANSACTION_NOT_REVERSIBLE", http.StatusUnprocessableEntity},
+	{ErrTransactionAlreadyReversed, "TRANSACTION_ALREADY_REVERSED", http.StatusConflict},
+
+	{ErrReconciliationNotFound, "RECONCILIATION_NOT_FOUND", http.StatusNotFound},
+	{ErrReconciliationAlreadyApplied, "RECONCILIATION_ALREADY_APPLIED", http.StatusConflict},
+
+	{ErrWebhookNotFound, "WEBHOOK_NOT_FOUND", http.StatusNotFound},
+	{ErrWebhookDeliveryNotFound, "WEBHOOK_DELIVERY_NOT_FOUND", http.StatusNotFound},
+}
+
+// ErrorSpecs lists the registered errors, e.g. for the API docs.
+func ErrorSpecs() []ErrorSpec {
+	return slices.Clone(errorRegistry)
+}
+
+// LookupError finds the spec of the registered error err wraps, ok is false for unexpected errors.
+func LookupError(err error) (ErrorSpec, bool) {
+	for _, spec := range errorRegistry {
+		if errors.Is(err, spec.Err) {
+			return spec, true
+		}
+	}
+
+	return ErrorSpec{}, false
+}
+
+// HandleResult answers with r, or with the problem details of a registered error.
+// Unexpected errors are hidden behind a 500, their message may leak internals.
 func HandleResult(err error, r any) resp.Response {
 	if err == nil {
 		return resp.OK(r)
@@ -24,18 +99,10 @@ func HandleResult(err error, r any) resp.Response {
 
 	err = pkgerr.UnwrapAll(err)
 
-	switch {
-	case errors.Is(err, ErrUnauthorized),
-		errors.Is(err, ErrInvalidAuthToken),
-		errors.Is(err, ErrUserExists):
-		return resp.Unauthorized(err)
-	case errors.Is(err, ErrBadRequest),
-		errors.Is(err, ErrLowBalance),
-		errors.Is(err, ErrMerchNotFound),
-		errors.Is(err, ErrUserNotFound),
-		errors.Is(err, ErrSelfSending):
-		return resp.BadRequest(err)
-	default:
+	spec, ok := LookupError(err)
+	if !ok {
 		return resp.Unknown(err)
 	}
+
+	return resp.Problem(spec.Status, spec.Code, err)
 }
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	resp "avito_shop/pkg/http/responses"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorRegistry_CodesAreUnique(t *testing.T) {
+	t.Parallel()
+
+	codes := make(map[string]error)
+	for _, spec := range ErrorSpecs() {
+		require.NotEmpty(t, spec.Code, spec.Err)
+		require.NotContains(t, codes, spec.Code, "%q is taken by %v", spec.Code, codes[spec.Code])
+		require.GreaterOrEqual(t, spec.Status, http.StatusBadRequest, spec.Code)
+		require.Less(t, spec.Status, http.StatusInternalServerError, spec.Code)
+
+		codes[spec.Code] = spec.Err
+	}
+}
+
+func TestHandleResult(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name      string
+		Err       error
+		ExpStatus int
+		ExpCode   string
+		ExpDetail string
+	}{
+		{"Low balance", ErrLowBalance, http.StatusUnprocessableEntity, "LOW_BALANCE", ErrLowBalance.Error()},
+		{"Wrapped", fmt.Errorf("TxService.BuyItem: %w", ErrMerchNotFound),
+			http.StatusNotFound, "MERCH_NOT_FOUND", ErrMerchNotFound.Error()},
+		{"User exists", ErrUserExists, http.StatusConflict, "USER_EXISTS", ErrUserExists.Error()},
+		{"Unexpected", errors.New("conn refused"), http.StatusInternalServerError, resp.CodeInternal, resp.InternalError},
+	}
+
+	for _, test := range tests {
+		res := HandleResult(test.Err, nil)
+
+		problem, ok := res.(*resp.ErrorResponse)
+		require.True(t, ok, test.Name)
+		require.Equal(t, test.ExpStatus, problem.StatusCode(), test.Name)
+		require.Equal(t, test.ExpStatus, problem.Status, test.Name)
+		require.Equal(t, test.ExpCode, problem.Code, test.Name)
+		require.Equal(t, test.ExpDetail, problem.Detail, test.Name)
+	}
+}
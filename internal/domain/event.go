@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type EventID = int64
+
+type EventType = string
+
+const (
+	EventCoinsSent           EventType = "CoinsSent"
+	EventMerchPurchased      EventType = "MerchPurchased"
+	EventCoinRequestCreated  EventType = "CoinRequestCreated"
+	EventCoinRequestDeclined EventType = "CoinRequestDeclined"
+)
+
+// Event is a domain event stored in the outbox. Payload holds one of the event bodies below
+// encoded as JSON, the bodies are what subscribers receive, so their fields must stay stable.
+type Event struct {
+	ID        EventID
+	Type      EventType
+	Payload   json.RawMessage
+	CreatedAt time.Time
+	Attempts  int
+}
+
+type CoinsSent struct {
+	TransactionID TransactionID `json:"transactionId"`
+	From          UserID        `json:"from"`
+	FromName      UserName      `json:"fromName"`
+	To            UserID        `json:"to"`
+	ToName        UserName      `json:"toName"`
+	Amount        int           `json:"amount"`
+}
+
+type MerchPurchased struct {
+	TransactionID TransactionID `json:"transactionId"`
+	User          UserID        `json:"user"`
+	UserName      UserName      `json:"userName"`
+	Item          MerchName     `json:"item"`
+	Price         int           `json:"price"`
+}
+
+type CoinRequestCreated struct {
+	RequestID     CoinRequestID `json:"requestId"`
+	Requester     UserID        `json:"requester"`
+	RequesterName UserName      `json:"requesterName"`
+	Payer         UserID        `json:"payer"`
+	PayerName     UserName      `json:"payerName"`
+	Amount        int           `json:"amount"`
+	ExpiresAt     time.Time     `json:"expiresAt"`
+}
+
+// CoinRequestDeclinedEvent is named apart from the CoinRequestDeclined status.
+// It has no accepted counterpart, an accepted request is paid with CoinsSent.
+type CoinRequestDeclinedEvent struct {
+	RequestID CoinRequestID `json:"requestId"`
+	Requester UserID        `json:"requester"`
+	Payer     UserID        `json:"payer"`
+	PayerName UserName      `json:"payerName"`
+	Amount    int           `json:"amount"`
+}
+
+// OutboxRelay counts the outcome of one relay batch, failed events are retried later.
+type OutboxRelay struct {
+	Published int
+	Failed    int
+}
+
+func (r OutboxRelay) Total() int {
+	return r.Published + r.Failed
+}
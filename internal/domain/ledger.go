@@ -0,0 +1,22 @@
+package domain
+
+// BalanceMismatch is an account whose stored balance differs from the sum of its ledger postings.
+type BalanceMismatch struct {
+	Account UserID
+	Name    UserName
+	Stored  int
+	Posted  int
+}
+
+// LedgerVerification is the result of checking the ledger against the stored balances.
+type LedgerVerification struct {
+	Postings int
+	// Total is the sum of all postings, zero for a consistent ledger.
+	Total             int
+	UnbalancedEntries []int
+	Mismatches        []BalanceMismatch
+}
+
+func (v LedgerVerification) OK() bool {
+	return v.Total == 0 && len(v.UnbalancedEntries) == 0 && len(v.Mismatches) == 0
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+type OrderID = int
+
+// Order is a purchase of a single item, Price is what was paid at the time.
+type Order struct {
+	ID            OrderID
+	User          UserID
+	Item          MerchName
+	Price         int
+	TransactionID TransactionID
+	CreatedAt     time.Time
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// OpeningBalance is what every account, the shop included, starts with.
+const OpeningBalance = 1000
+
+type ReconciliationID = int
+
+// BalanceDiscrepancy is an account whose stored balance differs from the one recomputed from history.
+type BalanceDiscrepancy struct {
+	Account  UserID
+	Name     UserName
+	Stored   int
+	Expected int
+	// Correction is the transaction that fixed the balance, zero until the report is applied
+	// and for the balances it left alone: changed since the dry run or expected to be negative.
+	Correction TransactionID
+}
+
+func (d BalanceDiscrepancy) Diff() int {
+	return d.Expected - d.Stored
+}
+
+// Reconciliation is a dry-run report: corrections are only written when an admin applies it.
+type Reconciliation struct {
+	ID            ReconciliationID
+	StartedAt     time.Time
+	Checked       int
+	Discrepancies []BalanceDiscrepancy
+	AppliedAt     time.Time
+	AppliedBy     UserID
+}
+
+func (r Reconciliation) Applied() bool {
+	return !r.AppliedAt.IsZero()
+}
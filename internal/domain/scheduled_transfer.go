@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type ScheduledTransferID = int
+
+type ScheduledTransfer struct {
+	ID        ScheduledTransferID
+	From      UserID
+	To        UserID
+	ToName    UserName
+	Amount    int
+	Interval  time.Duration // zero for one-off transfers
+	NextRunAt time.Time
+	Active    bool
+	CreatedAt time.Time
+}
+
+type ScheduledTransferRunStatus = string
+
+const (
+	ScheduledTransferSucceeded ScheduledTransferRunStatus = "succeeded"
+	ScheduledTransferFailed    ScheduledTransferRunStatus = "failed"
+)
+
+type ScheduledTransferRun struct {
+	TransferID ScheduledTransferID
+	Status     ScheduledTransferRunStatus
+	Reason     string
+	RunAt      time.Time
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type StreamEventName = string
+
+const (
+	StreamCoinsReceived       StreamEventName = "coinsReceived"
+	StreamCoinsSent           StreamEventName = "coinsSent"
+	StreamMerchPurchased      StreamEventName = "merchPurchased"
+	StreamCoinRequestCreated  StreamEventName = "coinRequestCreated"
+	StreamCoinRequestDeclined StreamEventName = "coinRequestDeclined"
+)
+
+// StreamEvent is pushed to a connected user. ID is the id of the outbox event it comes from,
+// Data is that event's payload, so the sender and the recipient of coins get the same data.
+type StreamEvent struct {
+	ID        EventID
+	Name      StreamEventName
+	Data      json.RawMessage
+	CreatedAt time.Time
+}
+
+// notificationNames are the stream events kept for users until they acknowledge them.
+// Users aren't notified about their own transfers.
+var notificationNames = []StreamEventName{
+	StreamCoinsReceived,
+	StreamMerchPurchased,
+	StreamCoinRequestCreated,
+	StreamCoinRequestDeclined,
+}
+
+func IsNotification(e StreamEvent) bool {
+	return slices.Contains(notificationNames, e.Name)
+}
+
+// StreamEventsOf returns the stream event every user involved in e gets.
+// Events nobody is pushed return an empty map.
+func StreamEventsOf(e Event) (map[UserID]StreamEvent, error) {
+	newEvent := func(name StreamEventName) StreamEvent {
+		return StreamEvent{ID: e.ID, Name: name, Data: e.Payload, CreatedAt: e.CreatedAt}
+	}
+
+	switch e.Type {
+	case EventCoinsSent:
+		var body CoinsSent
+		if err := json.Unmarshal(e.Payload, &body); err != nil {
+			return nil, fmt.Errorf("StreamEventsOf: %w", err)
+		}
+
+		return map[UserID]StreamEvent{
+			body.From: newEvent(StreamCoinsSent),
+			body.To:   newEvent(StreamCoinsReceived),
+		}, nil
+	case EventMerchPurchased:
+		var body MerchPurchased
+		if err := json.Unmarshal(e.Payload, &body); err != nil {
+			return nil, fmt.Errorf("StreamEventsOf: %w", err)
+		}
+
+		return map[UserID]StreamEvent{body.User: newEvent(StreamMerchPurchased)}, nil
+	case EventCoinRequestCreated:
+		var body CoinRequestCreated
+		if err := json.Unmarshal(e.Payload, &body); err != nil {
+			return nil, fmt.Errorf("StreamEventsOf: %w", err)
+		}
+
+		return map[UserID]StreamEvent{body.Payer: newEvent(StreamCoinRequestCreated)}, nil
+	case EventCoinRequestDeclined:
+		var body CoinRequestDeclinedEvent
+		if err := json.Unmarshal(e.Payload, &body); err != nil {
+			return nil, fmt.Errorf("StreamEventsOf: %w", err)
+		}
+
+		return map[UserID]StreamEvent{body.Requester: newEvent(StreamCoinRequestDeclined)}, nil
+	default:
+		return map[UserID]StreamEvent{}, nil
+	}
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type TransactionID = int
 
 type Transaction struct {
@@ -9,6 +11,23 @@ type Transaction struct {
 	Amount int
 }
 
+// TransactionRecord is a transaction as it is kept in the history.
+type TransactionRecord struct {
+	Transaction
+	// Reason is set for non-transfer entries, e.g. "expired" or an admin adjustment.
+	Reason    string
+	CreatedAt time.Time
+}
+
+// TransactionReversal describes an admin request to compensate a transaction.
+// With Force set, the part of the amount the recipient can't return is covered by the shop account.
+type TransactionReversal struct {
+	TransactionID TransactionID
+	Reason        string
+	Force         bool
+	AdminID       UserID
+}
+
 type TransactionDirection int
 
 const (
@@ -20,4 +39,7 @@ type UserTransaction struct {
 	OtherUser UserName
 	Amount    int
 	Direction TransactionDirection
+	// Reason is set for non-transfer entries, e.g. "expired" or an admin adjustment.
+	Reason    string
+	CreatedAt time.Time
 }
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// CoinAdjustment is an admin operation that creates or destroys coins.
+// For burns a zero Amount means the whole balance.
+type CoinAdjustment struct {
+	User       UserID
+	Amount     int
+	Reason     string
+	AdminID    UserID
+	Deactivate bool
+}
+
+type AllowancePeriod = string
+
+const allowancePeriodLayout = "2006-01"
+
+func AllowancePeriodOf(t time.Time) AllowancePeriod {
+	return t.UTC().Format(allowancePeriodLayout)
+}
+
+type AllowanceGrant struct {
+	Period  AllowancePeriod
+	Granted int
+}
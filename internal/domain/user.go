@@ -4,6 +4,17 @@ type UserID = int
 type UserName = string
 type UserHashPass = []byte
 
+// Language is the base language code, e.g. "ru".
+type Language = string
+
+const (
+	LanguageEnglish Language = "en"
+	LanguageRussian Language = "ru"
+)
+
+// Languages are the ones the API speaks, the first one is the default.
+var Languages = []Language{LanguageEnglish, LanguageRussian}
+
 type User struct {
 	ID             UserID
 	Name           UserName
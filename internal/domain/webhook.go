@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type WebhookID = int
+type WebhookDeliveryID = int64
+
+// WebhookSubscription sends every event of EventTypes to URL, signed with Secret.
+type WebhookSubscription struct {
+	ID         WebhookID
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	CreatedBy  UserID
+	CreatedAt  time.Time
+}
+
+type WebhookDeliveryStatus = string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryFailed is final: every attempt failed, only a redelivery sends the event again.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent to one subscription. Claimed deliveries carry
+// the URL and the secret of their subscription.
+type WebhookDelivery struct {
+	ID            WebhookDeliveryID
+	Subscription  WebhookSubscription
+	Event         Event
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	DeliveredAt   time.Time
+	Log           []WebhookAttempt
+}
+
+type WebhookAttempt struct {
+	At         time.Time
+	StatusCode int // zero when no response was received
+	Error      string
+	Duration   time.Duration
+}
+
+func (a WebhookAttempt) OK() bool {
+	return a.Error == ""
+}
+
+var knownEventTypes = []EventType{
+	EventCoinsSent,
+	EventMerchPurchased,
+	EventCoinRequestCreated,
+	EventCoinRequestDeclined,
+}
+
+func IsKnownEventType(eventType EventType) bool {
+	return slices.Contains(knownEventTypes, eventType)
+}
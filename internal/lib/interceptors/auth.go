@@ -0,0 +1,58 @@
+package interceptors
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases"
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var ErrContextParsing = errors.New("can't parse from context")
+
+const authMetadataKey = "authorization"
+
+type userIDCtxKey struct{}
+
+// WithTokenAuth reads the JWT from the "authorization" metadata the same way the HTTP API reads
+// the header, an optional "Bearer " prefix is accepted too. Methods listed in public skip the check.
+func WithTokenAuth(authService usecases.Auth, public ...string) grpc.UnaryServerInterceptor {
+	skip := make(map[string]struct{}, len(public))
+	for _, method := range public {
+		skip[method] = struct{}{}
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := skip[info.FullMethod]; ok {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		values := md.Get(authMetadataKey)
+		if len(values) == 0 || values[0] == "" {
+			return nil, status.Error(codes.Unauthenticated, "token is empty")
+		}
+
+		userID, err := authService.ParseToken(strings.TrimPrefix(values[0], "Bearer "))
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		return handler(context.WithValue(ctx, userIDCtxKey{}, userID), req)
+	}
+}
+
+func GetUserIDFromContext(ctx context.Context) (domain.UserID, error) {
+	id, ok := ctx.Value(userIDCtxKey{}).(domain.UserID)
+	if !ok {
+		return 0, ErrContextParsing
+	}
+
+	return id, nil
+}
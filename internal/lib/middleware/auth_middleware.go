@@ -7,7 +7,9 @@ import (
 	resp "avito_shop/pkg/http/responses"
 	"context"
 	"errors"
+	"net"
 	"net/http"
+	"strconv"
 )
 
 var ErrContextParsing = errors.New("can't parse from context")
@@ -37,6 +39,32 @@ func WithTokenAuth(authService usecases.Auth) func(http.Handler) http.Handler {
 	}
 }
 
+// WithAdminAuth must be used after WithTokenAuth, it relies on the user id put into the context.
+func WithAdminAuth(authService usecases.Auth) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, err := GetUserIDFromContext(r)
+			if err != nil {
+				handlers.WriteResponse(w, r, resp.Unauthorized(errors.New("invalid token")))
+				return
+			}
+
+			isAdmin, err := authService.IsAdmin(r.Context(), userID)
+			if err != nil {
+				handlers.WriteResponse(w, r, domain.HandleResult(err, nil))
+				return
+			}
+
+			if !isAdmin {
+				handlers.WriteResponse(w, r, resp.Forbidden(errors.New("admin rights required")))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func GetUserIDFromContext(r *http.Request) (domain.UserID, error) {
 	id, ok := r.Context().Value(AuthContextKey).(domain.UserID)
 	if !ok {
@@ -45,3 +73,42 @@ func GetUserIDFromContext(r *http.Request) (domain.UserID, error) {
 
 	return id, nil
 }
+
+// WithOptionalTokenAuth puts the user id into the context when the request carries a valid token
+// and lets every request through, so the middlewares in front of the routes can tell users apart.
+// The routes needing a user are still guarded by WithTokenAuth.
+func WithOptionalTokenAuth(authService usecases.Auth) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.Header.Get("Authorization")
+			if token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID, err := authService.ParseToken(token)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), AuthContextKey, userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RateLimitKey keys the requests of an authenticated user by the user and the rest by the client IP.
+// The idempotency keys of the clients are scoped by it as well.
+func RateLimitKey(r *http.Request) string {
+	if userID, err := GetUserIDFromContext(r); err == nil {
+		return "user:" + strconv.Itoa(userID)
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"avito_shop/internal/usecases"
+	"net/http"
+)
+
+// LanguagePreference reads the language the user chose, so it must go after WithOptionalTokenAuth.
+// Anonymous requests and failed lookups have no preference and follow Accept-Language.
+func LanguagePreference(userService usecases.User) func(r *http.Request) string {
+	return func(r *http.Request) string {
+		userID, err := GetUserIDFromContext(r)
+		if err != nil {
+			return ""
+		}
+
+		lang, err := userService.GetLanguage(r.Context(), userID)
+		if err != nil {
+			return ""
+		}
+
+		return lang
+	}
+}
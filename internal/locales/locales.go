@@ -0,0 +1,29 @@
+// Package locales embeds the translations of the API messages into the binary.
+package locales
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/pkg/i18n"
+	"embed"
+)
+
+//go:embed *.json
+var files embed.FS
+
+// MustBundle panics on a broken bundle, it's embedded, so it can only break at build time.
+func MustBundle() *i18n.Bundle {
+	bundle, err := i18n.NewBundle(files, domain.Languages...)
+	if err != nil {
+		panic(err)
+	}
+
+	return bundle
+}
+
+func MerchNameKey(name domain.MerchName) string {
+	return "merch." + name + ".name"
+}
+
+func MerchDescriptionKey(name domain.MerchName) string {
+	return "merch." + name + ".description"
+}
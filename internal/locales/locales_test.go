@@ -0,0 +1,79 @@
+package locales
+
+import (
+	"avito_shop/internal/domain"
+	resp "avito_shop/pkg/http/responses"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMustBundle(t *testing.T) {
+	t.Parallel()
+
+	bundle := MustBundle()
+
+	require.Equal(t, domain.LanguageEnglish, bundle.Default())
+	require.Equal(t, domain.Languages, bundle.Languages())
+}
+
+// The English details are the messages of the errors themselves, so only the other languages need them.
+func TestErrorCodesTranslated(t *testing.T) {
+	t.Parallel()
+
+	bundle := MustBundle()
+
+	codes := []string{
+		resp.CodeBadRequest, resp.CodeNotFound, resp.CodeMethodNotAllowed, resp.CodeUnauthorized,
+		resp.CodeForbidden, resp.CodeTooManyRequests, resp.CodeInternal,
+		resp.CodeIdempotencyKeyInUse, resp.CodeIdempotencyKeyReused,
+	}
+	for _, spec := range domain.ErrorSpecs() {
+		codes = append(codes, spec.Code)
+	}
+
+	for _, lang := range domain.Languages[1:] {
+		for _, code := range codes {
+			_, ok := bundle.Message(lang, resp.DetailKey(code))
+			require.True(t, ok, "%s has no message for %s", lang, code)
+		}
+	}
+}
+
+func TestLanguagesHaveSameMerch(t *testing.T) {
+	t.Parallel()
+
+	keys := func(lang string) []string {
+		data, err := files.ReadFile(lang + ".json")
+		require.NoError(t, err)
+
+		messages := make(map[string]string)
+		require.NoError(t, json.Unmarshal(data, &messages))
+
+		var merch []string
+		for key := range maps.Keys(messages) {
+			if strings.HasPrefix(key, "merch.") {
+				merch = append(merch, key)
+			}
+		}
+		slices.Sort(merch)
+
+		return merch
+	}
+
+	exp := keys(domain.LanguageEnglish)
+	require.NotEmpty(t, exp)
+
+	for _, lang := range domain.Languages[1:] {
+		require.Equal(t, exp, keys(lang), lang)
+	}
+
+	bundle := MustBundle()
+	msg, ok := bundle.Message(domain.LanguageRussian, MerchNameKey("t-shirt"))
+	require.True(t, ok)
+	require.Equal(t, "Футболка", msg)
+}
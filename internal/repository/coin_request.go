@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=CoinRequest --filename=coin_request_repository_mock.go
+type CoinRequest interface {
+	Create(ctx context.Context, req domain.CoinRequest) (domain.CoinRequest, error)
+	GetByID(ctx context.Context, id domain.CoinRequestID) (domain.CoinRequest, error)
+	ListByPayer(ctx context.Context, uid domain.UserID) ([]domain.CoinRequest, error)
+	ListByRequester(ctx context.Context, uid domain.UserID) ([]domain.CoinRequest, error)
+	Resolve(ctx context.Context, id domain.CoinRequestID, status domain.CoinRequestStatus) error
+}
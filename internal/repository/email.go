@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+	"time"
+)
+
+// Email keeps the preferences and the queue, emails are queued together with the notifications.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Email --filename=email_repository_mock.go
+type Email interface {
+	GetPreferences(ctx context.Context, uid domain.UserID) (domain.EmailPreferences, error)
+	// SetPreferences with an empty address removes the preferences, the user gets no emails.
+	SetPreferences(ctx context.Context, prefs domain.EmailPreferences) error
+	// ClaimDue leases up to limit pending emails that are due, like Webhook.ClaimDue.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]domain.Email, error)
+	Record(ctx context.Context, email domain.Email) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=History --filename=history_repository_mock.go
+type History interface {
+	ListTransactions(ctx context.Context, uid domain.UserID, limit int) ([]domain.TransactionRecord, error)
+	ListOrders(ctx context.Context, uid domain.UserID, limit int) ([]domain.Order, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Ledger --filename=ledger_repository_mock.go
+type Ledger interface {
+	Verify(ctx context.Context) (domain.LedgerVerification, error)
+}
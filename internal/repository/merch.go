@@ -8,4 +8,6 @@ import (
 //go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Merch --filename=merch_repository_mock.go
 type Merch interface {
 	GetByName(ctx context.Context, name string) (domain.Merch, error)
+	GetByNames(ctx context.Context, names []domain.MerchName) ([]domain.Merch, error)
+	List(ctx context.Context) ([]domain.Merch, error)
 }
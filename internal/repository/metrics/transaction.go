@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	coinsTransferred = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "shop_coins_transferred_total",
+		Help: "Coins sent between employees, including accepted coin requests and scheduled transfers.",
+	})
+	purchases = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "shop_purchases_total",
+		Help: "Merch purchases by item.",
+	}, []string{"item"})
+	lowBalanceRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "shop_low_balance_rejections_total",
+		Help: "Transfers and purchases rejected for lack of coins, by operation.",
+	}, []string{"operation"})
+)
+
+// TransactionRepository counts the business metrics on the way out of the wrapped repository.
+// Every transfer and purchase ends up here whichever use case started it.
+type TransactionRepository struct {
+	repository.Transaction
+}
+
+func NewTransactionRepository(repo repository.Transaction) repository.Transaction {
+	return &TransactionRepository{
+		Transaction: repo,
+	}
+}
+
+func (r *TransactionRepository) SendCoin(ctx context.Context, tx domain.Transaction) error {
+	err := r.Transaction.SendCoin(ctx, tx)
+	if err != nil {
+		countLowBalance("send_coin", err)
+		return err
+	}
+
+	coinsTransferred.Add(float64(tx.Amount))
+	return nil
+}
+
+func (r *TransactionRepository) BuyItem(ctx context.Context, uid domain.UserID, item domain.Merch) error {
+	err := r.Transaction.BuyItem(ctx, uid, item)
+	if err != nil {
+		countLowBalance("buy_item", err)
+		return err
+	}
+
+	purchases.WithLabelValues(item.Name).Inc()
+	return nil
+}
+
+func countLowBalance(operation string, err error) {
+	if errors.Is(err, domain.ErrLowBalance) {
+		lowBalanceRejections.WithLabelValues(operation).Inc()
+	}
+}
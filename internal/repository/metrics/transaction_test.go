@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository/mocks"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionRepository_SendCoin(t *testing.T) {
+	t.Parallel()
+
+	next := mocks.NewTransaction(t)
+	repo := NewTransactionRepository(next)
+
+	ok := domain.Transaction{From: 1, To: 2, Amount: 30}
+	poor := domain.Transaction{From: 3, To: 2, Amount: 1000}
+	failed := domain.Transaction{From: 4, To: 2, Amount: 10}
+
+	next.On("SendCoin", mock.Anything, ok).Return(nil)
+	next.On("SendCoin", mock.Anything, poor).Return(fmt.Errorf("consumeLots: %w", domain.ErrLowBalance))
+	next.On("SendCoin", mock.Anything, failed).Return(errors.New("connection reset"))
+
+	transferred := testutil.ToFloat64(coinsTransferred)
+	rejected := testutil.ToFloat64(lowBalanceRejections.WithLabelValues("send_coin"))
+
+	require.NoError(t, repo.SendCoin(context.Background(), ok))
+	require.ErrorIs(t, repo.SendCoin(context.Background(), poor), domain.ErrLowBalance)
+	require.Error(t, repo.SendCoin(context.Background(), failed))
+
+	require.InDelta(t, transferred+30, testutil.ToFloat64(coinsTransferred), 0)
+	require.InDelta(t, rejected+1, testutil.ToFloat64(lowBalanceRejections.WithLabelValues("send_coin")), 0)
+}
+
+func TestTransactionRepository_BuyItem(t *testing.T) {
+	t.Parallel()
+
+	next := mocks.NewTransaction(t)
+	repo := NewTransactionRepository(next)
+
+	cup := domain.Merch{ID: 1, Name: "cup", Price: 20}
+	hoody := domain.Merch{ID: 2, Name: "hoody", Price: 300}
+
+	next.On("BuyItem", mock.Anything, 1, cup).Return(nil)
+	next.On("BuyItem", mock.Anything, 1, hoody).Return(domain.ErrLowBalance)
+
+	cups := testutil.ToFloat64(purchases.WithLabelValues("cup"))
+	hoodies := testutil.ToFloat64(purchases.WithLabelValues("hoody"))
+	rejected := testutil.ToFloat64(lowBalanceRejections.WithLabelValues("buy_item"))
+
+	require.NoError(t, repo.BuyItem(context.Background(), 1, cup))
+	require.NoError(t, repo.BuyItem(context.Background(), 1, cup))
+	require.ErrorIs(t, repo.BuyItem(context.Background(), 1, hoody), domain.ErrLowBalance)
+
+	require.InDelta(t, cups+2, testutil.ToFloat64(purchases.WithLabelValues("cup")), 0)
+	require.InDelta(t, hoodies, testutil.ToFloat64(purchases.WithLabelValues("hoody")), 0)
+	require.InDelta(t, rejected+1, testutil.ToFloat64(lowBalanceRejections.WithLabelValues("buy_item")), 0)
+}
@@ -0,0 +1,163 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "avito_shop/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CoinRequest is an autogenerated mock type for the CoinRequest type
+type CoinRequest struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *CoinRequest) Create(ctx context.Context, req domain.CoinRequest) (domain.CoinRequest, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.CoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinRequest) (domain.CoinRequest, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinRequest) domain.CoinRequest); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(domain.CoinRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CoinRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *CoinRequest) GetByID(ctx context.Context, id int) (domain.CoinRequest, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.CoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.CoinRequest, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.CoinRequest); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.CoinRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByPayer provides a mock function with given fields: ctx, uid
+func (_m *CoinRequest) ListByPayer(ctx context.Context, uid int) ([]domain.CoinRequest, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByPayer")
+	}
+
+	var r0 []domain.CoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.CoinRequest, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.CoinRequest); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CoinRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByRequester provides a mock function with given fields: ctx, uid
+func (_m *CoinRequest) ListByRequester(ctx context.Context, uid int) ([]domain.CoinRequest, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByRequester")
+	}
+
+	var r0 []domain.CoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.CoinRequest, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.CoinRequest); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CoinRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resolve provides a mock function with given fields: ctx, id, status
+func (_m *CoinRequest) Resolve(ctx context.Context, id int, status string) error {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCoinRequest creates a new instance of CoinRequest. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCoinRequest(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CoinRequest {
+	mock := &CoinRequest{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "avito_shop/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Notification is an autogenerated mock type for the Notification type
+type Notification struct {
+	mock.Mock
+}
+
+// ListUnread provides a mock function with given fields: ctx, uid, after, limit
+func (_m *Notification) ListUnread(ctx context.Context, uid int, after int64, limit int) ([]domain.StreamEvent, error) {
+	ret := _m.Called(ctx, uid, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnread")
+	}
+
+	var r0 []domain.StreamEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64, int) ([]domain.StreamEvent, error)); ok {
+		return rf(ctx, uid, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64, int) []domain.StreamEvent); ok {
+		r0 = rf(ctx, uid, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.StreamEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64, int) error); ok {
+		r1 = rf(ctx, uid, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkRead provides a mock function with given fields: ctx, uid, ids
+func (_m *Notification) MarkRead(ctx context.Context, uid int, ids []int64) (int, error) {
+	ret := _m.Called(ctx, uid, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int64) (int, error)); ok {
+		return rf(ctx, uid, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int64) int); ok {
+		r0 = rf(ctx, uid, ids)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int64) error); ok {
+		r1 = rf(ctx, uid, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewNotification creates a new instance of Notification. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotification(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Notification {
+	mock := &Notification{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
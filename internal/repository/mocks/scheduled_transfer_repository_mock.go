@@ -0,0 +1,185 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "avito_shop/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ScheduledTransfer is an autogenerated mock type for the ScheduledTransfer type
+type ScheduledTransfer struct {
+	mock.Mock
+}
+
+// Cancel provides a mock function with given fields: ctx, uid, id
+func (_m *ScheduledTransfer) Cancel(ctx context.Context, uid int, id int) error {
+	ret := _m.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimDue provides a mock function with given fields: ctx, limit, lease
+func (_m *ScheduledTransfer) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]domain.ScheduledTransfer, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 []domain.ScheduledTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]domain.ScheduledTransfer, error)); ok {
+		return rf(ctx, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []domain.ScheduledTransfer); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ScheduledTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Complete provides a mock function with given fields: ctx, claimed, next, run
+func (_m *ScheduledTransfer) Complete(ctx context.Context, claimed domain.ScheduledTransfer, next domain.ScheduledTransfer, run domain.ScheduledTransferRun) error {
+	ret := _m.Called(ctx, claimed, next, run)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ScheduledTransfer, domain.ScheduledTransfer, domain.ScheduledTransferRun) error); ok {
+		r0 = rf(ctx, claimed, next, run)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, st
+func (_m *ScheduledTransfer) Create(ctx context.Context, st domain.ScheduledTransfer) (domain.ScheduledTransfer, error) {
+	ret := _m.Called(ctx, st)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.ScheduledTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ScheduledTransfer) (domain.ScheduledTransfer, error)); ok {
+		return rf(ctx, st)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ScheduledTransfer) domain.ScheduledTransfer); ok {
+		r0 = rf(ctx, st)
+	} else {
+		r0 = ret.Get(0).(domain.ScheduledTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ScheduledTransfer) error); ok {
+		r1 = rf(ctx, st)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBySender provides a mock function with given fields: ctx, uid
+func (_m *ScheduledTransfer) ListBySender(ctx context.Context, uid int) ([]domain.ScheduledTransfer, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBySender")
+	}
+
+	var r0 []domain.ScheduledTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.ScheduledTransfer, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.ScheduledTransfer); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ScheduledTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRuns provides a mock function with given fields: ctx, uid, id
+func (_m *ScheduledTransfer) ListRuns(ctx context.Context, uid int, id int) ([]domain.ScheduledTransferRun, error) {
+	ret := _m.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRuns")
+	}
+
+	var r0 []domain.ScheduledTransferRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]domain.ScheduledTransferRun, error)); ok {
+		return rf(ctx, uid, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.ScheduledTransferRun); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ScheduledTransferRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewScheduledTransfer creates a new instance of ScheduledTransfer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduledTransfer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduledTransfer {
+	mock := &ScheduledTransfer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,6 +14,34 @@ type Transaction struct {
 	mock.Mock
 }
 
+// Burn provides a mock function with given fields: ctx, adj
+func (_m *Transaction) Burn(ctx context.Context, adj domain.CoinAdjustment) (domain.Transaction, error) {
+	ret := _m.Called(ctx, adj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Burn")
+	}
+
+	var r0 domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinAdjustment) (domain.Transaction, error)); ok {
+		return rf(ctx, adj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinAdjustment) domain.Transaction); ok {
+		r0 = rf(ctx, adj)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CoinAdjustment) error); ok {
+		r1 = rf(ctx, adj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BuyItem provides a mock function with given fields: ctx, uid, item
 func (_m *Transaction) BuyItem(ctx context.Context, uid int, item domain.Merch) error {
 	ret := _m.Called(ctx, uid, item)
@@ -32,6 +60,120 @@ func (_m *Transaction) BuyItem(ctx context.Context, uid int, item domain.Merch)
 	return r0
 }
 
+// ExpireLots provides a mock function with given fields: ctx, limit
+func (_m *Transaction) ExpireLots(ctx context.Context, limit int) (int, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireLots")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantAllowance provides a mock function with given fields: ctx, period, amount
+func (_m *Transaction) GrantAllowance(ctx context.Context, period string, amount int) (int, error) {
+	ret := _m.Called(ctx, period, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantAllowance")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (int, error)); ok {
+		return rf(ctx, period, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) int); ok {
+		r0 = rf(ctx, period, amount)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, period, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mint provides a mock function with given fields: ctx, adj
+func (_m *Transaction) Mint(ctx context.Context, adj domain.CoinAdjustment) (domain.Transaction, error) {
+	ret := _m.Called(ctx, adj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Mint")
+	}
+
+	var r0 domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinAdjustment) (domain.Transaction, error)); ok {
+		return rf(ctx, adj)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinAdjustment) domain.Transaction); ok {
+		r0 = rf(ctx, adj)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CoinAdjustment) error); ok {
+		r1 = rf(ctx, adj)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reverse provides a mock function with given fields: ctx, rev
+func (_m *Transaction) Reverse(ctx context.Context, rev domain.TransactionReversal) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, rev)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reverse")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionReversal) ([]domain.Transaction, error)); ok {
+		return rf(ctx, rev)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionReversal) []domain.Transaction); ok {
+		r0 = rf(ctx, rev)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TransactionReversal) error); ok {
+		r1 = rf(ctx, rev)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendCoin provides a mock function with given fields: ctx, tx
 func (_m *Transaction) SendCoin(ctx context.Context, tx domain.Transaction) error {
 	ret := _m.Called(ctx, tx)
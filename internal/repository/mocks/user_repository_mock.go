@@ -14,23 +14,51 @@ type User struct {
 	mock.Mock
 }
 
-// GetByID provides a mock function with given fields: ctx, id
-func (_m *User) GetByID(ctx context.Context, id int) (domain.User, error) {
-	ret := _m.Called(ctx, id)
+// GetByName provides a mock function with given fields: ctx, name
+func (_m *User) GetByName(ctx context.Context, name string) (domain.User, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for GetByName")
 	}
 
 	var r0 domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.User, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInfoByID provides a mock function with given fields: ctx, id
+func (_m *User) GetInfoByID(ctx context.Context, id int) (domain.UserInfo, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInfoByID")
+	}
+
+	var r0 domain.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.UserInfo, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) domain.User); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.UserInfo); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(domain.User)
+		r0 = ret.Get(0).(domain.UserInfo)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
@@ -42,27 +70,27 @@ func (_m *User) GetByID(ctx context.Context, id int) (domain.User, error) {
 	return r0, r1
 }
 
-// GetByName provides a mock function with given fields: ctx, name
-func (_m *User) GetByName(ctx context.Context, name string) (domain.User, error) {
-	ret := _m.Called(ctx, name)
+// GetLanguage provides a mock function with given fields: ctx, id
+func (_m *User) GetLanguage(ctx context.Context, id int) (string, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByName")
+		panic("no return value specified for GetLanguage")
 	}
 
-	var r0 domain.User
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
-		return rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (string, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, int) string); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(domain.User)
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,23 +98,53 @@ func (_m *User) GetByName(ctx context.Context, name string) (domain.User, error)
 	return r0, r1
 }
 
-// GetInfoByID provides a mock function with given fields: ctx, id
-func (_m *User) GetInfoByID(ctx context.Context, id int) (domain.UserInfo, error) {
+// GetNamesByIDs provides a mock function with given fields: ctx, ids
+func (_m *User) GetNamesByIDs(ctx context.Context, ids []int) (map[int]string, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamesByIDs")
+	}
+
+	var r0 map[int]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) (map[int]string, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) map[int]string); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsAdmin provides a mock function with given fields: ctx, id
+func (_m *User) IsAdmin(ctx context.Context, id int) (bool, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetInfoByID")
+		panic("no return value specified for IsAdmin")
 	}
 
-	var r0 domain.UserInfo
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.UserInfo, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) (bool, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) domain.UserInfo); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(domain.UserInfo)
+		r0 = ret.Get(0).(bool)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
@@ -126,6 +184,24 @@ func (_m *User) Put(ctx context.Context, user domain.User) (int, error) {
 	return r0, r1
 }
 
+// SetLanguage provides a mock function with given fields: ctx, id, lang
+func (_m *User) SetLanguage(ctx context.Context, id int, lang string) error {
+	ret := _m.Called(ctx, id, lang)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLanguage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, id, lang)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUser creates a new instance of User. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUser(t interface {
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+// Notification is read only, notifications are written together with the events they come from.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Notification --filename=notification_repository_mock.go
+type Notification interface {
+	// ListUnread returns up to limit unread notifications of uid with event ids above after, oldest first.
+	ListUnread(ctx context.Context, uid domain.UserID, after domain.EventID, limit int) ([]domain.StreamEvent, error)
+	// MarkRead returns how many of the notifications were unread, unknown ids are skipped.
+	MarkRead(ctx context.Context, uid domain.UserID, ids []domain.EventID) (int, error)
+}
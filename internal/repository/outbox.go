@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+	"time"
+)
+
+// Outbox is read by the relay only, events are written by the repositories
+// in the same transaction as the change they describe.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Outbox --filename=outbox_repository_mock.go
+type Outbox interface {
+	// ClaimPending leases up to limit unpublished events that are due for an attempt, in the order
+	// they were written. A leased event is offered again once the lease expires without a mark.
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]domain.Event, error)
+	MarkPublished(ctx context.Context, id domain.EventID) error
+	MarkFailed(ctx context.Context, id domain.EventID, reason string, retryAt time.Time) error
+}
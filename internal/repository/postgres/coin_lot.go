@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// coinLot is a bucket of coins sharing one grant date. Balances of employees are
+// kept as lots, so employees.coins always equals the sum of their lots including
+// the expired ones the expiry job hasn't collected yet.
+// A zero ExpiresAt means a fresh lot: the database assigns the standard lifetime.
+type coinLot struct {
+	Amount    int
+	GrantedAt time.Time
+	ExpiresAt time.Time
+}
+
+// hasLots reports whether the account's coins are tracked by lots.
+// The shop and the system account only have a plain balance.
+func hasLots(id domain.UserID) bool {
+	return id != repository.ShopDBID && id != repository.SystemDBID
+}
+
+// ExpireLots moves up to limit expired lots to the shop account, leaving an
+// "expired" transaction in the history of every affected employee and posting it to the ledger.
+func (r *TransactionRepository) ExpireLots(ctx context.Context, limit int) (int, error) {
+	ctx, span := tracer.Start(ctx, "TxRepository.ExpireLots")
+	defer span.End()
+
+	var expired int
+
+	query := `WITH expired AS (
+                  SELECT id, employee_id, amount
+                  FROM coin_lots
+                  WHERE expires_at <= now()
+                  ORDER BY expires_at
+                  LIMIT $1
+                  FOR UPDATE SKIP LOCKED
+              ), removed AS (
+                  DELETE FROM coin_lots WHERE id IN (SELECT id FROM expired)
+              ), per_employee AS (
+                  SELECT employee_id, SUM(amount)::INT AS amount
+                  FROM expired
+                  GROUP BY employee_id
+              ), debited AS (
+                  UPDATE employees e
+                  SET coins = e.coins - p.amount
+                  FROM per_employee p
+                  WHERE e.id = p.employee_id
+              ), credited AS (
+                  UPDATE employees
+                  SET coins = coins + (SELECT SUM(amount) FROM expired)
+                  WHERE id = $2 AND EXISTS (SELECT 1 FROM expired)
+              ), logged AS (
+                  INSERT INTO coin_transactions (sender, recipient, amount, reason)
+                  SELECT employee_id, $2, amount, 'expired'
+                  FROM per_employee
+                  RETURNING id, sender, amount
+              ), entries AS (
+                  INSERT INTO ledger_entries (transaction_id)
+                  SELECT id FROM logged
+                  RETURNING id, transaction_id
+              ), postings AS (
+                  INSERT INTO ledger_postings (entry_id, account, amount)
+                  SELECT e.id, p.account, p.amount
+                  FROM entries e
+                  JOIN logged l ON l.id = e.transaction_id
+                  CROSS JOIN LATERAL (VALUES (l.sender, -l.amount), ($2::INT, l.amount)) AS p (account, amount)
+              )
+              SELECT count(*) FROM expired`
+
+	err := r.runner.run(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, func(dbTx pgx.Tx) error {
+		return dbTx.QueryRow(ctx, query, limit, repository.ShopDBID).Scan(&expired)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.ExpireLots: %w", err)
+	}
+
+	return expired, nil
+}
+
+// consumeLots takes amount coins from the account, spending the oldest non-expired lots first.
+// It returns the lots taken so the recipient inherits their expiry dates.
+func (r *TransactionRepository) consumeLots(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	id domain.UserID,
+	amount int,
+) ([]coinLot, error) {
+	if !hasLots(id) {
+		return []coinLot{{Amount: amount}}, nil
+	}
+
+	query := `SELECT id, amount, granted_at, expires_at
+              FROM coin_lots
+              WHERE employee_id = $1 AND expires_at > now()
+              ORDER BY expires_at, id
+              FOR UPDATE`
+
+	rows, err := dbTx.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("TxRepository.consumeLots: %w", err)
+	}
+	defer func() { rows.Close() }()
+
+	var (
+		taken    []coinLot
+		spent    []int
+		partial  int
+		leftover int
+	)
+
+	for amount > 0 && rows.Next() {
+		var (
+			lotID int
+			lot   coinLot
+		)
+
+		if err = rows.Scan(&lotID, &lot.Amount, &lot.GrantedAt, &lot.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("TxRepository.consumeLots: %w", err)
+		}
+
+		if lot.Amount > amount {
+			partial, leftover = lotID, lot.Amount-amount
+			lot.Amount = amount
+		} else {
+			spent = append(spent, lotID)
+		}
+
+		amount -= lot.Amount
+		taken = append(taken, lot)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("TxRepository.consumeLots: %w", err)
+	}
+
+	if amount > 0 {
+		return nil, fmt.Errorf("TxRepository.consumeLots: %w", domain.ErrLowBalance)
+	}
+
+	_, err = dbTx.Exec(ctx, `DELETE FROM coin_lots WHERE id = ANY($1)`, spent)
+	if err != nil {
+		return nil, fmt.Errorf("TxRepository.consumeLots: %w", err)
+	}
+
+	if partial != 0 {
+		_, err = dbTx.Exec(ctx, `UPDATE coin_lots SET amount = $2 WHERE id = $1`, partial, leftover)
+		if err != nil {
+			return nil, fmt.Errorf("TxRepository.consumeLots: %w", err)
+		}
+	}
+
+	return taken, nil
+}
+
+func (r *TransactionRepository) insertLots(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	id domain.UserID,
+	lots []coinLot,
+) error {
+	if !hasLots(id) {
+		return nil
+	}
+
+	for _, lot := range lots {
+		var err error
+		if lot.ExpiresAt.IsZero() {
+			_, err = dbTx.Exec(ctx,
+				`INSERT INTO coin_lots (employee_id, amount) VALUES ($1, $2)`,
+				id, lot.Amount)
+		} else {
+			_, err = dbTx.Exec(ctx,
+				`INSERT INTO coin_lots (employee_id, amount, granted_at, expires_at) VALUES ($1, $2, $3, $4)`,
+				id, lot.Amount, lot.GrantedAt, lot.ExpiresAt)
+		}
+		if err != nil {
+			return fmt.Errorf("TxRepository.insertLots: %w", mapLedgerError(err))
+		}
+	}
+
+	return nil
+}
+
+// getSpendableBalanceForUpdate locks the account and returns the coins it can spend right now.
+func (r *TransactionRepository) getSpendableBalanceForUpdate(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	id domain.UserID,
+) (int, error) {
+	coins, err := r.getUserBalanceForUpdate(ctx, dbTx, id)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.getSpendableBalanceForUpdate: %w", err)
+	}
+
+	if !hasLots(id) {
+		return coins, nil
+	}
+
+	query := `SELECT COALESCE(SUM(amount), 0)
+              FROM coin_lots
+              WHERE employee_id = $1 AND expires_at > now()`
+
+	err = dbTx.QueryRow(ctx, query, id).Scan(&coins)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.getSpendableBalanceForUpdate: %w", err)
+	}
+
+	return coins, nil
+}
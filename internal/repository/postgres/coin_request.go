@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CoinRequestRepository struct {
+	runner txRunner
+}
+
+func NewCoinRequestRepository(dbPool *pgxpool.Pool) repository.CoinRequest {
+	return &CoinRequestRepository{
+		runner: newTxRunner(dbPool),
+	}
+}
+
+// coinRequestColumns reports pending requests past their deadline as expired,
+// so no background job is needed to keep statuses up to date.
+const (
+	coinRequestColumns = `cr.id,
+    		  cr.requester,
+    		  e_req.username,
+    		  cr.payer,
+    		  e_pay.username,
+    		  cr.amount,
+    		  CASE WHEN cr.status = 'pending' AND cr.expires_at <= now()
+    		       THEN 'expired' ELSE cr.status END,
+    		  cr.created_at,
+    		  cr.expires_at`
+	coinRequestJoins = `JOIN employees e_req ON cr.requester = e_req.id
+              JOIN employees e_pay ON cr.payer = e_pay.id`
+	selectCoinRequests = `SELECT ` + coinRequestColumns + `
+			  FROM coin_requests cr
+              ` + coinRequestJoins
+)
+
+func (r *CoinRequestRepository) Create(ctx context.Context, req domain.CoinRequest) (domain.CoinRequest, error) {
+	ctx, span := tracer.Start(ctx, "CoinRequestRepository.Create")
+	defer span.End()
+
+	query := `WITH cr AS (
+                  INSERT INTO coin_requests (requester, payer, amount, expires_at)
+                  VALUES ($1, $2, $3, $4)
+                  RETURNING *
+              )
+              SELECT ` + coinRequestColumns + `
+              FROM cr
+              ` + coinRequestJoins
+
+	var created domain.CoinRequest
+
+	err := r.runner.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		var err error
+		created, err = scanCoinRequest(dbTx.QueryRow(ctx, query, req.Requester, req.Payer, req.Amount, req.ExpiresAt))
+		if err != nil {
+			return err
+		}
+
+		return insertEvent(ctx, dbTx, domain.EventCoinRequestCreated, domain.CoinRequestCreated{
+			RequestID:     created.ID,
+			Requester:     created.Requester,
+			RequesterName: created.RequesterName,
+			Payer:         created.Payer,
+			PayerName:     created.PayerName,
+			Amount:        created.Amount,
+			ExpiresAt:     created.ExpiresAt,
+		})
+	})
+	if err != nil {
+		var pgError *pgconn.PgError
+		if errors.As(err, &pgError) {
+			if pgError.Code == PgForeignKeyViolation {
+				return domain.CoinRequest{}, fmt.Errorf("CoinRequestRepository.Create: %w", domain.ErrUserNotFound)
+			}
+		}
+		return domain.CoinRequest{}, fmt.Errorf("CoinRequestRepository.Create: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *CoinRequestRepository) GetByID(ctx context.Context, id domain.CoinRequestID) (domain.CoinRequest, error) {
+	ctx, span := tracer.Start(ctx, "CoinRequestRepository.GetByID")
+	defer span.End()
+
+	query := selectCoinRequests + `
+              WHERE cr.id = $1`
+
+	req, err := scanCoinRequest(r.runner.conn(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.CoinRequest{}, fmt.Errorf("CoinRequestRepository.GetByID: %w", domain.ErrCoinRequestNotFound)
+		}
+		return domain.CoinRequest{}, fmt.Errorf("CoinRequestRepository.GetByID: %w", err)
+	}
+
+	return req, nil
+}
+
+func (r *CoinRequestRepository) ListByPayer(ctx context.Context, uid domain.UserID) ([]domain.CoinRequest, error) {
+	ctx, span := tracer.Start(ctx, "CoinRequestRepository.ListByPayer")
+	defer span.End()
+
+	query := selectCoinRequests + `
+              WHERE cr.payer = $1
+              ORDER BY cr.created_at DESC`
+
+	reqs, err := r.list(ctx, query, uid)
+	if err != nil {
+		return nil, fmt.Errorf("CoinRequestRepository.ListByPayer: %w", err)
+	}
+
+	return reqs, nil
+}
+
+func (r *CoinRequestRepository) ListByRequester(ctx context.Context, uid domain.UserID) ([]domain.CoinRequest, error) {
+	ctx, span := tracer.Start(ctx, "CoinRequestRepository.ListByRequester")
+	defer span.End()
+
+	query := selectCoinRequests + `
+              WHERE cr.requester = $1
+              ORDER BY cr.created_at DESC`
+
+	reqs, err := r.list(ctx, query, uid)
+	if err != nil {
+		return nil, fmt.Errorf("CoinRequestRepository.ListByRequester: %w", err)
+	}
+
+	return reqs, nil
+}
+
+// Resolve records a decline together with its event. An accepted request is resolved in the same
+// unit of work as its payment, so its requester learns about it from the payment instead.
+func (r *CoinRequestRepository) Resolve(
+	ctx context.Context,
+	id domain.CoinRequestID,
+	status domain.CoinRequestStatus,
+) error {
+	ctx, span := tracer.Start(ctx, "CoinRequestRepository.Resolve")
+	defer span.End()
+
+	query := `WITH cr AS (
+                  UPDATE coin_requests
+                  SET status = $2, resolved_at = now()
+                  WHERE id = $1 AND status = 'pending' AND expires_at > now()
+                  RETURNING requester, payer, amount
+              )
+              SELECT cr.requester, cr.payer, e_pay.username, cr.amount
+              FROM cr
+              JOIN employees e_pay ON cr.payer = e_pay.id`
+
+	err := r.runner.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		event := domain.CoinRequestDeclinedEvent{RequestID: id}
+
+		err := dbTx.QueryRow(ctx, query, id, status).Scan(&event.Requester, &event.Payer, &event.PayerName, &event.Amount)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrCoinRequestNotActive
+			}
+			return err
+		}
+
+		if status != domain.CoinRequestDeclined {
+			return nil
+		}
+
+		return insertEvent(ctx, dbTx, domain.EventCoinRequestDeclined, event)
+	})
+	if err != nil {
+		return fmt.Errorf("CoinRequestRepository.Resolve: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CoinRequestRepository) list(
+	ctx context.Context,
+	query string,
+	uid domain.UserID,
+) ([]domain.CoinRequest, error) {
+	rows, err := r.runner.conn(ctx).Query(ctx, query, uid)
+	if err != nil {
+		return nil, fmt.Errorf("CoinRequestRepository.list: %w", err)
+	}
+	defer func() { rows.Close() }()
+
+	var reqs []domain.CoinRequest
+	for rows.Next() {
+		var req domain.CoinRequest
+
+		if req, err = scanCoinRequest(rows); err != nil {
+			return nil, fmt.Errorf("CoinRequestRepository.list: %w", err)
+		}
+
+		reqs = append(reqs, req)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("CoinRequestRepository.list: %w", err)
+	}
+
+	return reqs, nil
+}
+
+func scanCoinRequest(row pgx.Row) (domain.CoinRequest, error) {
+	var req domain.CoinRequest
+
+	err := row.Scan(
+		&req.ID,
+		&req.Requester,
+		&req.RequesterName,
+		&req.Payer,
+		&req.PayerName,
+		&req.Amount,
+		&req.Status,
+		&req.CreatedAt,
+		&req.ExpiresAt,
+	)
+	if err != nil {
+		return domain.CoinRequest{}, err
+	}
+
+	return req, nil
+}
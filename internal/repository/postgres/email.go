@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmailRepository struct {
+	runner txRunner
+}
+
+func NewEmailRepository(dbPool *pgxpool.Pool) repository.Email {
+	return &EmailRepository{
+		runner: newTxRunner(dbPool),
+	}
+}
+
+func (r *EmailRepository) GetPreferences(ctx context.Context, uid domain.UserID) (domain.EmailPreferences, error) {
+	ctx, span := tracer.Start(ctx, "EmailRepository.GetPreferences")
+	defer span.End()
+
+	prefs := domain.EmailPreferences{User: uid}
+
+	query := `SELECT address, coins_received, merch_purchased
+              FROM email_preferences
+              WHERE employee_id = $1`
+
+	err := r.runner.conn(ctx).QueryRow(ctx, query, uid).Scan(&prefs.Address, &prefs.CoinsReceived, &prefs.MerchPurchased)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return domain.EmailPreferences{}, fmt.Errorf("EmailRepository.GetPreferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+func (r *EmailRepository) SetPreferences(ctx context.Context, prefs domain.EmailPreferences) error {
+	ctx, span := tracer.Start(ctx, "EmailRepository.SetPreferences")
+	defer span.End()
+
+	query := `INSERT INTO email_preferences (employee_id, address, coins_received, merch_purchased)
+              VALUES ($1, $2, $3, $4)
+              ON CONFLICT (employee_id) DO UPDATE
+              SET address = excluded.address,
+                  coins_received = excluded.coins_received,
+                  merch_purchased = excluded.merch_purchased,
+                  updated_at = now()`
+	args := []any{prefs.User, prefs.Address, prefs.CoinsReceived, prefs.MerchPurchased}
+
+	if prefs.Address == "" {
+		query = `DELETE FROM email_preferences WHERE employee_id = $1`
+		args = args[:1]
+	}
+
+	_, err := r.runner.exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("EmailRepository.SetPreferences: %w", err)
+	}
+
+	return nil
+}
+
+func (r *EmailRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]domain.Email, error) {
+	ctx, span := tracer.Start(ctx, "EmailRepository.ClaimDue")
+	defer span.End()
+
+	query := `WITH q AS (
+                  UPDATE email_queue
+                  SET next_attempt_at = now() + make_interval(secs => $2)
+                  WHERE id IN (
+                      SELECT id
+                      FROM email_queue
+                      WHERE status = 'pending'
+                        AND next_attempt_at <= now()
+                      ORDER BY next_attempt_at
+                      LIMIT $1
+                      FOR UPDATE SKIP LOCKED
+                  )
+                  RETURNING *
+              )
+              SELECT q.id, q.employee_id, q.address, q.event_id, q.kind, o.payload, o.created_at,
+                     q.status, q.attempts, q.next_attempt_at, COALESCE(q.last_error, '')
+              FROM q
+              JOIN outbox o ON o.id = q.event_id
+              ORDER BY q.id`
+
+	var emails []domain.Email
+
+	err := r.runner.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		rows, err := dbTx.Query(ctx, query, limit, lease.Seconds())
+		if err != nil {
+			return err
+		}
+
+		emails, err = pgx.CollectRows(rows, scanEmail)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("EmailRepository.ClaimDue: %w", err)
+	}
+
+	return emails, nil
+}
+
+func (r *EmailRepository) Record(ctx context.Context, email domain.Email) error {
+	ctx, span := tracer.Start(ctx, "EmailRepository.Record")
+	defer span.End()
+
+	query := `UPDATE email_queue
+              SET status = $2,
+                  attempts = $3,
+                  next_attempt_at = $4,
+                  last_error = NULLIF($5, ''),
+                  sent_at = CASE WHEN $2 = 'sent' THEN now() END
+              WHERE id = $1`
+
+	_, err := r.runner.exec(ctx, query, email.ID, email.Status, email.Attempts, email.NextAttemptAt, email.LastError)
+	if err != nil {
+		return fmt.Errorf("EmailRepository.Record: %w", err)
+	}
+
+	return nil
+}
+
+func scanEmail(row pgx.CollectableRow) (domain.Email, error) {
+	var e domain.Email
+
+	err := row.Scan(
+		&e.ID,
+		&e.User,
+		&e.Address,
+		&e.Event.ID,
+		&e.Event.Name,
+		&e.Event.Data,
+		&e.Event.CreatedAt,
+		&e.Status,
+		&e.Attempts,
+		&e.NextAttemptAt,
+		&e.LastError,
+	)
+
+	return e, err
+}
@@ -1,6 +1,10 @@
 package postgres
 
 const (
-	PgUniqueViolation = "23505"
-	PgCheckViolation  = "23514"
+	PgUniqueViolation     = "23505"
+	PgCheckViolation      = "23514"
+	PgForeignKeyViolation = "23503"
+
+	PgSerializationFailure = "40001"
+	PgDeadlockDetected     = "40P01"
 )
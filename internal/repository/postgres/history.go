@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HistoryRepository struct {
+	runner txRunner
+}
+
+func NewHistoryRepository(dbPool *pgxpool.Pool) repository.History {
+	return &HistoryRepository{
+		runner: newTxRunner(dbPool),
+	}
+}
+
+// ListTransactions returns the latest transactions of the user, newest first.
+// A counterpart whose account was deleted comes back as zero.
+func (r *HistoryRepository) ListTransactions(
+	ctx context.Context,
+	uid domain.UserID,
+	limit int,
+) ([]domain.TransactionRecord, error) {
+	ctx, span := tracer.Start(ctx, "HistoryRepository.ListTransactions")
+	defer span.End()
+
+	query := `SELECT id, COALESCE(sender, 0), COALESCE(recipient, 0), amount, COALESCE(reason, ''), created_at
+              FROM coin_transactions
+              WHERE sender = $1 OR recipient = $1
+              ORDER BY created_at DESC, id DESC
+              LIMIT $2`
+
+	rows, err := r.runner.conn(ctx).Query(ctx, query, uid, limit)
+	if err != nil {
+		return nil, fmt.Errorf("HistoryRepository.ListTransactions: %w", err)
+	}
+
+	txs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.TransactionRecord, error) {
+		var tx domain.TransactionRecord
+		return tx, row.Scan(&tx.ID, &tx.From, &tx.To, &tx.Amount, &tx.Reason, &tx.CreatedAt)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("HistoryRepository.ListTransactions: %w", err)
+	}
+
+	return txs, nil
+}
+
+func (r *HistoryRepository) ListOrders(ctx context.Context, uid domain.UserID, limit int) ([]domain.Order, error) {
+	ctx, span := tracer.Start(ctx, "HistoryRepository.ListOrders")
+	defer span.End()
+
+	query := `SELECT o.id, o.employee_id, m.name, o.price, o.transaction_id, o.created_at
+              FROM orders o
+              JOIN merch m ON m.id = o.merch_id
+              WHERE o.employee_id = $1
+              ORDER BY o.created_at DESC, o.id DESC
+              LIMIT $2`
+
+	rows, err := r.runner.conn(ctx).Query(ctx, query, uid, limit)
+	if err != nil {
+		return nil, fmt.Errorf("HistoryRepository.ListOrders: %w", err)
+	}
+
+	orders, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Order, error) {
+		var o domain.Order
+		return o, row.Scan(&o.ID, &o.User, &o.Item, &o.Price, &o.TransactionID, &o.CreatedAt)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("HistoryRepository.ListOrders: %w", err)
+	}
+
+	return orders, nil
+}
@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ledgerPosting changes the balance of one account: negative amounts leave it, positive ones arrive.
+type ledgerPosting struct {
+	Account domain.UserID
+	Amount  int
+}
+
+// postEntry writes a journal entry and applies its postings to the stored balances.
+// Every balance change must go through here, so employees.coins never drifts from the ledger.
+// The system account issues and absorbs coins, its stored balance is not maintained.
+func postEntry(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	txID *domain.TransactionID,
+	memo string,
+	postings ...ledgerPosting,
+) error {
+	var sum int
+	for _, p := range postings {
+		sum += p.Amount
+	}
+
+	if sum != 0 {
+		return fmt.Errorf("postEntry: unbalanced entry, postings sum up to %d", sum)
+	}
+
+	var entryID int
+
+	query := `INSERT INTO ledger_entries (transaction_id, memo)
+              VALUES ($1, NULLIF($2, ''))
+              RETURNING id`
+
+	err := dbTx.QueryRow(ctx, query, txID, memo).Scan(&entryID)
+	if err != nil {
+		return fmt.Errorf("postEntry: %w", err)
+	}
+
+	for _, p := range postings {
+		if p.Amount == 0 {
+			continue
+		}
+
+		query = `INSERT INTO ledger_postings (entry_id, account, amount)
+                 VALUES ($1, $2, $3)`
+
+		_, err = dbTx.Exec(ctx, query, entryID, p.Account, p.Amount)
+		if err != nil {
+			return fmt.Errorf("postEntry: %w", mapLedgerError(err))
+		}
+
+		if p.Account == repository.SystemDBID {
+			continue
+		}
+
+		query = `UPDATE employees
+                 SET coins = coins + $2
+                 WHERE id = $1`
+
+		_, err = dbTx.Exec(ctx, query, p.Account, p.Amount)
+		if err != nil {
+			return fmt.Errorf("postEntry: %w", mapLedgerError(err))
+		}
+	}
+
+	return nil
+}
+
+// postTransfer posts an entry moving tx.Amount from tx.From to tx.To for a logged transaction.
+func postTransfer(ctx context.Context, dbTx pgx.Tx, tx domain.Transaction) error {
+	err := postEntry(ctx, dbTx, &tx.ID, "",
+		ledgerPosting{Account: tx.From, Amount: -tx.Amount},
+		ledgerPosting{Account: tx.To, Amount: tx.Amount},
+	)
+	if err != nil {
+		return fmt.Errorf("postTransfer: %w", err)
+	}
+
+	return nil
+}
+
+func mapLedgerError(err error) error {
+	var pgError *pgconn.PgError
+	if errors.As(err, &pgError) {
+		switch pgError.Code {
+		case PgCheckViolation:
+			return domain.ErrLowBalance
+		case PgForeignKeyViolation:
+			return domain.ErrUserNotFound
+		}
+	}
+
+	return err
+}
+
+type LedgerRepository struct {
+	runner txRunner
+}
+
+func NewLedgerRepository(dbPool *pgxpool.Pool) repository.Ledger {
+	return &LedgerRepository{
+		runner: newTxRunner(dbPool),
+	}
+}
+
+// Verify proves the ledger and the stored balances agree: every entry must sum up to zero
+// and every stored balance must equal the sum of the account's postings.
+func (r *LedgerRepository) Verify(ctx context.Context) (domain.LedgerVerification, error) {
+	ctx, span := tracer.Start(ctx, "LedgerRepository.Verify")
+	defer span.End()
+
+	var res domain.LedgerVerification
+
+	opts := pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	}
+
+	err := r.runner.run(ctx, opts, func(dbTx pgx.Tx) error {
+		query := `SELECT count(*), COALESCE(SUM(amount), 0) FROM ledger_postings`
+		err := dbTx.QueryRow(ctx, query).Scan(&res.Postings, &res.Total)
+		if err != nil {
+			return err
+		}
+
+		res.UnbalancedEntries, err = r.getUnbalancedEntries(ctx, dbTx)
+		if err != nil {
+			return err
+		}
+
+		res.Mismatches, err = r.getBalanceMismatches(ctx, dbTx)
+		return err
+	})
+	if err != nil {
+		return domain.LedgerVerification{}, fmt.Errorf("LedgerRepository.Verify: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *LedgerRepository) getUnbalancedEntries(ctx context.Context, dbTx pgx.Tx) ([]int, error) {
+	query := `SELECT entry_id
+              FROM ledger_postings
+              GROUP BY entry_id
+              HAVING SUM(amount) <> 0
+              ORDER BY entry_id`
+
+	rows, err := dbTx.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("LedgerRepository.getUnbalancedEntries: %w", err)
+	}
+
+	entries, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("LedgerRepository.getUnbalancedEntries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *LedgerRepository) getBalanceMismatches(ctx context.Context, dbTx pgx.Tx) ([]domain.BalanceMismatch, error) {
+	query := `SELECT e.id, e.username, e.coins, COALESCE(SUM(p.amount), 0)
+              FROM employees e
+              LEFT JOIN ledger_postings p ON p.account = e.id
+              WHERE e.id <> $1
+              GROUP BY e.id
+              HAVING e.coins <> COALESCE(SUM(p.amount), 0)
+              ORDER BY e.id`
+
+	rows, err := dbTx.Query(ctx, query, repository.SystemDBID)
+	if err != nil {
+		return nil, fmt.Errorf("LedgerRepository.getBalanceMismatches: %w", err)
+	}
+	defer func() { rows.Close() }()
+
+	var mismatches []domain.BalanceMismatch
+	for rows.Next() {
+		var m domain.BalanceMismatch
+
+		if err = rows.Scan(&m.Account, &m.Name, &m.Stored, &m.Posted); err != nil {
+			return nil, fmt.Errorf("LedgerRepository.getBalanceMismatches: %w", err)
+		}
+
+		mismatches = append(mismatches, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("LedgerRepository.getBalanceMismatches: %w", err)
+	}
+
+	return mismatches, nil
+}
@@ -13,18 +13,21 @@ import (
 )
 
 type MerchRepository struct {
-	pool        *pgxpool.Pool
+	runner      txRunner
 	cacheByName sync.Map
 }
 
 func NewMerchRepository(dbPool *pgxpool.Pool) repository.Merch {
 	return &MerchRepository{
-		pool:        dbPool,
+		runner:      newTxRunner(dbPool),
 		cacheByName: sync.Map{},
 	}
 }
 
 func (r *MerchRepository) GetByName(ctx context.Context, name string) (domain.Merch, error) {
+	ctx, span := tracer.Start(ctx, "MerchRepository.GetByName")
+	defer span.End()
+
 	var item domain.Merch
 
 	if val, ok := r.cacheByName.Load(name); ok {
@@ -39,7 +42,7 @@ func (r *MerchRepository) GetByName(ctx context.Context, name string) (domain.Me
               FROM merch
               WHERE name = $1`
 
-	err := r.pool.QueryRow(ctx, query, name).Scan(&item.ID, &item.Price)
+	err := r.runner.conn(ctx).QueryRow(ctx, query, name).Scan(&item.ID, &item.Price)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.Merch{}, fmt.Errorf("MerchRepository.GetByName: %w", domain.ErrMerchNotFound)
@@ -51,3 +54,47 @@ func (r *MerchRepository) GetByName(ctx context.Context, name string) (domain.Me
 
 	return item, nil
 }
+
+func (r *MerchRepository) GetByNames(ctx context.Context, names []domain.MerchName) ([]domain.Merch, error) {
+	ctx, span := tracer.Start(ctx, "MerchRepository.GetByNames")
+	defer span.End()
+
+	query := `SELECT id, name, price
+              FROM merch
+              WHERE name = ANY ($1)`
+
+	items, err := r.list(ctx, query, names)
+	if err != nil {
+		return nil, fmt.Errorf("MerchRepository.GetByNames: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *MerchRepository) List(ctx context.Context) ([]domain.Merch, error) {
+	ctx, span := tracer.Start(ctx, "MerchRepository.List")
+	defer span.End()
+
+	query := `SELECT id, name, price
+              FROM merch
+              ORDER BY price, name`
+
+	items, err := r.list(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("MerchRepository.List: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *MerchRepository) list(ctx context.Context, query string, args ...any) ([]domain.Merch, error) {
+	rows, err := r.runner.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Merch, error) {
+		var item domain.Merch
+		return item, row.Scan(&item.ID, &item.Name, &item.Price)
+	})
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NotificationRepository struct {
+	runner txRunner
+}
+
+func NewNotificationRepository(dbPool *pgxpool.Pool) repository.Notification {
+	return &NotificationRepository{
+		runner: newTxRunner(dbPool),
+	}
+}
+
+func (r *NotificationRepository) ListUnread(
+	ctx context.Context,
+	uid domain.UserID,
+	after domain.EventID,
+	limit int,
+) ([]domain.StreamEvent, error) {
+	ctx, span := tracer.Start(ctx, "NotificationRepository.ListUnread")
+	defer span.End()
+
+	query := `SELECT n.event_id, n.kind, o.payload, o.created_at
+              FROM notifications n
+              JOIN outbox o ON o.id = n.event_id
+              WHERE n.employee_id = $1 AND n.event_id > $2 AND n.read_at IS NULL
+              ORDER BY n.event_id
+              LIMIT $3`
+
+	rows, err := r.runner.conn(ctx).Query(ctx, query, uid, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("NotificationRepository.ListUnread: %w", err)
+	}
+
+	notifications, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.StreamEvent, error) {
+		var e domain.StreamEvent
+		return e, row.Scan(&e.ID, &e.Name, &e.Data, &e.CreatedAt)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("NotificationRepository.ListUnread: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, uid domain.UserID, ids []domain.EventID) (int, error) {
+	ctx, span := tracer.Start(ctx, "NotificationRepository.MarkRead")
+	defer span.End()
+
+	query := `UPDATE notifications
+              SET read_at = now()
+              WHERE employee_id = $1 AND event_id = ANY ($2) AND read_at IS NULL`
+
+	tag, err := r.runner.exec(ctx, query, uid, ids)
+	if err != nil {
+		return 0, fmt.Errorf("NotificationRepository.MarkRead: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// insertEvent writes an event to the outbox, it must be called inside the transaction making
+// the change, so the event is published if and only if the change is committed.
+// A delivery is queued for every webhook subscribed to the event type at the same time,
+// and so are the notifications of the users the event concerns along with their emails.
+func insertEvent(ctx context.Context, dbTx pgx.Tx, eventType domain.EventType, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("insertEvent: %w", err)
+	}
+
+	query := `WITH event AS (
+                  INSERT INTO outbox (event_type, payload)
+                  VALUES ($1, $2)
+                  RETURNING id
+              ), deliveries AS (
+                  INSERT INTO webhook_deliveries (subscription_id, event_id)
+                  SELECT s.id, event.id
+                  FROM event, webhook_subscriptions s
+                  WHERE $1 = ANY (s.event_types)
+              )
+              SELECT id FROM event`
+
+	event := domain.Event{Type: eventType, Payload: body}
+
+	err = dbTx.QueryRow(ctx, query, eventType, body).Scan(&event.ID)
+	if err != nil {
+		return fmt.Errorf("insertEvent: %w", err)
+	}
+
+	err = insertNotifications(ctx, dbTx, event)
+	if err != nil {
+		return fmt.Errorf("insertEvent: %w", err)
+	}
+
+	return nil
+}
+
+func insertNotifications(ctx context.Context, dbTx pgx.Tx, event domain.Event) error {
+	targets, err := domain.StreamEventsOf(event)
+	if err != nil {
+		return fmt.Errorf("insertNotifications: %w", err)
+	}
+
+	var (
+		users []domain.UserID
+		kinds []domain.StreamEventName
+	)
+	for uid, e := range targets {
+		if domain.IsNotification(e) && uid != repository.ShopDBID && uid != repository.SystemDBID {
+			users = append(users, uid)
+			kinds = append(kinds, e.Name)
+		}
+	}
+
+	if len(users) == 0 {
+		return nil
+	}
+
+	query := `WITH n AS (
+                  INSERT INTO notifications (employee_id, event_id, kind)
+                  SELECT u.employee_id, $2, u.kind
+                  FROM unnest($1::INT[], $3::VARCHAR[]) AS u (employee_id, kind)
+                  RETURNING employee_id, event_id, kind
+              )
+              INSERT INTO email_queue (employee_id, address, event_id, kind)
+              SELECT n.employee_id, p.address, n.event_id, n.kind
+              FROM n
+              JOIN email_preferences p ON p.employee_id = n.employee_id
+              WHERE (n.kind = $4 AND p.coins_received) OR (n.kind = $5 AND p.merch_purchased)`
+
+	_, err = dbTx.Exec(ctx, query, users, event.ID, kinds, domain.StreamCoinsReceived, domain.StreamMerchPurchased)
+	if err != nil {
+		return fmt.Errorf("insertNotifications: %w", err)
+	}
+
+	return nil
+}
+
+type OutboxRepository struct {
+	runner txRunner
+}
+
+func NewOutboxRepository(dbPool *pgxpool.Pool) repository.Outbox {
+	return &OutboxRepository{
+		runner: newTxRunner(dbPool),
+	}
+}
+
+// ClaimPending moves next_attempt_at past the lease, so a relay that dies mid-batch
+// only delays its events, and SKIP LOCKED keeps concurrent relays off each other's rows.
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]domain.Event, error) {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.ClaimPending")
+	defer span.End()
+
+	query := `UPDATE outbox
+              SET next_attempt_at = now() + make_interval(secs => $2)
+              WHERE id IN (
+                  SELECT id
+                  FROM outbox
+                  WHERE published_at IS NULL
+                    AND next_attempt_at <= now()
+                  ORDER BY id
+                  LIMIT $1
+                  FOR UPDATE SKIP LOCKED
+              )
+              RETURNING id, event_type, payload, created_at, attempts`
+
+	var events []domain.Event
+
+	err := r.runner.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		rows, err := dbTx.Query(ctx, query, limit, lease.Seconds())
+		if err != nil {
+			return err
+		}
+
+		events, err = pgx.CollectRows(rows, scanEvent)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepository.ClaimPending: %w", err)
+	}
+
+	// UPDATE ... RETURNING doesn't keep the subquery order
+	slices.SortFunc(events, func(a, b domain.Event) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return events, nil
+}
+
+func scanEvent(row pgx.CollectableRow) (domain.Event, error) {
+	var e domain.Event
+
+	err := row.Scan(&e.ID, &e.Type, &e.Payload, &e.CreatedAt, &e.Attempts)
+	if err != nil {
+		return domain.Event{}, err
+	}
+
+	return e, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id domain.EventID) error {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.MarkPublished")
+	defer span.End()
+
+	query := `UPDATE outbox
+              SET published_at = now(), attempts = attempts + 1, last_error = NULL
+              WHERE id = $1`
+
+	_, err := r.runner.exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("OutboxRepository.MarkPublished: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) MarkFailed(
+	ctx context.Context,
+	id domain.EventID,
+	reason string,
+	retryAt time.Time,
+) error {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.MarkFailed")
+	defer span.End()
+
+	query := `UPDATE outbox
+              SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+              WHERE id = $1 AND published_at IS NULL`
+
+	_, err := r.runner.exec(ctx, query, id, reason, retryAt)
+	if err != nil {
+		return fmt.Errorf("OutboxRepository.MarkFailed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,382 @@
+package postgres
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReconciliationRepository struct {
+	runner txRunner
+	// corrections move coins the same way regular transactions do
+	txRepo *TransactionRepository
+}
+
+func NewReconciliationRepository(dbPool *pgxpool.Pool) repository.Reconciliation {
+	return &ReconciliationRepository{
+		runner: newTxRunner(dbPool),
+		txRepo: newTransactionRepository(dbPool),
+	}
+}
+
+// expectedBalanceQuery recomputes balances as the opening balance plus received minus sent coins.
+// Corrective transactions are left out, otherwise every correction would shift the expected value too.
+const expectedBalanceQuery = `SELECT e.id, e.username, e.coins,
+                                     $1 + COALESCE(SUM(CASE WHEN ct.recipient = e.id
+                                                            THEN ct.amount
+                                                            ELSE -ct.amount END), 0)::INT
+                              FROM employees e
+                              LEFT JOIN coin_transactions ct
+                                  ON (ct.sender = e.id OR ct.recipient = e.id) AND ct.corrects IS NULL
+                              WHERE e.id <> $2 AND ($3::INT IS NULL OR e.id = $3)
+                              GROUP BY e.id`
+
+// Run recomputes every balance from history and stores the discrepancies as a new dry-run report.
+func (r *ReconciliationRepository) Run(ctx context.Context) (domain.Reconciliation, error) {
+	ctx, span := tracer.Start(ctx, "ReconciliationRepository.Run")
+	defer span.End()
+
+	var rec domain.Reconciliation
+
+	err := r.runner.run(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, func(dbTx pgx.Tx) error {
+		var err error
+		rec, err = r.run(ctx, dbTx)
+		return err
+	})
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.Run: %w", err)
+	}
+
+	return rec, nil
+}
+
+func (r *ReconciliationRepository) run(ctx context.Context, dbTx pgx.Tx) (domain.Reconciliation, error) {
+	var rec domain.Reconciliation
+
+	rows, err := dbTx.Query(ctx, expectedBalanceQuery, domain.OpeningBalance, repository.SystemDBID, nil)
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.run: %w", err)
+	}
+
+	for rows.Next() {
+		var d domain.BalanceDiscrepancy
+
+		if err = rows.Scan(&d.Account, &d.Name, &d.Stored, &d.Expected); err != nil {
+			rows.Close()
+			return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.run: %w", err)
+		}
+
+		rec.Checked++
+		if d.Diff() != 0 {
+			rec.Discrepancies = append(rec.Discrepancies, d)
+		}
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.run: %w", err)
+	}
+
+	query := `INSERT INTO reconciliations (checked)
+              VALUES ($1)
+              RETURNING id, started_at`
+
+	err = dbTx.QueryRow(ctx, query, rec.Checked).Scan(&rec.ID, &rec.StartedAt)
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.run: %w", err)
+	}
+
+	for _, d := range rec.Discrepancies {
+		query = `INSERT INTO reconciliation_discrepancies (reconciliation_id, employee_id, stored, expected)
+                 VALUES ($1, $2, $3, $4)`
+
+		_, err = dbTx.Exec(ctx, query, rec.ID, d.Account, d.Stored, d.Expected)
+		if err != nil {
+			return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.run: %w", err)
+		}
+	}
+
+	return rec, nil
+}
+
+func (r *ReconciliationRepository) GetLatest(ctx context.Context) (domain.Reconciliation, error) {
+	ctx, span := tracer.Start(ctx, "ReconciliationRepository.GetLatest")
+	defer span.End()
+
+	var id domain.ReconciliationID
+
+	query := `SELECT id FROM reconciliations ORDER BY id DESC LIMIT 1`
+	err := r.runner.conn(ctx).QueryRow(ctx, query).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.GetLatest: %w",
+				domain.ErrReconciliationNotFound)
+		}
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.GetLatest: %w", err)
+	}
+
+	rec, err := r.get(ctx, r.runner.conn(ctx), id)
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.GetLatest: %w", err)
+	}
+
+	return rec, nil
+}
+
+// Apply writes corrective transactions for a dry-run report. An account is only corrected when
+// both its stored and its recomputed balance are still the ones the report saw, so a balance
+// that moved since the dry run is never "fixed" blindly. An account that can't be corrected
+// (e.g. its recomputed balance is negative) is left without a correction, the rest still are.
+func (r *ReconciliationRepository) Apply(
+	ctx context.Context,
+	id domain.ReconciliationID,
+	adminID domain.UserID,
+) (domain.Reconciliation, error) {
+	ctx, span := tracer.Start(ctx, "ReconciliationRepository.Apply")
+	defer span.End()
+
+	var rec domain.Reconciliation
+
+	err := r.runner.run(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, func(dbTx pgx.Tx) error {
+		var err error
+		rec, err = r.apply(ctx, dbTx, id, adminID)
+		return err
+	})
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.Apply: %w", err)
+	}
+
+	return rec, nil
+}
+
+func (r *ReconciliationRepository) apply(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	id domain.ReconciliationID,
+	adminID domain.UserID,
+) (domain.Reconciliation, error) {
+	var appliedAt *time.Time
+
+	query := `SELECT applied_at FROM reconciliations WHERE id = $1 FOR UPDATE`
+	err := dbTx.QueryRow(ctx, query, id).Scan(&appliedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = domain.ErrReconciliationNotFound
+		}
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.apply: %w", err)
+	}
+
+	if appliedAt != nil {
+		err = domain.ErrReconciliationAlreadyApplied
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.apply: %w", err)
+	}
+
+	rec, err := r.get(ctx, dbTx, id)
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.apply: %w", err)
+	}
+
+	for _, d := range rec.Discrepancies {
+		err = r.correctInSavepoint(ctx, dbTx, id, adminID, d)
+		if err != nil {
+			return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.apply: %w", err)
+		}
+	}
+
+	query = `UPDATE reconciliations SET applied_at = now(), applied_by = $2 WHERE id = $1`
+	_, err = dbTx.Exec(ctx, query, id, adminID)
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.apply: %w", err)
+	}
+
+	rec, err = r.get(ctx, dbTx, id)
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.apply: %w", err)
+	}
+
+	return rec, nil
+}
+
+// correctInSavepoint skips the correction of d when the balance it leaves would be negative,
+// the savepoint keeps the failed correction from aborting the others.
+func (r *ReconciliationRepository) correctInSavepoint(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	id domain.ReconciliationID,
+	adminID domain.UserID,
+	d domain.BalanceDiscrepancy,
+) error {
+	savepoint, err := dbTx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("ReconciliationRepository.correctInSavepoint: %w", err)
+	}
+
+	err = r.correct(ctx, savepoint, id, adminID, d)
+	if err != nil {
+		rollbackErr := savepoint.Rollback(ctx)
+		if errors.Is(err, domain.ErrLowBalance) && rollbackErr == nil {
+			return nil
+		}
+		return fmt.Errorf("ReconciliationRepository.correctInSavepoint: %w", err)
+	}
+
+	err = savepoint.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("ReconciliationRepository.correctInSavepoint: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReconciliationRepository) correct(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	id domain.ReconciliationID,
+	adminID domain.UserID,
+	d domain.BalanceDiscrepancy,
+) error {
+	_, err := r.txRepo.getUserBalanceForUpdate(ctx, dbTx, d.Account)
+	if err != nil {
+		return fmt.Errorf("ReconciliationRepository.correct: %w", err)
+	}
+
+	var cur domain.BalanceDiscrepancy
+
+	err = dbTx.QueryRow(ctx, expectedBalanceQuery, domain.OpeningBalance, repository.SystemDBID, d.Account).
+		Scan(&cur.Account, &cur.Name, &cur.Stored, &cur.Expected)
+	if err != nil {
+		return fmt.Errorf("ReconciliationRepository.correct: %w", err)
+	}
+
+	if cur.Stored != d.Stored || cur.Expected != d.Expected {
+		return nil
+	}
+
+	tx := domain.Transaction{From: repository.SystemDBID, To: d.Account, Amount: d.Diff()}
+	if tx.Amount < 0 {
+		tx.From, tx.To, tx.Amount = d.Account, repository.SystemDBID, -tx.Amount
+		err = r.dropLots(ctx, dbTx, tx.From, tx.Amount)
+	} else {
+		err = r.txRepo.insertLots(ctx, dbTx, tx.To, []coinLot{{Amount: tx.Amount}})
+	}
+	if err != nil {
+		return fmt.Errorf("ReconciliationRepository.correct: %w", err)
+	}
+
+	query := `INSERT INTO coin_transactions
+    		  (sender, recipient, amount, reason, performed_by, corrects)
+              VALUES ($1, $2, $3, 'reconciliation #' || $5::INT, $4, $5)
+              RETURNING id`
+
+	err = dbTx.QueryRow(ctx, query, tx.From, tx.To, tx.Amount, adminID, id).Scan(&tx.ID)
+	if err != nil {
+		return fmt.Errorf("ReconciliationRepository.correct: %w", err)
+	}
+
+	err = postTransfer(ctx, dbTx, tx)
+	if err != nil {
+		return fmt.Errorf("ReconciliationRepository.correct: %w", err)
+	}
+
+	query = `UPDATE reconciliation_discrepancies
+             SET correction = $3
+             WHERE reconciliation_id = $1 AND employee_id = $2`
+
+	_, err = dbTx.Exec(ctx, query, id, d.Account, tx.ID)
+	if err != nil {
+		return fmt.Errorf("ReconciliationRepository.correct: %w", err)
+	}
+
+	return nil
+}
+
+// dropLots takes up to amount coins from the lots of the account. The surplus being corrected
+// often has no lots behind it, so the balance is lowered by the ledger posting regardless.
+func (r *ReconciliationRepository) dropLots(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	id domain.UserID,
+	amount int,
+) error {
+	covered, err := r.txRepo.getSpendableBalanceForUpdate(ctx, dbTx, id)
+	if err != nil {
+		return fmt.Errorf("ReconciliationRepository.dropLots: %w", err)
+	}
+
+	if amount = min(amount, covered); amount <= 0 {
+		return nil
+	}
+
+	_, err = r.txRepo.consumeLots(ctx, dbTx, id, amount)
+	if err != nil {
+		return fmt.Errorf("ReconciliationRepository.dropLots: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReconciliationRepository) get(
+	ctx context.Context,
+	q querier,
+	id domain.ReconciliationID,
+) (domain.Reconciliation, error) {
+	var (
+		rec       = domain.Reconciliation{ID: id}
+		appliedAt *time.Time
+		appliedBy *domain.UserID
+	)
+
+	query := `SELECT started_at, checked, applied_at, applied_by
+              FROM reconciliations
+              WHERE id = $1`
+
+	err := q.QueryRow(ctx, query, id).Scan(&rec.StartedAt, &rec.Checked, &appliedAt, &appliedBy)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.get: %w",
+				domain.ErrReconciliationNotFound)
+		}
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.get: %w", err)
+	}
+
+	if appliedAt != nil {
+		rec.AppliedAt = *appliedAt
+	}
+	if appliedBy != nil {
+		rec.AppliedBy = *appliedBy
+	}
+
+	query = `SELECT d.employee_id, e.username, d.stored, d.expected, COALESCE(d.correction, 0)
+             FROM reconciliation_discrepancies d
+             JOIN employees e ON e.id = d.employee_id
+             WHERE d.reconciliation_id = $1
+             ORDER BY d.employee_id`
+
+	rows, err := q.Query(ctx, query, id)
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.get: %w", err)
+	}
+	defer func() { rows.Close() }()
+
+	for rows.Next() {
+		var d domain.BalanceDiscrepancy
+
+		if err = rows.Scan(&d.Account, &d.Name, &d.Stored, &d.Expected, &d.Correction); err != nil {
+			return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.get: %w", err)
+		}
+
+		rec.Discrepancies = append(rec.Discrepancies, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationRepository.get: %w", err)
+	}
+
+	return rec, nil
+}
@@ -0,0 +1,292 @@
+package postgres
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ScheduledTransferRepository struct {
+	runner txRunner
+}
+
+func NewScheduledTransferRepository(dbPool *pgxpool.Pool) repository.ScheduledTransfer {
+	return &ScheduledTransferRepository{
+		runner: newTxRunner(dbPool),
+	}
+}
+
+const scheduledTransferColumns = `st.id,
+    		  st.sender,
+    		  st.recipient,
+    		  e_to.username,
+    		  st.amount,
+    		  st.interval_sec,
+    		  st.next_run_at,
+    		  st.active,
+    		  st.created_at`
+
+func (r *ScheduledTransferRepository) Create(
+	ctx context.Context,
+	st domain.ScheduledTransfer,
+) (domain.ScheduledTransfer, error) {
+	ctx, span := tracer.Start(ctx, "ScheduledTransferRepository.Create")
+	defer span.End()
+
+	query := `WITH st AS (
+                  INSERT INTO scheduled_transfers (sender, recipient, amount, interval_sec, next_run_at)
+                  VALUES ($1, $2, $3, $4, $5)
+                  RETURNING *
+              )
+              SELECT ` + scheduledTransferColumns + `
+              FROM st
+              JOIN employees e_to ON st.recipient = e_to.id`
+
+	var created domain.ScheduledTransfer
+
+	err := r.runner.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		var err error
+		created, err = scanScheduledTransfer(dbTx.QueryRow(ctx, query,
+			st.From, st.To, st.Amount, int64(st.Interval.Seconds()), st.NextRunAt,
+		))
+		return err
+	})
+	if err != nil {
+		var pgError *pgconn.PgError
+		if errors.As(err, &pgError) {
+			if pgError.Code == PgForeignKeyViolation {
+				return domain.ScheduledTransfer{}, fmt.Errorf(
+					"ScheduledTransferRepository.Create: %w", domain.ErrUserNotFound,
+				)
+			}
+		}
+		return domain.ScheduledTransfer{}, fmt.Errorf("ScheduledTransferRepository.Create: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *ScheduledTransferRepository) ListBySender(
+	ctx context.Context,
+	uid domain.UserID,
+) ([]domain.ScheduledTransfer, error) {
+	ctx, span := tracer.Start(ctx, "ScheduledTransferRepository.ListBySender")
+	defer span.End()
+
+	query := `SELECT ` + scheduledTransferColumns + `
+			  FROM scheduled_transfers st
+              JOIN employees e_to ON st.recipient = e_to.id
+              WHERE st.sender = $1
+              ORDER BY st.created_at DESC`
+
+	rows, err := r.runner.conn(ctx).Query(ctx, query, uid)
+	if err != nil {
+		return nil, fmt.Errorf("ScheduledTransferRepository.ListBySender: %w", err)
+	}
+
+	sts, err := collectScheduledTransfers(rows)
+	if err != nil {
+		return nil, fmt.Errorf("ScheduledTransferRepository.ListBySender: %w", err)
+	}
+
+	return sts, nil
+}
+
+func (r *ScheduledTransferRepository) Cancel(
+	ctx context.Context,
+	uid domain.UserID,
+	id domain.ScheduledTransferID,
+) error {
+	ctx, span := tracer.Start(ctx, "ScheduledTransferRepository.Cancel")
+	defer span.End()
+
+	query := `UPDATE scheduled_transfers
+              SET active = FALSE
+              WHERE id = $1 AND sender = $2`
+
+	tag, err := r.runner.exec(ctx, query, id, uid)
+	if err != nil {
+		return fmt.Errorf("ScheduledTransferRepository.Cancel: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("ScheduledTransferRepository.Cancel: %w", domain.ErrScheduledTransferNotFound)
+	}
+
+	return nil
+}
+
+func (r *ScheduledTransferRepository) ListRuns(
+	ctx context.Context,
+	uid domain.UserID,
+	id domain.ScheduledTransferID,
+) ([]domain.ScheduledTransferRun, error) {
+	ctx, span := tracer.Start(ctx, "ScheduledTransferRepository.ListRuns")
+	defer span.End()
+
+	query := `SELECT
+    		  run.transfer_id,
+    		  run.status,
+    		  COALESCE(run.reason, ''),
+    		  run.run_at
+			  FROM scheduled_transfer_runs run
+              JOIN scheduled_transfers st ON run.transfer_id = st.id
+              WHERE st.id = $1 AND st.sender = $2
+              ORDER BY run.run_at DESC`
+
+	rows, err := r.runner.conn(ctx).Query(ctx, query, id, uid)
+	if err != nil {
+		return nil, fmt.Errorf("ScheduledTransferRepository.ListRuns: %w", err)
+	}
+	defer func() { rows.Close() }()
+
+	var runs []domain.ScheduledTransferRun
+	for rows.Next() {
+		var run domain.ScheduledTransferRun
+
+		if err = rows.Scan(&run.TransferID, &run.Status, &run.Reason, &run.RunAt); err != nil {
+			return nil, fmt.Errorf("ScheduledTransferRepository.ListRuns: %w", err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ScheduledTransferRepository.ListRuns: %w", err)
+	}
+
+	return runs, nil
+}
+
+// ClaimDue relies on FOR UPDATE SKIP LOCKED, so concurrent replicas never lease the same row.
+func (r *ScheduledTransferRepository) ClaimDue(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]domain.ScheduledTransfer, error) {
+	ctx, span := tracer.Start(ctx, "ScheduledTransferRepository.ClaimDue")
+	defer span.End()
+
+	query := `WITH st AS (
+                  UPDATE scheduled_transfers
+                  SET locked_until = now() + make_interval(secs => $2)
+                  WHERE id IN (
+                      SELECT id
+                      FROM scheduled_transfers
+                      WHERE active
+                        AND next_run_at <= now()
+                        AND (locked_until IS NULL OR locked_until <= now())
+                      ORDER BY next_run_at
+                      LIMIT $1
+                      FOR UPDATE SKIP LOCKED
+                  )
+                  RETURNING *
+              )
+              SELECT ` + scheduledTransferColumns + `
+              FROM st
+              JOIN employees e_to ON st.recipient = e_to.id`
+
+	var sts []domain.ScheduledTransfer
+
+	err := r.runner.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		rows, err := dbTx.Query(ctx, query, limit, lease.Seconds())
+		if err != nil {
+			return err
+		}
+
+		sts, err = collectScheduledTransfers(rows)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ScheduledTransferRepository.ClaimDue: %w", err)
+	}
+
+	return sts, nil
+}
+
+func (r *ScheduledTransferRepository) Complete(
+	ctx context.Context,
+	claimed, next domain.ScheduledTransfer,
+	run domain.ScheduledTransferRun,
+) error {
+	ctx, span := tracer.Start(ctx, "ScheduledTransferRepository.Complete")
+	defer span.End()
+
+	err := r.runner.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		query := `UPDATE scheduled_transfers
+                  SET next_run_at = $2, active = $3, locked_until = NULL
+                  WHERE id = $1 AND active AND next_run_at = $4`
+
+		tag, err := dbTx.Exec(ctx, query, claimed.ID, next.NextRunAt, next.Active, claimed.NextRunAt)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return domain.ErrScheduledTransferNotDue
+		}
+
+		query = `INSERT INTO scheduled_transfer_runs (transfer_id, status, reason, run_at)
+                 VALUES ($1, $2, NULLIF($3, ''), $4)`
+
+		_, err = dbTx.Exec(ctx, query, claimed.ID, run.Status, run.Reason, run.RunAt)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("ScheduledTransferRepository.Complete: %w", err)
+	}
+
+	return nil
+}
+
+func collectScheduledTransfers(rows pgx.Rows) ([]domain.ScheduledTransfer, error) {
+	defer func() { rows.Close() }()
+
+	var sts []domain.ScheduledTransfer
+	for rows.Next() {
+		st, err := scanScheduledTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sts = append(sts, st)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sts, nil
+}
+
+func scanScheduledTransfer(row pgx.Row) (domain.ScheduledTransfer, error) {
+	var (
+		st          domain.ScheduledTransfer
+		intervalSec int64
+	)
+
+	err := row.Scan(
+		&st.ID,
+		&st.From,
+		&st.To,
+		&st.ToName,
+		&st.Amount,
+		&intervalSec,
+		&st.NextRunAt,
+		&st.Active,
+		&st.CreatedAt,
+	)
+	if err != nil {
+		return domain.ScheduledTransfer{}, err
+	}
+	st.Interval = time.Duration(intervalSec) * time.Second
+
+	return st, nil
+}
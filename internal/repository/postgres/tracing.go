@@ -0,0 +1,7 @@
+package postgres
+
+import "go.opentelemetry.io/otel"
+
+// tracer resolves to the provider installed at startup. Repository methods open a span around
+// their queries, the queries themselves are traced by the pool.
+var tracer = otel.Tracer("avito_shop/internal/repository/postgres")
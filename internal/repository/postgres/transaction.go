@@ -7,7 +7,6 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
 
 	"avito_shop/internal/repository"
 
@@ -15,135 +14,553 @@ import (
 )
 
 type TransactionRepository struct {
-	pool *pgxpool.Pool
+	runner txRunner
 }
 
 func NewTransactionRepository(dbPool *pgxpool.Pool) repository.Transaction {
+	return newTransactionRepository(dbPool)
+}
+
+func newTransactionRepository(dbPool *pgxpool.Pool) *TransactionRepository {
 	return &TransactionRepository{
-		pool: dbPool,
+		runner: newTxRunner(dbPool),
 	}
 }
 
 func (r *TransactionRepository) SendCoin(ctx context.Context, tx domain.Transaction) error {
-	dbTx, err := r.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.RepeatableRead,
-	})
-	defer func() {
+	ctx, span := tracer.Start(ctx, "TxRepository.SendCoin")
+	defer span.End()
+
+	err := r.runner.run(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, func(dbTx pgx.Tx) error {
+		id, err := r.moveCoins(ctx, dbTx, tx)
 		if err != nil {
-			_ = dbTx.Rollback(ctx)
+			return err
 		}
-	}()
 
-	err = r.updateUserBalance(ctx, dbTx, tx.From, -tx.Amount)
+		// checked after the sender is locked by moveCoins, so concurrent transfers lock in the same order
+		err = r.lockActiveUser(ctx, dbTx, tx.To)
+		if err != nil {
+			return err
+		}
+
+		event := domain.CoinsSent{TransactionID: id, From: tx.From, To: tx.To, Amount: tx.Amount}
+
+		event.FromName, err = r.getUserName(ctx, dbTx, tx.From)
+		if err != nil {
+			return err
+		}
+
+		event.ToName, err = r.getUserName(ctx, dbTx, tx.To)
+		if err != nil {
+			return err
+		}
+
+		return insertEvent(ctx, dbTx, domain.EventCoinsSent, event)
+	})
 	if err != nil {
-		var pgError *pgconn.PgError
-		if errors.As(err, &pgError) {
-			if pgError.Code == PgCheckViolation {
-				return fmt.Errorf("TxRepository.SendCoin: %w", domain.ErrLowBalance)
+		return fmt.Errorf("TxRepository.SendCoin: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TransactionRepository) BuyItem(ctx context.Context, uid domain.UserID, item domain.Merch) error {
+	ctx, span := tracer.Start(ctx, "TxRepository.BuyItem")
+	defer span.End()
+
+	tx := domain.Transaction{
+		From:   uid,
+		To:     repository.ShopDBID,
+		Amount: item.Price,
+	}
+
+	err := r.runner.run(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, func(dbTx pgx.Tx) error {
+		id, err := r.moveCoins(ctx, dbTx, tx)
+		if err != nil {
+			return err
+		}
+
+		err = r.addItemToInventory(ctx, dbTx, tx.From, item)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("invalid inventory: %w", domain.ErrMerchNotFound)
 			}
+			return err
 		}
-		return fmt.Errorf("TxRepository.SendCoin: %w", err)
+
+		err = r.insertOrder(ctx, dbTx, id, uid, item)
+		if err != nil {
+			return err
+		}
+
+		event := domain.MerchPurchased{TransactionID: id, User: uid, Item: item.Name, Price: item.Price}
+
+		event.UserName, err = r.getUserName(ctx, dbTx, uid)
+		if err != nil {
+			return err
+		}
+
+		return insertEvent(ctx, dbTx, domain.EventMerchPurchased, event)
+	})
+	if err != nil {
+		return fmt.Errorf("TxRepository.BuyItem: %w", err)
 	}
 
-	err = r.updateUserBalance(ctx, dbTx, tx.To, tx.Amount)
+	return nil
+}
+
+// Reverse compensates a transaction with new ones pointing back at it, so history is never rewritten.
+func (r *TransactionRepository) Reverse(
+	ctx context.Context,
+	rev domain.TransactionReversal,
+) ([]domain.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TxRepository.Reverse")
+	defer span.End()
+
+	var reversals []domain.Transaction
+
+	err := r.runner.run(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, func(dbTx pgx.Tx) error {
+		var err error
+		reversals, err = r.reverse(ctx, dbTx, rev)
+		return err
+	})
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("TxRepository.SendCoin: %w", domain.ErrUserNotFound)
-		}
-		return fmt.Errorf("TxRepository.SendCoin: %w", err)
+		return nil, fmt.Errorf("TxRepository.Reverse: %w", err)
 	}
 
-	err = r.insertTransaction(ctx, dbTx, tx)
+	return reversals, nil
+}
+
+func (r *TransactionRepository) reverse(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	rev domain.TransactionReversal,
+) ([]domain.Transaction, error) {
+	orig, err := r.getReversibleTransaction(ctx, dbTx, rev.TransactionID)
 	if err != nil {
-		return fmt.Errorf("TxRepository.SendCoin: %w", err)
+		return nil, fmt.Errorf("TxRepository.reverse: %w", err)
 	}
 
-	err = dbTx.Commit(ctx)
+	balance, err := r.getSpendableBalanceForUpdate(ctx, dbTx, orig.To)
 	if err != nil {
-		return fmt.Errorf("TxRepository.SendCoin: %w", err)
+		return nil, fmt.Errorf("TxRepository.reverse: %w", err)
 	}
 
-	return nil
+	fromRecipient := orig.Amount
+	if balance < orig.Amount {
+		if !rev.Force {
+			return nil, fmt.Errorf("TxRepository.reverse: recipient can't return coins: %w", domain.ErrLowBalance)
+		}
+		fromRecipient = balance
+	}
+
+	var reversals []domain.Transaction
+	if fromRecipient > 0 {
+		reversals = append(reversals, domain.Transaction{From: orig.To, To: orig.From, Amount: fromRecipient})
+	}
+	if shortfall := orig.Amount - fromRecipient; shortfall > 0 {
+		reversals = append(reversals, domain.Transaction{From: repository.ShopDBID, To: orig.From, Amount: shortfall})
+	}
+
+	for i := range reversals {
+		reversals[i].ID, err = r.applyReversal(ctx, dbTx, reversals[i], rev)
+		if err != nil {
+			return nil, fmt.Errorf("TxRepository.reverse: %w", err)
+		}
+	}
+
+	return reversals, nil
 }
 
-func (r *TransactionRepository) BuyItem(ctx context.Context, uid domain.UserID, item domain.Merch) error {
-	dbTx, err := r.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.RepeatableRead,
-	})
-	defer func() {
+func (r *TransactionRepository) Mint(ctx context.Context, adj domain.CoinAdjustment) (domain.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TxRepository.Mint")
+	defer span.End()
+
+	tx := domain.Transaction{
+		From:   repository.SystemDBID,
+		To:     adj.User,
+		Amount: adj.Amount,
+	}
+
+	err := r.runner.run(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, func(dbTx pgx.Tx) error {
+		err := r.lockActiveUser(ctx, dbTx, tx.To)
 		if err != nil {
-			_ = dbTx.Rollback(ctx)
+			return err
 		}
-	}()
 
+		err = r.insertLots(ctx, dbTx, tx.To, []coinLot{{Amount: tx.Amount}})
+		if err != nil {
+			return err
+		}
+
+		tx.ID, err = r.insertAdjustment(ctx, dbTx, tx, adj)
+		if err != nil {
+			return err
+		}
+
+		return postTransfer(ctx, dbTx, tx)
+	})
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("TxRepository.Mint: %w", err)
+	}
+
+	return tx, nil
+}
+
+func (r *TransactionRepository) Burn(ctx context.Context, adj domain.CoinAdjustment) (domain.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TxRepository.Burn")
+	defer span.End()
+
+	var tx domain.Transaction
+
+	err := r.runner.run(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, func(dbTx pgx.Tx) error {
+		var err error
+		tx, err = r.burn(ctx, dbTx, adj)
+		return err
+	})
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("TxRepository.Burn: %w", err)
+	}
+
+	return tx, nil
+}
+
+func (r *TransactionRepository) burn(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	adj domain.CoinAdjustment,
+) (domain.Transaction, error) {
 	tx := domain.Transaction{
-		From:   uid,
-		To:     repository.ShopDBID,
-		Amount: item.Price,
+		From:   adj.User,
+		To:     repository.SystemDBID,
+		Amount: adj.Amount,
 	}
 
-	err = r.updateUserBalance(ctx, dbTx, tx.From, -tx.Amount)
+	err := r.lockActiveUser(ctx, dbTx, tx.From)
 	if err != nil {
-		var pgError *pgconn.PgError
-		if errors.As(err, &pgError) {
-			if pgError.Code == PgCheckViolation {
-				return fmt.Errorf("TxRepository.BuyItem: %w", domain.ErrLowBalance)
-			}
+		return domain.Transaction{}, fmt.Errorf("TxRepository.burn: %w", err)
+	}
+
+	if tx.Amount == 0 {
+		tx.Amount, err = r.getSpendableBalanceForUpdate(ctx, dbTx, tx.From)
+		if err != nil {
+			return domain.Transaction{}, fmt.Errorf("TxRepository.burn: %w", err)
 		}
-		return fmt.Errorf("TxRepository.BuyItem: %w", err)
 	}
 
-	err = r.addItemToInventory(ctx, dbTx, tx.From, item)
+	_, err = r.consumeLots(ctx, dbTx, tx.From, tx.Amount)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("TxRepository.BuyItem: invalid inventory: %w", domain.ErrMerchNotFound)
+		return domain.Transaction{}, fmt.Errorf("TxRepository.burn: %w", err)
+	}
+
+	tx.ID, err = r.insertAdjustment(ctx, dbTx, tx, adj)
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("TxRepository.burn: %w", err)
+	}
+
+	err = postTransfer(ctx, dbTx, tx)
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("TxRepository.burn: %w", err)
+	}
+
+	if adj.Deactivate {
+		_, err = dbTx.Exec(ctx, `UPDATE employees SET active = FALSE WHERE id = $1`, tx.From)
+		if err != nil {
+			return domain.Transaction{}, fmt.Errorf("TxRepository.burn: %w", err)
 		}
-		return fmt.Errorf("TxRepository.BuyItem: %w", err)
 	}
 
-	err = r.insertTransaction(ctx, dbTx, tx)
+	return tx, nil
+}
+
+// GrantAllowance credits every active employee once per period: allowance_grants
+// remembers who was already paid, so running it repeatedly is safe.
+func (r *TransactionRepository) GrantAllowance(
+	ctx context.Context,
+	period domain.AllowancePeriod,
+	amount int,
+) (int, error) {
+	ctx, span := tracer.Start(ctx, "TxRepository.GrantAllowance")
+	defer span.End()
+
+	var granted int
+
+	// the same as postTransfer for every employee at once
+	query := `WITH granted AS (
+                  INSERT INTO allowance_grants (period, employee_id)
+                  SELECT $1, id FROM employees WHERE active
+                  ON CONFLICT DO NOTHING
+                  RETURNING employee_id
+              ), credited AS (
+                  UPDATE employees
+                  SET coins = coins + $2
+                  WHERE id IN (SELECT employee_id FROM granted)
+              ), lots AS (
+                  INSERT INTO coin_lots (employee_id, amount)
+                  SELECT employee_id, $2 FROM granted
+              ), logged AS (
+                  INSERT INTO coin_transactions (sender, recipient, amount, reason)
+                  SELECT $3, employee_id, $2, 'monthly allowance ' || $1
+                  FROM granted
+                  RETURNING id, recipient
+              ), entries AS (
+                  INSERT INTO ledger_entries (transaction_id)
+                  SELECT id FROM logged
+                  RETURNING id, transaction_id
+              ), postings AS (
+                  INSERT INTO ledger_postings (entry_id, account, amount)
+                  SELECT e.id, p.account, p.amount
+                  FROM entries e
+                  JOIN logged l ON l.id = e.transaction_id
+                  CROSS JOIN LATERAL (VALUES ($3::INT, -$2::INT), (l.recipient, $2::INT)) AS p (account, amount)
+              )
+              SELECT count(*) FROM granted`
+
+	err := r.runner.run(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, func(dbTx pgx.Tx) error {
+		return dbTx.QueryRow(ctx, query, period, amount, repository.SystemDBID).Scan(&granted)
+	})
 	if err != nil {
-		return fmt.Errorf("TxRepository.BuyItem: %w", err)
+		return 0, fmt.Errorf("TxRepository.GrantAllowance: %w", err)
 	}
 
-	err = dbTx.Commit(ctx)
+	return granted, nil
+}
+
+func (r *TransactionRepository) lockActiveUser(ctx context.Context, dbTx pgx.Tx, id domain.UserID) error {
+	query := `SELECT id FROM employees WHERE id = $1 AND active FOR UPDATE`
+
+	err := dbTx.QueryRow(ctx, query, id).Scan(&id)
 	if err != nil {
-		return fmt.Errorf("TxRepository.BuyItem: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("TxRepository.lockActiveUser: %w", domain.ErrUserNotFound)
+		}
+		return fmt.Errorf("TxRepository.lockActiveUser: %w", err)
 	}
 
 	return nil
 }
 
-func (r *TransactionRepository) updateUserBalance(
+// moveCoins transfers the oldest lots of the sender to the recipient, logs the transaction and posts it.
+func (r *TransactionRepository) moveCoins(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	tx domain.Transaction,
+) (domain.TransactionID, error) {
+	lots, err := r.consumeLots(ctx, dbTx, tx.From, tx.Amount)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.moveCoins: %w", err)
+	}
+
+	err = r.insertLots(ctx, dbTx, tx.To, lots)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.moveCoins: %w", err)
+	}
+
+	tx.ID, err = r.insertTransaction(ctx, dbTx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.moveCoins: %w", err)
+	}
+
+	err = postTransfer(ctx, dbTx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.moveCoins: %w", err)
+	}
+
+	return tx.ID, nil
+}
+
+func (r *TransactionRepository) insertAdjustment(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	tx domain.Transaction,
+	adj domain.CoinAdjustment,
+) (domain.TransactionID, error) {
+	var id domain.TransactionID
+
+	query := `INSERT INTO coin_transactions
+    		  (sender, recipient, amount, reason, performed_by)
+              VALUES ($1, $2, $3, $4, $5)
+              RETURNING id`
+
+	err := dbTx.QueryRow(ctx, query, tx.From, tx.To, tx.Amount, adj.Reason, adj.AdminID).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.insertAdjustment: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *TransactionRepository) getReversibleTransaction(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	id domain.TransactionID,
+) (domain.Transaction, error) {
+	var (
+		sender, recipient *domain.UserID
+		isReversal        bool
+		tx                = domain.Transaction{ID: id}
+	)
+
+	query := `SELECT sender, recipient, amount, reverses IS NOT NULL
+              FROM coin_transactions
+              WHERE id = $1
+              FOR UPDATE`
+
+	err := dbTx.QueryRow(ctx, query, id).Scan(&sender, &recipient, &tx.Amount, &isReversal)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Transaction{}, fmt.Errorf("TxRepository.getReversibleTransaction: %w",
+				domain.ErrTransactionNotFound)
+		}
+		return domain.Transaction{}, fmt.Errorf("TxRepository.getReversibleTransaction: %w", err)
+	}
+
+	if !isReversible(sender, recipient, isReversal) {
+		return domain.Transaction{}, fmt.Errorf("TxRepository.getReversibleTransaction: %w",
+			domain.ErrTransactionNotReversible)
+	}
+	tx.From, tx.To = *sender, *recipient
+
+	var reversed bool
+
+	query = `SELECT EXISTS (SELECT 1 FROM coin_transactions WHERE reverses = $1)`
+	err = dbTx.QueryRow(ctx, query, id).Scan(&reversed)
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("TxRepository.getReversibleTransaction: %w", err)
+	}
+
+	if reversed {
+		return domain.Transaction{}, fmt.Errorf("TxRepository.getReversibleTransaction: %w",
+			domain.ErrTransactionAlreadyReversed)
+	}
+
+	return tx, nil
+}
+
+// isReversible allows transfers between employees only. Reversing a purchase would return the coins
+// while the merch stays in the inventory, mints and burns are undone with the opposite adjustment.
+func isReversible(sender, recipient *domain.UserID, isReversal bool) bool {
+	if sender == nil || recipient == nil || isReversal {
+		return false
+	}
+
+	for _, id := range []domain.UserID{*sender, *recipient} {
+		if id == repository.ShopDBID || id == repository.SystemDBID {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getUserName resolves the names events carry, so subscribers don't have to look them up.
+func (r *TransactionRepository) getUserName(
 	ctx context.Context,
 	dbTx pgx.Tx,
 	id domain.UserID,
-	amount int,
-) error {
-	query := `UPDATE employees
-              SET coins = coins + $2
-              WHERE id = $1`
+) (domain.UserName, error) {
+	var name domain.UserName
 
-	_, err := dbTx.Exec(ctx, query, id, amount)
+	query := `SELECT username FROM employees WHERE id = $1`
+	err := dbTx.QueryRow(ctx, query, id).Scan(&name)
 	if err != nil {
-		return fmt.Errorf("TxRepository.updateUserBalance: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("TxRepository.getUserName: %w", domain.ErrUserNotFound)
+		}
+		return "", fmt.Errorf("TxRepository.getUserName: %w", err)
 	}
-	return nil
+
+	return name, nil
+}
+
+func (r *TransactionRepository) getUserBalanceForUpdate(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	id domain.UserID,
+) (int, error) {
+	var coins int
+
+	query := `SELECT coins FROM employees WHERE id = $1 FOR UPDATE`
+	err := dbTx.QueryRow(ctx, query, id).Scan(&coins)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("TxRepository.getUserBalanceForUpdate: %w", domain.ErrUserNotFound)
+		}
+		return 0, fmt.Errorf("TxRepository.getUserBalanceForUpdate: %w", err)
+	}
+
+	return coins, nil
+}
+
+func (r *TransactionRepository) applyReversal(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	tx domain.Transaction,
+	rev domain.TransactionReversal,
+) (domain.TransactionID, error) {
+	lots, err := r.consumeLots(ctx, dbTx, tx.From, tx.Amount)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.applyReversal: %w", err)
+	}
+
+	err = r.insertLots(ctx, dbTx, tx.To, lots)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.applyReversal: %w", err)
+	}
+
+	query := `INSERT INTO coin_transactions
+    		  (sender, recipient, amount, reverses, reason, performed_by)
+              VALUES ($1, $2, $3, $4, $5, $6)
+              RETURNING id`
+
+	err = dbTx.QueryRow(ctx, query, tx.From, tx.To, tx.Amount, rev.TransactionID, rev.Reason, rev.AdminID).
+		Scan(&tx.ID)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.applyReversal: %w", err)
+	}
+
+	err = postTransfer(ctx, dbTx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.applyReversal: %w", err)
+	}
+
+	return tx.ID, nil
 }
 
 func (r *TransactionRepository) insertTransaction(
 	ctx context.Context,
 	dbTx pgx.Tx,
 	tx domain.Transaction,
-) error {
+) (domain.TransactionID, error) {
+	var id domain.TransactionID
+
 	query := `INSERT INTO coin_transactions 
     		  (sender, recipient, amount)
-              VALUES ($1, $2, $3)`
+              VALUES ($1, $2, $3)
+              RETURNING id`
+
+	err := dbTx.QueryRow(ctx, query, tx.From, tx.To, tx.Amount).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("TxRepository.insertTransaction: %w", err)
+	}
+	return id, nil
+}
 
-	_, err := dbTx.Exec(ctx, query, tx.From, tx.To, tx.Amount)
+func (r *TransactionRepository) insertOrder(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	txID domain.TransactionID,
+	uid domain.UserID,
+	item domain.Merch,
+) error {
+	query := `INSERT INTO orders (employee_id, merch_id, price, transaction_id)
+              VALUES ($1, $2, $3, $4)`
+
+	_, err := dbTx.Exec(ctx, query, uid, item.ID, item.Price, txID)
 	if err != nil {
-		return fmt.Errorf("TxRepository.insertTransaction: %w", err)
+		return fmt.Errorf("TxRepository.insertOrder: %w", err)
 	}
+
 	return nil
 }
 
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReversible(t *testing.T) {
+	t.Parallel()
+
+	user := func(id domain.UserID) *domain.UserID { return &id }
+
+	tests := []struct {
+		Name       string
+		Sender     *domain.UserID
+		Recipient  *domain.UserID
+		IsReversal bool
+		Exp        bool
+	}{
+		{"Transfer", user(3), user(4), false, true},
+		{"Purchase", user(3), user(repository.ShopDBID), false, false},
+		{"Mint", user(repository.SystemDBID), user(3), false, false},
+		{"Burn", user(3), user(repository.SystemDBID), false, false},
+		{"Reversal", user(4), user(3), true, false},
+		{"Deleted party", nil, user(3), false, false},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.Exp, isReversible(test.Sender, test.Recipient, test.IsReversal), test.Name)
+	}
+}
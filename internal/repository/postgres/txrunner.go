@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	txMaxAttempts = 5
+	txBaseDelay   = 10 * time.Millisecond
+	txMaxDelay    = 300 * time.Millisecond
+)
+
+// txRunner runs a function inside a transaction and runs it again from scratch when Postgres
+// aborts the transaction with a serialization failure or a deadlock. The function may be called
+// several times, so it must not keep state between calls.
+type txRunner struct {
+	pool *pgxpool.Pool
+	// begin starts the transactions, the pool's BeginTx unless replaced in tests
+	begin       func(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error)
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+func newTxRunner(pool *pgxpool.Pool) txRunner {
+	return txRunner{
+		pool:        pool,
+		begin:       pool.BeginTx,
+		maxAttempts: txMaxAttempts,
+		baseDelay:   txBaseDelay,
+		maxDelay:    txMaxDelay,
+	}
+}
+
+func (r txRunner) run(ctx context.Context, opts pgx.TxOptions, fn func(dbTx pgx.Tx) error) error {
+	// inside a unit of work the outer transaction owns the retries,
+	// a savepoint keeps a failed call from aborting it
+	if outer, ok := txFromContext(ctx); ok {
+		return r.runOnce(ctx, outer.Begin, fn)
+	}
+
+	begin := func(ctx context.Context) (pgx.Tx, error) {
+		return r.begin(ctx, opts)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := r.runOnce(ctx, begin, fn)
+		if err == nil || !isRetryable(err) || attempt >= r.maxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(r.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (r txRunner) runOnce(
+	ctx context.Context,
+	begin func(ctx context.Context) (pgx.Tx, error),
+	fn func(dbTx pgx.Tx) error,
+) error {
+	dbTx, err := begin(ctx)
+	if err != nil {
+		return fmt.Errorf("txRunner.run: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = dbTx.Rollback(ctx)
+		}
+	}()
+
+	err = fn(dbTx)
+	if err != nil {
+		return err
+	}
+
+	err = dbTx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("txRunner.run: %w", err)
+	}
+
+	return nil
+}
+
+// conn returns the transaction of the unit of work ctx belongs to, or the pool outside of one.
+func (r txRunner) conn(ctx context.Context) querier {
+	if dbTx, ok := txFromContext(ctx); ok {
+		return dbTx
+	}
+
+	return r.pool
+}
+
+// exec runs a single statement with the same retries.
+func (r txRunner) exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	var tag pgconn.CommandTag
+
+	err := r.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		var err error
+		tag, err = dbTx.Exec(ctx, sql, args...)
+		return err
+	})
+
+	return tag, err
+}
+
+// backoff doubles the delay with every attempt and picks a random point in its upper half,
+// so transactions that collided once don't collide again in lockstep.
+func (r txRunner) backoff(attempt int) time.Duration {
+	delay := r.maxDelay
+	if shift := attempt - 1; shift < 32 && r.baseDelay<<shift < r.maxDelay {
+		delay = r.baseDelay << shift
+	}
+
+	half := delay / 2
+	//nolint:gosec // jitter doesn't need a cryptographically secure source
+	return half + time.Duration(rand.Int64N(int64(half)+1))
+}
+
+func isRetryable(err error) bool {
+	var pgError *pgconn.PgError
+	if errors.As(err, &pgError) {
+		return pgError.Code == PgSerializationFailure || pgError.Code == PgDeadlockDetected
+	}
+
+	return false
+}
+
+// querier is satisfied by both the pool and a transaction.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type txKey struct{}
+
+func withTx(ctx context.Context, dbTx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, dbTx)
+}
+
+func txFromContext(ctx context.Context) (pgx.Tx, bool) {
+	dbTx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return dbTx, ok
+}
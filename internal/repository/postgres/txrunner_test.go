@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx records how a transaction ended, Begin starts a savepoint like pgx does.
+type fakeTx struct {
+	pgx.Tx
+	savepoints []*fakeTx
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Begin(context.Context) (pgx.Tx, error) {
+	savepoint := &fakeTx{}
+	tx.savepoints = append(tx.savepoints, savepoint)
+
+	return savepoint, nil
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	tx.rolledBack = true
+	return nil
+}
+
+// newTestTxRunner runs without a database, the transactions it began are returned in order.
+func newTestTxRunner(baseDelay time.Duration) (txRunner, *[]*fakeTx) {
+	var txs []*fakeTx
+
+	return txRunner{
+		begin: func(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+			tx := &fakeTx{}
+			txs = append(txs, tx)
+			return tx, nil
+		},
+		maxAttempts: 3,
+		baseDelay:   baseDelay,
+		maxDelay:    baseDelay,
+	}, &txs
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name string
+		Err  error
+		Exp  bool
+	}{
+		{"Serialization failure", &pgconn.PgError{Code: PgSerializationFailure}, true},
+		{"Deadlock", fmt.Errorf("TxRepository.moveCoins: %w", &pgconn.PgError{Code: PgDeadlockDetected}), true},
+		{"Check violation", &pgconn.PgError{Code: PgCheckViolation}, false},
+		{"Not a postgres error", errors.New("connection refused"), false},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.Exp, isRetryable(test.Err), test.Name)
+	}
+}
+
+func TestTxRunnerBackoff(t *testing.T) {
+	t.Parallel()
+
+	r := txRunner{
+		maxAttempts: 5,
+		baseDelay:   10 * time.Millisecond,
+		maxDelay:    50 * time.Millisecond,
+	}
+
+	tests := []struct {
+		Attempt  int
+		MinDelay time.Duration
+		MaxDelay time.Duration
+	}{
+		{1, 5 * time.Millisecond, 10 * time.Millisecond},
+		{2, 10 * time.Millisecond, 20 * time.Millisecond},
+		{3, 20 * time.Millisecond, 40 * time.Millisecond},
+		{4, 25 * time.Millisecond, 50 * time.Millisecond},
+		{100, 25 * time.Millisecond, 50 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		for range 20 {
+			delay := r.backoff(test.Attempt)
+
+			require.GreaterOrEqual(t, delay, test.MinDelay, "attempt %d", test.Attempt)
+			require.LessOrEqual(t, delay, test.MaxDelay, "attempt %d", test.Attempt)
+		}
+	}
+}
+
+func TestTxRunnerRun_RetriesUntilCommitted(t *testing.T) {
+	t.Parallel()
+
+	r, txs := newTestTxRunner(time.Millisecond)
+
+	errs := []error{
+		&pgconn.PgError{Code: PgSerializationFailure},
+		fmt.Errorf("TxRepository.moveCoins: %w", &pgconn.PgError{Code: PgDeadlockDetected}),
+		nil,
+	}
+	calls := 0
+
+	err := r.run(context.Background(), pgx.TxOptions{}, func(pgx.Tx) error {
+		calls++
+		return errs[calls-1]
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+	require.Len(t, *txs, 3)
+	for _, tx := range (*txs)[:2] {
+		require.True(t, tx.rolledBack)
+		require.False(t, tx.committed)
+	}
+	require.True(t, (*txs)[2].committed)
+}
+
+func TestTxRunnerRun_StopsAtMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	r, txs := newTestTxRunner(time.Millisecond)
+	serializationFailure := &pgconn.PgError{Code: PgSerializationFailure}
+
+	err := r.run(context.Background(), pgx.TxOptions{}, func(pgx.Tx) error {
+		return serializationFailure
+	})
+
+	require.ErrorIs(t, err, serializationFailure)
+	require.Len(t, *txs, r.maxAttempts)
+}
+
+func TestTxRunnerRun_NotRetryable(t *testing.T) {
+	t.Parallel()
+
+	r, txs := newTestTxRunner(time.Millisecond)
+	checkViolation := &pgconn.PgError{Code: PgCheckViolation}
+
+	err := r.run(context.Background(), pgx.TxOptions{}, func(pgx.Tx) error {
+		return checkViolation
+	})
+
+	require.ErrorIs(t, err, checkViolation)
+	require.Len(t, *txs, 1)
+	require.True(t, (*txs)[0].rolledBack)
+}
+
+func TestTxRunnerRun_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	r, txs := newTestTxRunner(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	start := time.Now()
+	err := r.run(ctx, pgx.TxOptions{}, func(pgx.Tx) error {
+		cancel()
+		return &pgconn.PgError{Code: PgSerializationFailure}
+	})
+
+	// the backoff isn't waited out
+	require.True(t, isRetryable(err))
+	require.Len(t, *txs, 1)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestTxRunnerRun_InsideUnitOfWork(t *testing.T) {
+	t.Parallel()
+
+	r, txs := newTestTxRunner(time.Millisecond)
+	outer := &fakeTx{}
+	ctx := withTx(context.Background(), outer)
+
+	calls := 0
+	err := r.run(ctx, pgx.TxOptions{}, func(pgx.Tx) error {
+		calls++
+		return &pgconn.PgError{Code: PgSerializationFailure}
+	})
+
+	// the unit of work retries as a whole, the failed call only rolls its savepoint back
+	require.True(t, isRetryable(err))
+	require.Equal(t, 1, calls)
+	require.Empty(t, *txs)
+	require.Len(t, outer.savepoints, 1)
+	require.True(t, outer.savepoints[0].rolledBack)
+	require.False(t, outer.rolledBack)
+}
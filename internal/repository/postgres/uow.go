@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"avito_shop/internal/repository"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// UnitOfWork keeps the transaction in the context, every repository method called with that
+// context runs inside it: reads go through the transaction and writes become savepoints.
+type UnitOfWork struct {
+	runner txRunner
+}
+
+func NewUnitOfWork(dbPool *pgxpool.Pool) repository.UnitOfWork {
+	return &UnitOfWork{
+		runner: newTxRunner(dbPool),
+	}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "UnitOfWork.Do")
+	defer span.End()
+
+	err := u.runner.run(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, func(dbTx pgx.Tx) error {
+		return fn(withTx(ctx, dbTx))
+	})
+	if err != nil {
+		return fmt.Errorf("UnitOfWork.Do: %w", err)
+	}
+
+	return nil
+}
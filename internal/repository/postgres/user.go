@@ -15,7 +15,7 @@ import (
 )
 
 type UserRepository struct {
-	pool              *pgxpool.Pool
+	runner            txRunner
 	cacheByName       cache.Cache
 	cacheTTL          time.Duration
 	cacheWriteTimeout time.Duration
@@ -28,7 +28,7 @@ func NewUserRepository(
 	cacheWriteTimeout time.Duration,
 ) repository.User {
 	return &UserRepository{
-		pool:              dbPool,
+		runner:            newTxRunner(dbPool),
 		cacheByName:       cache,
 		cacheTTL:          cacheTTL,
 		cacheWriteTimeout: cacheWriteTimeout,
@@ -36,12 +36,33 @@ func NewUserRepository(
 }
 
 func (r *UserRepository) Put(ctx context.Context, user domain.User) (domain.UserID, error) {
-	var id domain.UserID
-	query := `INSERT INTO Employees (username, hashed_password)
-              VALUES ($1, $2)
-              RETURNING id`
+	ctx, span := tracer.Start(ctx, "UserRepository.Put")
+	defer span.End()
 
-	err := r.pool.QueryRow(ctx, query, user.Name, user.HashedPassword).Scan(&id)
+	var id domain.UserID
+	// the starting coins are issued by the system account with an opening ledger entry
+	query := `WITH created AS (
+                  INSERT INTO Employees (username, hashed_password)
+                  VALUES ($1, $2)
+                  RETURNING id, coins
+              ), lots AS (
+                  INSERT INTO coin_lots (employee_id, amount)
+                  SELECT id, coins FROM created
+              ), entry AS (
+                  INSERT INTO ledger_entries (memo)
+                  SELECT 'opening balance' FROM created
+                  RETURNING id
+              ), postings AS (
+                  INSERT INTO ledger_postings (entry_id, account, amount)
+                  SELECT entry.id, p.account, p.amount
+                  FROM entry, created c
+                  CROSS JOIN LATERAL (VALUES ($3::INT, -c.coins), (c.id, c.coins)) AS p (account, amount)
+              )
+              SELECT id FROM created`
+
+	err := r.runner.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		return dbTx.QueryRow(ctx, query, user.Name, user.HashedPassword, repository.SystemDBID).Scan(&id)
+	})
 	if err != nil {
 		var pgError *pgconn.PgError
 		if errors.As(err, &pgError) {
@@ -56,10 +77,18 @@ func (r *UserRepository) Put(ctx context.Context, user domain.User) (domain.User
 }
 
 func (r *UserRepository) GetByName(ctx context.Context, name domain.UserName) (domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserRepository.GetByName")
+	defer span.End()
+
+	// inside a unit of work the cache is bypassed both ways: a cached user may already be deleted,
+	// and a user read from a transaction may never be committed
+	_, inTx := txFromContext(ctx)
+
 	user := domain.User{}
-	err := r.cacheByName.Get(ctx, name, &user)
-	if err == nil {
-		return user, nil
+	if !inTx {
+		if err := r.cacheByName.Get(ctx, name, &user); err == nil {
+			return user, nil
+		}
 	}
 
 	user.Name = name
@@ -67,7 +96,7 @@ func (r *UserRepository) GetByName(ctx context.Context, name domain.UserName) (d
 	query := `SELECT id, hashed_password, coins FROM Employees
               WHERE username = $1`
 
-	err = r.pool.QueryRow(ctx, query, name).Scan(&user.ID, &user.HashedPassword, &user.Info.Coins)
+	err := r.runner.conn(ctx).QueryRow(ctx, query, name).Scan(&user.ID, &user.HashedPassword, &user.Info.Coins)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.User{}, fmt.Errorf("UserRepository.GetByName: %w", domain.ErrUserNotFound)
@@ -75,7 +104,9 @@ func (r *UserRepository) GetByName(ctx context.Context, name domain.UserName) (d
 		return domain.User{}, fmt.Errorf("UserRepository.GetByName: %w", err)
 	}
 
-	go r.cacheUserByNameAsync(user)
+	if !inTx {
+		go r.cacheUserByNameAsync(user)
+	}
 
 	return user, nil
 }
@@ -87,44 +118,140 @@ func (r *UserRepository) cacheUserByNameAsync(user domain.User) {
 }
 
 func (r *UserRepository) GetInfoByID(ctx context.Context, id domain.UserID) (domain.UserInfo, error) {
-	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{
+	ctx, span := tracer.Start(ctx, "UserRepository.GetInfoByID")
+	defer span.End()
+
+	var info domain.UserInfo
+
+	opts := pgx.TxOptions{
 		IsoLevel:   pgx.RepeatableRead,
 		AccessMode: pgx.ReadOnly,
-	})
-	defer func() {
+	}
+
+	err := r.runner.run(ctx, opts, func(tx pgx.Tx) error {
+		var err error
+
+		info.Coins, err = r.getCoinsTx(ctx, tx, id)
 		if err != nil {
-			_ = tx.Rollback(ctx)
+			return err
 		}
-	}()
 
-	coins, err := r.getCoinsTx(ctx, tx, id)
+		info.Inventory, err = r.getInventoryTx(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		info.Transactions, err = r.getTxHistoryTx(ctx, tx, id)
+		return err
+	})
 	if err != nil {
 		return domain.UserInfo{}, fmt.Errorf("UserRepository.GetInfoByID: %w", err)
 	}
 
-	inv, err := r.getInventoryTx(ctx, tx, id)
+	return info, nil
+}
+
+func (r *UserRepository) GetNamesByIDs(
+	ctx context.Context,
+	ids []domain.UserID,
+) (map[domain.UserID]domain.UserName, error) {
+	ctx, span := tracer.Start(ctx, "UserRepository.GetNamesByIDs")
+	defer span.End()
+
+	query := `SELECT id, username FROM Employees WHERE id = ANY ($1)`
+
+	rows, err := r.runner.conn(ctx).Query(ctx, query, ids)
 	if err != nil {
-		return domain.UserInfo{}, fmt.Errorf("UserRepository.GetInfoByID: %w", err)
+		return nil, fmt.Errorf("UserRepository.GetNamesByIDs: %w", err)
+	}
+	defer func() { rows.Close() }()
+
+	names := make(map[domain.UserID]domain.UserName, len(ids))
+	for rows.Next() {
+		var (
+			id   domain.UserID
+			name domain.UserName
+		)
+
+		if err = rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("UserRepository.GetNamesByIDs: %w", err)
+		}
+
+		names[id] = name
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("UserRepository.GetNamesByIDs: %w", err)
 	}
 
-	txHistory, err := r.getTxHistoryTx(ctx, tx, id)
+	return names, nil
+}
+
+func (r *UserRepository) IsAdmin(ctx context.Context, id domain.UserID) (bool, error) {
+	ctx, span := tracer.Start(ctx, "UserRepository.IsAdmin")
+	defer span.End()
+
+	var isAdmin bool
+
+	query := `SELECT is_admin FROM Employees WHERE id = $1`
+	err := r.runner.conn(ctx).QueryRow(ctx, query, id).Scan(&isAdmin)
 	if err != nil {
-		return domain.UserInfo{}, fmt.Errorf("UserRepository.GetInfoByID: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, fmt.Errorf("UserRepository.IsAdmin: %w", domain.ErrUserNotFound)
+		}
+		return false, fmt.Errorf("UserRepository.IsAdmin: %w", err)
 	}
 
-	err = tx.Commit(ctx)
+	return isAdmin, nil
+}
+
+// GetLanguage is empty for a user who hasn't chosen a language.
+func (r *UserRepository) GetLanguage(ctx context.Context, id domain.UserID) (domain.Language, error) {
+	ctx, span := tracer.Start(ctx, "UserRepository.GetLanguage")
+	defer span.End()
+
+	var lang domain.Language
+
+	query := `SELECT COALESCE(language, '') FROM Employees WHERE id = $1`
+	err := r.runner.conn(ctx).QueryRow(ctx, query, id).Scan(&lang)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("UserRepository.GetLanguage: %w", domain.ErrUserNotFound)
+		}
+		return "", fmt.Errorf("UserRepository.GetLanguage: %w", err)
+	}
+
+	return lang, nil
+}
+
+// SetLanguage with an empty language resets the choice.
+func (r *UserRepository) SetLanguage(ctx context.Context, id domain.UserID, lang domain.Language) error {
+	ctx, span := tracer.Start(ctx, "UserRepository.SetLanguage")
+	defer span.End()
+
+	query := `UPDATE Employees SET language = NULLIF($2, '') WHERE id = $1`
+
+	tag, err := r.runner.exec(ctx, query, id, lang)
+	if err != nil {
+		return fmt.Errorf("UserRepository.SetLanguage: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("UserRepository.SetLanguage: %w", domain.ErrUserNotFound)
+	}
 
-	return domain.UserInfo{
-		Coins:        coins,
-		Inventory:    inv,
-		Transactions: txHistory,
-	}, nil
+	return nil
 }
 
 func (r *UserRepository) getCoinsTx(ctx context.Context, tx pgx.Tx, id domain.UserID) (int, error) {
 	var coins int
 
-	query := `SELECT coins from Employees WHERE id = $1`
+	// expired lots still count in employees.coins until the expiry job collects them
+	query := `SELECT COALESCE(SUM(l.amount), 0)
+              FROM Employees e
+              LEFT JOIN coin_lots l ON l.employee_id = e.id AND l.expires_at > now()
+              WHERE e.id = $1
+              GROUP BY e.id`
 	err := tx.QueryRow(ctx, query, id).Scan(&coins)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -145,7 +272,9 @@ func (r *UserRepository) getTxHistoryTx(
     		  ct.sender,
     		  COALESCE(e_from.username, 'deleted'),
     		  COALESCE(e_to.username, 'deleted'),
-    		  ct.amount
+    		  ct.amount,
+    		  COALESCE(ct.reason, ''),
+    		  ct.created_at
 			  FROM coin_transactions ct
               LEFT JOIN employees e_from ON ct.sender = e_from.id
               LEFT JOIN employees e_to   ON ct.recipient = e_to.id
@@ -170,7 +299,7 @@ func (r *UserRepository) getTxHistoryTx(
 			userTX   domain.UserTransaction
 		)
 
-		if err = rows.Scan(&idFrom, &nameFrom, &nameTo, &userTX.Amount); err != nil {
+		if err = rows.Scan(&idFrom, &nameFrom, &nameTo, &userTX.Amount, &userTX.Reason, &userTX.CreatedAt); err != nil {
 			return nil, fmt.Errorf("UserRepository.getTxHistoryTx: %w", err)
 		}
 
@@ -0,0 +1,374 @@
+package postgres
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookRepository struct {
+	runner txRunner
+}
+
+func NewWebhookRepository(dbPool *pgxpool.Pool) repository.Webhook {
+	return &WebhookRepository{
+		runner: newTxRunner(dbPool),
+	}
+}
+
+const (
+	webhookDeliveryColumns = `d.id,
+    		  s.id,
+    		  s.url,
+    		  s.secret,
+    		  o.id,
+    		  o.event_type,
+    		  o.payload,
+    		  o.created_at,
+    		  d.status,
+    		  d.attempts,
+    		  d.next_attempt_at,
+    		  COALESCE(d.last_error, ''),
+    		  d.created_at,
+    		  d.delivered_at`
+	webhookDeliveryJoins = `JOIN webhook_subscriptions s ON d.subscription_id = s.id
+              JOIN outbox o ON d.event_id = o.id`
+)
+
+func (r *WebhookRepository) Create(
+	ctx context.Context,
+	sub domain.WebhookSubscription,
+) (domain.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.Create")
+	defer span.End()
+
+	query := `INSERT INTO webhook_subscriptions (url, secret, event_types, created_by)
+              VALUES ($1, $2, $3, $4)
+              RETURNING id, created_at`
+
+	err := r.runner.conn(ctx).QueryRow(ctx, query, sub.URL, sub.Secret, sub.EventTypes, sub.CreatedBy).
+		Scan(&sub.ID, &sub.CreatedAt)
+	if err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("WebhookRepository.Create: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (r *WebhookRepository) List(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.List")
+	defer span.End()
+
+	query := `SELECT id, url, secret, event_types, COALESCE(created_by, 0), created_at
+              FROM webhook_subscriptions
+              ORDER BY id`
+
+	rows, err := r.runner.conn(ctx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository.List: %w", err)
+	}
+	defer func() { rows.Close() }()
+
+	var subs []domain.WebhookSubscription
+	for rows.Next() {
+		var sub domain.WebhookSubscription
+
+		err = rows.Scan(&sub.ID, &sub.URL, &sub.Secret, &sub.EventTypes, &sub.CreatedBy, &sub.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("WebhookRepository.List: %w", err)
+		}
+
+		subs = append(subs, sub)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("WebhookRepository.List: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id domain.WebhookID) error {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.Delete")
+	defer span.End()
+
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1`
+
+	tag, err := r.runner.exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("WebhookRepository.Delete: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("WebhookRepository.Delete: %w", domain.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) ListDeliveries(
+	ctx context.Context,
+	id domain.WebhookID,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.ListDeliveries")
+	defer span.End()
+
+	opts := pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	}
+
+	var deliveries []domain.WebhookDelivery
+
+	err := r.runner.run(ctx, opts, func(dbTx pgx.Tx) error {
+		var exists bool
+
+		query := `SELECT EXISTS (SELECT 1 FROM webhook_subscriptions WHERE id = $1)`
+		err := dbTx.QueryRow(ctx, query, id).Scan(&exists)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return domain.ErrWebhookNotFound
+		}
+
+		query = `SELECT ` + webhookDeliveryColumns + `
+                 FROM webhook_deliveries d
+                 ` + webhookDeliveryJoins + `
+                 WHERE d.subscription_id = $1
+                 ORDER BY d.id DESC
+                 LIMIT $2`
+
+		rows, err := dbTx.Query(ctx, query, id, limit)
+		if err != nil {
+			return err
+		}
+
+		deliveries, err = pgx.CollectRows(rows, scanWebhookDelivery)
+		if err != nil {
+			return err
+		}
+
+		return r.attachAttempts(ctx, dbTx, deliveries)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository.ListDeliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) attachAttempts(
+	ctx context.Context,
+	dbTx pgx.Tx,
+	deliveries []domain.WebhookDelivery,
+) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	byID := make(map[domain.WebhookDeliveryID]*domain.WebhookDelivery, len(deliveries))
+	ids := make([]domain.WebhookDeliveryID, 0, len(deliveries))
+	for i := range deliveries {
+		byID[deliveries[i].ID] = &deliveries[i]
+		ids = append(ids, deliveries[i].ID)
+	}
+
+	query := `SELECT delivery_id, attempted_at, COALESCE(status_code, 0), COALESCE(error, ''), duration_ms
+              FROM webhook_delivery_attempts
+              WHERE delivery_id = ANY ($1)
+              ORDER BY attempted_at`
+
+	rows, err := dbTx.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("WebhookRepository.attachAttempts: %w", err)
+	}
+	defer func() { rows.Close() }()
+
+	for rows.Next() {
+		var (
+			deliveryID domain.WebhookDeliveryID
+			attempt    domain.WebhookAttempt
+			durationMS int64
+		)
+
+		err = rows.Scan(&deliveryID, &attempt.At, &attempt.StatusCode, &attempt.Error, &durationMS)
+		if err != nil {
+			return fmt.Errorf("WebhookRepository.attachAttempts: %w", err)
+		}
+		attempt.Duration = time.Duration(durationMS) * time.Millisecond
+
+		d := byID[deliveryID]
+		d.Log = append(d.Log, attempt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("WebhookRepository.attachAttempts: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) Redeliver(
+	ctx context.Context,
+	id domain.WebhookDeliveryID,
+) (domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.Redeliver")
+	defer span.End()
+
+	query := `WITH d AS (
+                  INSERT INTO webhook_deliveries (subscription_id, event_id)
+                  SELECT subscription_id, event_id
+                  FROM webhook_deliveries
+                  WHERE id = $1
+                  RETURNING *
+              )
+              SELECT ` + webhookDeliveryColumns + `
+              FROM d
+              ` + webhookDeliveryJoins
+
+	var d domain.WebhookDelivery
+
+	err := r.runner.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		rows, err := dbTx.Query(ctx, query, id)
+		if err != nil {
+			return err
+		}
+
+		d, err = pgx.CollectExactlyOneRow(rows, scanWebhookDelivery)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.WebhookDelivery{}, fmt.Errorf("WebhookRepository.Redeliver: %w",
+				domain.ErrWebhookDeliveryNotFound)
+		}
+		return domain.WebhookDelivery{}, fmt.Errorf("WebhookRepository.Redeliver: %w", err)
+	}
+
+	return d, nil
+}
+
+// ClaimDue relies on FOR UPDATE SKIP LOCKED, so concurrent replicas never lease the same row.
+func (r *WebhookRepository) ClaimDue(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.ClaimDue")
+	defer span.End()
+
+	query := `WITH d AS (
+                  UPDATE webhook_deliveries
+                  SET next_attempt_at = now() + make_interval(secs => $2)
+                  WHERE id IN (
+                      SELECT id
+                      FROM webhook_deliveries
+                      WHERE status = 'pending'
+                        AND next_attempt_at <= now()
+                      ORDER BY next_attempt_at
+                      LIMIT $1
+                      FOR UPDATE SKIP LOCKED
+                  )
+                  RETURNING *
+              )
+              SELECT ` + webhookDeliveryColumns + `
+              FROM d
+              ` + webhookDeliveryJoins + `
+              ORDER BY d.id`
+
+	var deliveries []domain.WebhookDelivery
+
+	err := r.runner.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		rows, err := dbTx.Query(ctx, query, limit, lease.Seconds())
+		if err != nil {
+			return err
+		}
+
+		deliveries, err = pgx.CollectRows(rows, scanWebhookDelivery)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository.ClaimDue: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) RecordAttempt(
+	ctx context.Context,
+	d domain.WebhookDelivery,
+	attempt domain.WebhookAttempt,
+) error {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.RecordAttempt")
+	defer span.End()
+
+	err := r.runner.run(ctx, pgx.TxOptions{}, func(dbTx pgx.Tx) error {
+		query := `INSERT INTO webhook_delivery_attempts
+    			  (delivery_id, attempted_at, status_code, error, duration_ms)
+                  VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, ''), $5)`
+
+		_, err := dbTx.Exec(ctx, query, d.ID, attempt.At, attempt.StatusCode, attempt.Error,
+			attempt.Duration.Milliseconds())
+		if err != nil {
+			return err
+		}
+
+		query = `UPDATE webhook_deliveries
+                 SET status = $2,
+                     attempts = $3,
+                     next_attempt_at = $4,
+                     last_error = NULLIF($5, ''),
+                     delivered_at = CASE WHEN $2 = 'delivered' THEN $6::TIMESTAMPTZ END
+                 WHERE id = $1`
+
+		_, err = dbTx.Exec(ctx, query, d.ID, d.Status, d.Attempts, d.NextAttemptAt, d.LastError, attempt.At)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("WebhookRepository.RecordAttempt: %w", err)
+	}
+
+	return nil
+}
+
+func scanWebhookDelivery(row pgx.CollectableRow) (domain.WebhookDelivery, error) {
+	var (
+		d           domain.WebhookDelivery
+		deliveredAt *time.Time
+	)
+
+	err := row.Scan(
+		&d.ID,
+		&d.Subscription.ID,
+		&d.Subscription.URL,
+		&d.Subscription.Secret,
+		&d.Event.ID,
+		&d.Event.Type,
+		&d.Event.Payload,
+		&d.Event.CreatedAt,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastError,
+		&d.CreatedAt,
+		&deliveredAt,
+	)
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	if deliveredAt != nil {
+		d.DeliveredAt = *deliveredAt
+	}
+
+	return d, nil
+}
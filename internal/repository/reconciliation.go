@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Reconciliation --filename=reconciliation_repository_mock.go
+type Reconciliation interface {
+	Run(ctx context.Context) (domain.Reconciliation, error)
+	GetLatest(ctx context.Context) (domain.Reconciliation, error)
+	Apply(ctx context.Context, id domain.ReconciliationID, adminID domain.UserID) (domain.Reconciliation, error)
+}
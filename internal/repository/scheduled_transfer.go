@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=ScheduledTransfer --filename=scheduled_transfer_repository_mock.go
+type ScheduledTransfer interface {
+	Create(ctx context.Context, st domain.ScheduledTransfer) (domain.ScheduledTransfer, error)
+	ListBySender(ctx context.Context, uid domain.UserID) ([]domain.ScheduledTransfer, error)
+	Cancel(ctx context.Context, uid domain.UserID, id domain.ScheduledTransferID) error
+	ListRuns(ctx context.Context, uid domain.UserID, id domain.ScheduledTransferID) ([]domain.ScheduledTransferRun, error)
+	// ClaimDue leases up to limit due transfers so that no other replica picks them until
+	// Complete is called or the lease expires.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]domain.ScheduledTransfer, error)
+	// Complete moves claimed to next and records the run. It fails with ErrScheduledTransferNotDue
+	// when claimed was run meanwhile, e.g. by another replica after the lease expired.
+	Complete(ctx context.Context, claimed, next domain.ScheduledTransfer, run domain.ScheduledTransferRun) error
+}
@@ -5,10 +5,19 @@ import (
 	"context"
 )
 
-const ShopDBID = 1
+const (
+	ShopDBID = 1
+	// SystemDBID issues minted coins and receives burnt ones, its balance is never tracked.
+	SystemDBID = 2
+)
 
 //go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Transaction --filename=tx_repository_mock.go
 type Transaction interface {
 	SendCoin(ctx context.Context, tx domain.Transaction) error
 	BuyItem(ctx context.Context, uid domain.UserID, item domain.Merch) error
+	Reverse(ctx context.Context, rev domain.TransactionReversal) ([]domain.Transaction, error)
+	Mint(ctx context.Context, adj domain.CoinAdjustment) (domain.Transaction, error)
+	Burn(ctx context.Context, adj domain.CoinAdjustment) (domain.Transaction, error)
+	GrantAllowance(ctx context.Context, period domain.AllowancePeriod, amount int) (int, error)
+	ExpireLots(ctx context.Context, limit int) (int, error)
 }
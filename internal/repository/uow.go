@@ -0,0 +1,13 @@
+package repository
+
+import "context"
+
+// UnitOfWork lets services compose several repository calls atomically.
+// Repositories called with the context passed to fn join its transaction.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=UnitOfWork --filename=uow_mock.go
+type UnitOfWork interface {
+	// Do runs fn in one transaction, committing it when fn returns nil and rolling it back otherwise.
+	// fn is called again from scratch when the transaction has to be retried.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
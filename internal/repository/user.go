@@ -10,4 +10,8 @@ type User interface {
 	Put(ctx context.Context, user domain.User) (domain.UserID, error)
 	GetByName(ctx context.Context, name domain.UserName) (domain.User, error)
 	GetInfoByID(ctx context.Context, id domain.UserID) (domain.UserInfo, error)
+	GetNamesByIDs(ctx context.Context, ids []domain.UserID) (map[domain.UserID]domain.UserName, error)
+	IsAdmin(ctx context.Context, id domain.UserID) (bool, error)
+	GetLanguage(ctx context.Context, id domain.UserID) (domain.Language, error)
+	SetLanguage(ctx context.Context, id domain.UserID, lang domain.Language) error
 }
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Webhook --filename=webhook_repository_mock.go
+type Webhook interface {
+	Create(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	List(ctx context.Context) ([]domain.WebhookSubscription, error)
+	// Delete removes the subscription together with its delivery log.
+	Delete(ctx context.Context, id domain.WebhookID) error
+	// ListDeliveries returns up to limit latest deliveries of the subscription with their attempts.
+	ListDeliveries(ctx context.Context, id domain.WebhookID, limit int) ([]domain.WebhookDelivery, error)
+	// Redeliver queues the event of a delivery once more as a new delivery, whatever the status of the old one.
+	Redeliver(ctx context.Context, id domain.WebhookDeliveryID) (domain.WebhookDelivery, error)
+	// ClaimDue leases up to limit pending deliveries due for an attempt, like ScheduledTransfer.ClaimDue.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookDelivery, error)
+	// RecordAttempt logs the attempt and stores the status, attempts and next attempt time of d.
+	RecordAttempt(ctx context.Context, d domain.WebhookDelivery, attempt domain.WebhookAttempt) error
+}
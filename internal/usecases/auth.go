@@ -11,4 +11,5 @@ type Auth interface {
 	Register(ctx context.Context, username domain.UserName, password string) (domain.Token, error)
 	GenerateToken(user domain.User) (domain.Token, error)
 	ParseToken(token domain.Token) (domain.UserID, error)
+	IsAdmin(ctx context.Context, id domain.UserID) (bool, error)
 }
@@ -0,0 +1,15 @@
+package usecases
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=CoinRequest --filename=coin_request_service_mock.go
+type CoinRequest interface {
+	CreateByName(ctx context.Context, req domain.CoinRequest, payer domain.UserName) (domain.CoinRequest, error)
+	Accept(ctx context.Context, uid domain.UserID, id domain.CoinRequestID) error
+	Decline(ctx context.Context, uid domain.UserID, id domain.CoinRequestID) error
+	ListIncoming(ctx context.Context, uid domain.UserID) ([]domain.CoinRequest, error)
+	ListOutgoing(ctx context.Context, uid domain.UserID) ([]domain.CoinRequest, error)
+}
@@ -0,0 +1,13 @@
+package usecases
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Email --filename=email_service_mock.go
+type Email interface {
+	GetPreferences(ctx context.Context, uid domain.UserID) (domain.EmailPreferences, error)
+	SetPreferences(ctx context.Context, prefs domain.EmailPreferences) error
+	SendDue(ctx context.Context) (int, error)
+}
@@ -0,0 +1,15 @@
+package usecases
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=EventStream --filename=event_stream_service_mock.go
+type EventStream interface {
+	// Subscribe returns the events of user uid. The channel is closed once ctx is done
+	// or when the subscriber falls too far behind.
+	Subscribe(ctx context.Context, uid domain.UserID) <-chan domain.StreamEvent
+	// Listen routes events from all replicas to the local subscribers until ctx is cancelled.
+	Listen(ctx context.Context) error
+}
@@ -0,0 +1,14 @@
+package usecases
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=History --filename=history_service_mock.go
+type History interface {
+	// Transactions returns up to limit latest transactions of the user, newest first.
+	Transactions(ctx context.Context, uid domain.UserID, limit int) ([]domain.TransactionRecord, error)
+	// Orders returns up to limit latest purchases of the user, newest first.
+	Orders(ctx context.Context, uid domain.UserID, limit int) ([]domain.Order, error)
+}
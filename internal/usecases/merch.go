@@ -0,0 +1,13 @@
+package usecases
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Merch --filename=merch_service_mock.go
+type Merch interface {
+	List(ctx context.Context) ([]domain.Merch, error)
+	// GetByNames skips the names that aren't in the catalog.
+	GetByNames(ctx context.Context, names []domain.MerchName) ([]domain.Merch, error)
+}
@@ -42,6 +42,34 @@ func (_m *Auth) GenerateToken(user domain.User) (string, error) {
 	return r0, r1
 }
 
+// IsAdmin provides a mock function with given fields: ctx, id
+func (_m *Auth) IsAdmin(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAdmin")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: ctx, username, password
 func (_m *Auth) Login(ctx context.Context, username string, password string) (string, error) {
 	ret := _m.Called(ctx, username, password)
@@ -0,0 +1,153 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "avito_shop/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CoinRequest is an autogenerated mock type for the CoinRequest type
+type CoinRequest struct {
+	mock.Mock
+}
+
+// Accept provides a mock function with given fields: ctx, uid, id
+func (_m *CoinRequest) Accept(ctx context.Context, uid int, id int) error {
+	ret := _m.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Accept")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateByName provides a mock function with given fields: ctx, req, payer
+func (_m *CoinRequest) CreateByName(ctx context.Context, req domain.CoinRequest, payer string) (domain.CoinRequest, error) {
+	ret := _m.Called(ctx, req, payer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateByName")
+	}
+
+	var r0 domain.CoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinRequest, string) (domain.CoinRequest, error)); ok {
+		return rf(ctx, req, payer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinRequest, string) domain.CoinRequest); ok {
+		r0 = rf(ctx, req, payer)
+	} else {
+		r0 = ret.Get(0).(domain.CoinRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CoinRequest, string) error); ok {
+		r1 = rf(ctx, req, payer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Decline provides a mock function with given fields: ctx, uid, id
+func (_m *CoinRequest) Decline(ctx context.Context, uid int, id int) error {
+	ret := _m.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decline")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListIncoming provides a mock function with given fields: ctx, uid
+func (_m *CoinRequest) ListIncoming(ctx context.Context, uid int) ([]domain.CoinRequest, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIncoming")
+	}
+
+	var r0 []domain.CoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.CoinRequest, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.CoinRequest); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CoinRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOutgoing provides a mock function with given fields: ctx, uid
+func (_m *CoinRequest) ListOutgoing(ctx context.Context, uid int) ([]domain.CoinRequest, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOutgoing")
+	}
+
+	var r0 []domain.CoinRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.CoinRequest, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.CoinRequest); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CoinRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCoinRequest creates a new instance of CoinRequest. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCoinRequest(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CoinRequest {
+	mock := &CoinRequest{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "avito_shop/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Email is an autogenerated mock type for the Email type
+type Email struct {
+	mock.Mock
+}
+
+// GetPreferences provides a mock function with given fields: ctx, uid
+func (_m *Email) GetPreferences(ctx context.Context, uid int) (domain.EmailPreferences, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreferences")
+	}
+
+	var r0 domain.EmailPreferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.EmailPreferences, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.EmailPreferences); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(domain.EmailPreferences)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendDue provides a mock function with given fields: ctx
+func (_m *Email) SendDue(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendDue")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetPreferences provides a mock function with given fields: ctx, prefs
+func (_m *Email) SetPreferences(ctx context.Context, prefs domain.EmailPreferences) error {
+	ret := _m.Called(ctx, prefs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPreferences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.EmailPreferences) error); ok {
+		r0 = rf(ctx, prefs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewEmail creates a new instance of Email. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEmail(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Email {
+	mock := &Email{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,67 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "avito_shop/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventStream is an autogenerated mock type for the EventStream type
+type EventStream struct {
+	mock.Mock
+}
+
+// Listen provides a mock function with given fields: ctx
+func (_m *EventStream) Listen(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Listen")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Subscribe provides a mock function with given fields: ctx, uid
+func (_m *EventStream) Subscribe(ctx context.Context, uid int) <-chan domain.StreamEvent {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan domain.StreamEvent
+	if rf, ok := ret.Get(0).(func(context.Context, int) <-chan domain.StreamEvent); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan domain.StreamEvent)
+		}
+	}
+
+	return r0
+}
+
+// NewEventStream creates a new instance of EventStream. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventStream(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventStream {
+	mock := &EventStream{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
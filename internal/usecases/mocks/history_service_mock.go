@@ -0,0 +1,89 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "avito_shop/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// History is an autogenerated mock type for the History type
+type History struct {
+	mock.Mock
+}
+
+// Orders provides a mock function with given fields: ctx, uid, limit
+func (_m *History) Orders(ctx context.Context, uid int, limit int) ([]domain.Order, error) {
+	ret := _m.Called(ctx, uid, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Orders")
+	}
+
+	var r0 []domain.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]domain.Order, error)); ok {
+		return rf(ctx, uid, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.Order); ok {
+		r0 = rf(ctx, uid, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, uid, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transactions provides a mock function with given fields: ctx, uid, limit
+func (_m *History) Transactions(ctx context.Context, uid int, limit int) ([]domain.TransactionRecord, error) {
+	ret := _m.Called(ctx, uid, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transactions")
+	}
+
+	var r0 []domain.TransactionRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]domain.TransactionRecord, error)); ok {
+		return rf(ctx, uid, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.TransactionRecord); ok {
+		r0 = rf(ctx, uid, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransactionRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, uid, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewHistory creates a new instance of History. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHistory(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *History {
+	mock := &History{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
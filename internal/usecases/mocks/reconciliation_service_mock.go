@@ -0,0 +1,113 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "avito_shop/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Reconciliation is an autogenerated mock type for the Reconciliation type
+type Reconciliation struct {
+	mock.Mock
+}
+
+// Apply provides a mock function with given fields: ctx, id, adminID
+func (_m *Reconciliation) Apply(ctx context.Context, id int, adminID int) (domain.Reconciliation, error) {
+	ret := _m.Called(ctx, id, adminID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Apply")
+	}
+
+	var r0 domain.Reconciliation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (domain.Reconciliation, error)); ok {
+		return rf(ctx, id, adminID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) domain.Reconciliation); ok {
+		r0 = rf(ctx, id, adminID)
+	} else {
+		r0 = ret.Get(0).(domain.Reconciliation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, adminID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatest provides a mock function with given fields: ctx
+func (_m *Reconciliation) GetLatest(ctx context.Context) (domain.Reconciliation, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatest")
+	}
+
+	var r0 domain.Reconciliation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.Reconciliation, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.Reconciliation); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.Reconciliation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *Reconciliation) Run(ctx context.Context) (domain.Reconciliation, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 domain.Reconciliation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.Reconciliation, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.Reconciliation); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.Reconciliation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReconciliation creates a new instance of Reconciliation. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReconciliation(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Reconciliation {
+	mock := &Reconciliation{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,163 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "avito_shop/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ScheduledTransfer is an autogenerated mock type for the ScheduledTransfer type
+type ScheduledTransfer struct {
+	mock.Mock
+}
+
+// Cancel provides a mock function with given fields: ctx, uid, id
+func (_m *ScheduledTransfer) Cancel(ctx context.Context, uid int, id int) error {
+	ret := _m.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateByName provides a mock function with given fields: ctx, st, to
+func (_m *ScheduledTransfer) CreateByName(ctx context.Context, st domain.ScheduledTransfer, to string) (domain.ScheduledTransfer, error) {
+	ret := _m.Called(ctx, st, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateByName")
+	}
+
+	var r0 domain.ScheduledTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ScheduledTransfer, string) (domain.ScheduledTransfer, error)); ok {
+		return rf(ctx, st, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ScheduledTransfer, string) domain.ScheduledTransfer); ok {
+		r0 = rf(ctx, st, to)
+	} else {
+		r0 = ret.Get(0).(domain.ScheduledTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ScheduledTransfer, string) error); ok {
+		r1 = rf(ctx, st, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, uid
+func (_m *ScheduledTransfer) List(ctx context.Context, uid int) ([]domain.ScheduledTransfer, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.ScheduledTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.ScheduledTransfer, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.ScheduledTransfer); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ScheduledTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRuns provides a mock function with given fields: ctx, uid, id
+func (_m *ScheduledTransfer) ListRuns(ctx context.Context, uid int, id int) ([]domain.ScheduledTransferRun, error) {
+	ret := _m.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRuns")
+	}
+
+	var r0 []domain.ScheduledTransferRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]domain.ScheduledTransferRun, error)); ok {
+		return rf(ctx, uid, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.ScheduledTransferRun); ok {
+		r0 = rf(ctx, uid, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ScheduledTransferRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunDue provides a mock function with given fields: ctx
+func (_m *ScheduledTransfer) RunDue(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunDue")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewScheduledTransfer creates a new instance of ScheduledTransfer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduledTransfer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduledTransfer {
+	mock := &ScheduledTransfer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,143 @@
+// Code generated by mockery v2.52.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "avito_shop/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Treasury is an autogenerated mock type for the Treasury type
+type Treasury struct {
+	mock.Mock
+}
+
+// BurnByName provides a mock function with given fields: ctx, adj, from
+func (_m *Treasury) BurnByName(ctx context.Context, adj domain.CoinAdjustment, from string) (domain.Transaction, error) {
+	ret := _m.Called(ctx, adj, from)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BurnByName")
+	}
+
+	var r0 domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinAdjustment, string) (domain.Transaction, error)); ok {
+		return rf(ctx, adj, from)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinAdjustment, string) domain.Transaction); ok {
+		r0 = rf(ctx, adj, from)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CoinAdjustment, string) error); ok {
+		r1 = rf(ctx, adj, from)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpireCoins provides a mock function with given fields: ctx
+func (_m *Treasury) ExpireCoins(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireCoins")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantAllowance provides a mock function with given fields: ctx, now
+func (_m *Treasury) GrantAllowance(ctx context.Context, now time.Time) (domain.AllowanceGrant, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantAllowance")
+	}
+
+	var r0 domain.AllowanceGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (domain.AllowanceGrant, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) domain.AllowanceGrant); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(domain.AllowanceGrant)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MintByName provides a mock function with given fields: ctx, adj, to
+func (_m *Treasury) MintByName(ctx context.Context, adj domain.CoinAdjustment, to string) (domain.Transaction, error) {
+	ret := _m.Called(ctx, adj, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MintByName")
+	}
+
+	var r0 domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinAdjustment, string) (domain.Transaction, error)); ok {
+		return rf(ctx, adj, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CoinAdjustment, string) domain.Transaction); ok {
+		r0 = rf(ctx, adj, to)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CoinAdjustment, string) error); ok {
+		r1 = rf(ctx, adj, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTreasury creates a new instance of Treasury. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTreasury(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Treasury {
+	mock := &Treasury{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -32,6 +32,36 @@ func (_m *Transaction) BuyItemByName(ctx context.Context, uid int, name string)
 	return r0
 }
 
+// Reverse provides a mock function with given fields: ctx, rev
+func (_m *Transaction) Reverse(ctx context.Context, rev domain.TransactionReversal) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, rev)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reverse")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionReversal) ([]domain.Transaction, error)); ok {
+		return rf(ctx, rev)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionReversal) []domain.Transaction); ok {
+		r0 = rf(ctx, rev)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TransactionReversal) error); ok {
+		r1 = rf(ctx, rev)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendCoinByName provides a mock function with given fields: ctx, tx, to
 func (_m *Transaction) SendCoinByName(ctx context.Context, tx domain.Transaction, to string) error {
 	ret := _m.Called(ctx, tx, to)
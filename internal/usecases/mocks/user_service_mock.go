@@ -70,6 +70,64 @@ func (_m *User) GetInfoByID(ctx context.Context, id int) (domain.UserInfo, error
 	return r0, r1
 }
 
+// GetLanguage provides a mock function with given fields: ctx, id
+func (_m *User) GetLanguage(ctx context.Context, id int) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLanguage")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNamesByIDs provides a mock function with given fields: ctx, ids
+func (_m *User) GetNamesByIDs(ctx context.Context, ids []int) (map[int]string, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamesByIDs")
+	}
+
+	var r0 map[int]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) (map[int]string, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) map[int]string); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Put provides a mock function with given fields: ctx, user
 func (_m *User) Put(ctx context.Context, user domain.User) (int, error) {
 	ret := _m.Called(ctx, user)
@@ -98,6 +156,24 @@ func (_m *User) Put(ctx context.Context, user domain.User) (int, error) {
 	return r0, r1
 }
 
+// SetLanguage provides a mock function with given fields: ctx, id, lang
+func (_m *User) SetLanguage(ctx context.Context, id int, lang string) error {
+	ret := _m.Called(ctx, id, lang)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLanguage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, id, lang)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUser creates a new instance of User. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUser(t interface {
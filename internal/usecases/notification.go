@@ -0,0 +1,13 @@
+package usecases
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Notification --filename=notification_service_mock.go
+type Notification interface {
+	// ListUnread returns the next page of unread notifications with event ids above after.
+	ListUnread(ctx context.Context, uid domain.UserID, after domain.EventID) ([]domain.StreamEvent, error)
+	Ack(ctx context.Context, uid domain.UserID, ids []domain.EventID) (int, error)
+}
@@ -0,0 +1,11 @@
+package usecases
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Outbox --filename=outbox_service_mock.go
+type Outbox interface {
+	Relay(ctx context.Context) (domain.OutboxRelay, error)
+}
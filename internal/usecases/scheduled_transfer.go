@@ -0,0 +1,15 @@
+package usecases
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=ScheduledTransfer --filename=scheduled_transfer_service_mock.go
+type ScheduledTransfer interface {
+	CreateByName(ctx context.Context, st domain.ScheduledTransfer, to domain.UserName) (domain.ScheduledTransfer, error)
+	List(ctx context.Context, uid domain.UserID) ([]domain.ScheduledTransfer, error)
+	Cancel(ctx context.Context, uid domain.UserID, id domain.ScheduledTransferID) error
+	ListRuns(ctx context.Context, uid domain.UserID, id domain.ScheduledTransferID) ([]domain.ScheduledTransferRun, error)
+	RunDue(ctx context.Context) (int, error)
+}
@@ -26,6 +26,9 @@ func NewAuth(repo repository.User, secret string) usecases.Auth {
 }
 
 func (s *Auth) Login(ctx context.Context, username domain.UserName, password string) (domain.Token, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.Login")
+	defer span.End()
+
 	user, err := s.userRepo.GetByName(ctx, username)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
@@ -34,7 +37,7 @@ func (s *Auth) Login(ctx context.Context, username domain.UserName, password str
 		return "", fmt.Errorf("AuthService.Login: %w", err)
 	}
 
-	if ok := s.compareHash(user.HashedPassword, password); ok {
+	if ok := s.compareHash(ctx, user.HashedPassword, password); ok {
 		return s.GenerateToken(user)
 	}
 
@@ -42,7 +45,10 @@ func (s *Auth) Login(ctx context.Context, username domain.UserName, password str
 }
 
 func (s *Auth) Register(ctx context.Context, username domain.UserName, password string) (domain.Token, error) {
-	hashedPassword, err := s.hashPassword(password)
+	ctx, span := tracer.Start(ctx, "AuthService.Register")
+	defer span.End()
+
+	hashedPassword, err := s.hashPassword(ctx, password)
 	if err != nil {
 		return "", fmt.Errorf("AuthService.Register: %w", err)
 	}
@@ -85,7 +91,23 @@ func (s *Auth) ParseToken(token domain.Token) (domain.UserID, error) {
 	return val, nil
 }
 
-func (s *Auth) hashPassword(password string) (domain.UserHashPass, error) {
+func (s *Auth) IsAdmin(ctx context.Context, id domain.UserID) (bool, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.IsAdmin")
+	defer span.End()
+
+	isAdmin, err := s.userRepo.IsAdmin(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("AuthService.IsAdmin: %w", err)
+	}
+
+	return isAdmin, nil
+}
+
+// bcrypt is slow on purpose, its own span keeps it from being mistaken for a slow query.
+func (s *Auth) hashPassword(ctx context.Context, password string) (domain.UserHashPass, error) {
+	_, span := tracer.Start(ctx, "bcrypt.GenerateFromPassword")
+	defer span.End()
+
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, err
@@ -94,7 +116,10 @@ func (s *Auth) hashPassword(password string) (domain.UserHashPass, error) {
 	return hash, nil
 }
 
-func (s *Auth) compareHash(hashedPassword domain.UserHashPass, password string) bool {
+func (s *Auth) compareHash(ctx context.Context, hashedPassword domain.UserHashPass, password string) bool {
+	_, span := tracer.Start(ctx, "bcrypt.CompareHashAndPassword")
+	defer span.End()
+
 	err := bcrypt.CompareHashAndPassword(hashedPassword, domain.UserHashPass(password))
 	return err == nil
 }
@@ -193,3 +193,38 @@ func TestParseToken_IncorrectToken(t *testing.T) {
 
 	require.Error(t, err)
 }
+
+func TestIsAdmin_Success(t *testing.T) {
+	t.Parallel()
+
+	userRepo := mocks.NewUser(t)
+	svc := NewAuth(userRepo, secretForTests)
+
+	uid := 2
+	ctx := context.Background()
+
+	userRepo.On("IsAdmin", mock.Anything, uid).Return(true, nil)
+
+	isAdmin, err := svc.IsAdmin(ctx, uid)
+
+	require.NoError(t, err)
+	require.True(t, isAdmin)
+	userRepo.AssertExpectations(t)
+}
+
+func TestIsAdmin_DBError(t *testing.T) {
+	t.Parallel()
+
+	userRepo := mocks.NewUser(t)
+	svc := NewAuth(userRepo, secretForTests)
+
+	uid := 2
+	ctx := context.Background()
+
+	userRepo.On("IsAdmin", mock.Anything, uid).Return(false, errors.New("unexpected DBError"))
+
+	_, err := svc.IsAdmin(ctx, uid)
+
+	require.Error(t, err)
+	userRepo.AssertExpectations(t)
+}
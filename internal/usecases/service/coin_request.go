@@ -0,0 +1,158 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases"
+	"context"
+	"fmt"
+	"time"
+)
+
+type CoinRequest struct {
+	uow      repository.UnitOfWork
+	repo     repository.CoinRequest
+	txRepo   repository.Transaction
+	userRepo repository.User
+	ttl      time.Duration
+}
+
+func NewCoinRequest(
+	uow repository.UnitOfWork,
+	repo repository.CoinRequest,
+	txRepo repository.Transaction,
+	userRepo repository.User,
+	ttl time.Duration,
+) usecases.CoinRequest {
+	return &CoinRequest{
+		uow:      uow,
+		repo:     repo,
+		txRepo:   txRepo,
+		userRepo: userRepo,
+		ttl:      ttl,
+	}
+}
+
+func (s *CoinRequest) CreateByName(
+	ctx context.Context,
+	req domain.CoinRequest,
+	payer domain.UserName,
+) (domain.CoinRequest, error) {
+	ctx, span := tracer.Start(ctx, "CoinRequestService.CreateByName")
+	defer span.End()
+
+	payerUser, err := s.userRepo.GetByName(ctx, payer)
+	if err != nil {
+		return domain.CoinRequest{}, fmt.Errorf("CoinRequestService.CreateByName: %w", err)
+	}
+
+	if isServiceAccount(payerUser.ID) {
+		return domain.CoinRequest{}, fmt.Errorf("CoinRequestService.CreateByName: %w", domain.ErrUserNotFound)
+	}
+
+	if payerUser.ID == req.Requester {
+		return domain.CoinRequest{}, fmt.Errorf("CoinRequestService.CreateByName: %w", domain.ErrSelfRequesting)
+	}
+	req.Payer = payerUser.ID
+	req.ExpiresAt = time.Now().Add(s.ttl)
+
+	created, err := s.repo.Create(ctx, req)
+	if err != nil {
+		return domain.CoinRequest{}, fmt.Errorf("CoinRequestService.CreateByName: %w", err)
+	}
+
+	return created, nil
+}
+
+// Accept resolves the request and pays it in one unit of work, so a request is never left
+// accepted without the payment, and concurrent accepts can't pay the same request twice.
+func (s *CoinRequest) Accept(ctx context.Context, uid domain.UserID, id domain.CoinRequestID) error {
+	ctx, span := tracer.Start(ctx, "CoinRequestService.Accept")
+	defer span.End()
+
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		req, err := s.getActiveForPayer(ctx, uid, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.repo.Resolve(ctx, id, domain.CoinRequestAccepted)
+		if err != nil {
+			return err
+		}
+
+		return s.txRepo.SendCoin(ctx, domain.Transaction{
+			From:   req.Payer,
+			To:     req.Requester,
+			Amount: req.Amount,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("CoinRequestService.Accept: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CoinRequest) Decline(ctx context.Context, uid domain.UserID, id domain.CoinRequestID) error {
+	ctx, span := tracer.Start(ctx, "CoinRequestService.Decline")
+	defer span.End()
+
+	_, err := s.getActiveForPayer(ctx, uid, id)
+	if err != nil {
+		return fmt.Errorf("CoinRequestService.Decline: %w", err)
+	}
+
+	err = s.repo.Resolve(ctx, id, domain.CoinRequestDeclined)
+	if err != nil {
+		return fmt.Errorf("CoinRequestService.Decline: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CoinRequest) ListIncoming(ctx context.Context, uid domain.UserID) ([]domain.CoinRequest, error) {
+	ctx, span := tracer.Start(ctx, "CoinRequestService.ListIncoming")
+	defer span.End()
+
+	reqs, err := s.repo.ListByPayer(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("CoinRequestService.ListIncoming: %w", err)
+	}
+
+	return reqs, nil
+}
+
+func (s *CoinRequest) ListOutgoing(ctx context.Context, uid domain.UserID) ([]domain.CoinRequest, error) {
+	ctx, span := tracer.Start(ctx, "CoinRequestService.ListOutgoing")
+	defer span.End()
+
+	reqs, err := s.repo.ListByRequester(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("CoinRequestService.ListOutgoing: %w", err)
+	}
+
+	return reqs, nil
+}
+
+func (s *CoinRequest) getActiveForPayer(
+	ctx context.Context,
+	uid domain.UserID,
+	id domain.CoinRequestID,
+) (domain.CoinRequest, error) {
+	req, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.CoinRequest{}, err
+	}
+
+	// Requests addressed to other users are reported as missing to avoid leaking their existence.
+	if req.Payer != uid {
+		return domain.CoinRequest{}, domain.ErrCoinRequestNotFound
+	}
+
+	if req.Status != domain.CoinRequestPending {
+		return domain.CoinRequest{}, domain.ErrCoinRequestNotActive
+	}
+
+	return req, nil
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/repository/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCoinRequestByName_Success(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewCoinRequest(t)
+	userRepo := mocks.NewUser(t)
+	svc := NewCoinRequest(nil, repo, nil, userRepo, time.Hour)
+
+	requesterID := 3
+	payerID := 4
+	payerName := "Avito"
+	amount := 100
+	ctx := context.Background()
+
+	userRepo.On("GetByName", mock.Anything, payerName).
+		Return(domain.User{ID: payerID, Name: payerName}, nil)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(req domain.CoinRequest) bool {
+		return req.Requester == requesterID &&
+			req.Payer == payerID &&
+			req.Amount == amount &&
+			req.ExpiresAt.After(time.Now())
+	})).Return(domain.CoinRequest{ID: 1, Requester: requesterID, Payer: payerID, Amount: amount}, nil)
+
+	created, err := svc.CreateByName(ctx, domain.CoinRequest{Requester: requesterID, Amount: amount}, payerName)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, created.ID)
+	userRepo.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateCoinRequestByName_SelfRequesting(t *testing.T) {
+	t.Parallel()
+
+	userRepo := mocks.NewUser(t)
+	svc := NewCoinRequest(nil, nil, nil, userRepo, time.Hour)
+
+	requesterID := 3
+	payerName := "Avito"
+	ctx := context.Background()
+
+	userRepo.On("GetByName", mock.Anything, payerName).
+		Return(domain.User{ID: requesterID, Name: payerName}, nil)
+
+	_, err := svc.CreateByName(ctx, domain.CoinRequest{Requester: requesterID, Amount: 100}, payerName)
+
+	require.ErrorIs(t, err, domain.ErrSelfRequesting)
+	userRepo.AssertExpectations(t)
+}
+
+func TestCreateCoinRequestByName_ServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	for _, payerID := range []domain.UserID{repository.ShopDBID, repository.SystemDBID} {
+		userRepo := mocks.NewUser(t)
+		svc := NewCoinRequest(nil, nil, nil, userRepo, time.Hour)
+
+		payerName := "shop"
+		ctx := context.Background()
+
+		userRepo.On("GetByName", mock.Anything, payerName).
+			Return(domain.User{ID: payerID, Name: payerName}, nil)
+
+		_, err := svc.CreateByName(ctx, domain.CoinRequest{Requester: 3, Amount: 100}, payerName)
+
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+		userRepo.AssertExpectations(t)
+	}
+}
+
+func TestCreateCoinRequestByName_InvalidUsername(t *testing.T) {
+	t.Parallel()
+
+	userRepo := mocks.NewUser(t)
+	svc := NewCoinRequest(nil, nil, nil, userRepo, time.Hour)
+
+	payerName := "Avito"
+	ctx := context.Background()
+
+	userRepo.On("GetByName", mock.Anything, payerName).
+		Return(domain.User{}, domain.ErrUserNotFound)
+
+	_, err := svc.CreateByName(ctx, domain.CoinRequest{Requester: 3, Amount: 100}, payerName)
+
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+	userRepo.AssertExpectations(t)
+}
+
+func TestAcceptCoinRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewCoinRequest(t)
+	txRepo := mocks.NewTransaction(t)
+	svc := NewCoinRequest(newUnitOfWork(t), repo, txRepo, nil, time.Hour)
+
+	req := domain.CoinRequest{
+		ID:        1,
+		Requester: 1,
+		Payer:     2,
+		Amount:    100,
+		Status:    domain.CoinRequestPending,
+	}
+	ctx := context.Background()
+
+	repo.On("GetByID", inUnitOfWork, req.ID).Return(req, nil)
+	repo.On("Resolve", inUnitOfWork, req.ID, domain.CoinRequestAccepted).Return(nil)
+	txRepo.On(
+		"SendCoin",
+		inUnitOfWork,
+		domain.Transaction{From: req.Payer, To: req.Requester, Amount: req.Amount}).
+		Return(nil)
+
+	err := svc.Accept(ctx, req.Payer, req.ID)
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+}
+
+func TestAcceptCoinRequest_LowBalance(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewCoinRequest(t)
+	txRepo := mocks.NewTransaction(t)
+	svc := NewCoinRequest(newUnitOfWork(t), repo, txRepo, nil, time.Hour)
+
+	req := domain.CoinRequest{
+		ID:        1,
+		Requester: 1,
+		Payer:     2,
+		Amount:    100,
+		Status:    domain.CoinRequestPending,
+	}
+	ctx := context.Background()
+
+	repo.On("GetByID", inUnitOfWork, req.ID).Return(req, nil)
+	repo.On("Resolve", inUnitOfWork, req.ID, domain.CoinRequestAccepted).Return(nil)
+	txRepo.On("SendCoin", inUnitOfWork, mock.Anything).Return(domain.ErrLowBalance)
+
+	err := svc.Accept(ctx, req.Payer, req.ID)
+
+	require.ErrorIs(t, err, domain.ErrLowBalance)
+	repo.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+}
+
+// A request resolved and paid in a unit of work that fails to commit is reported as failed,
+// nothing is left to undo because the resolve is rolled back together with the payment.
+func TestAcceptCoinRequest_CommitFails(t *testing.T) {
+	t.Parallel()
+
+	uow := mocks.NewUnitOfWork(t)
+	repo := mocks.NewCoinRequest(t)
+	txRepo := mocks.NewTransaction(t)
+	svc := NewCoinRequest(uow, repo, txRepo, nil, time.Hour)
+
+	req := domain.CoinRequest{
+		ID:        1,
+		Requester: 1,
+		Payer:     2,
+		Amount:    100,
+		Status:    domain.CoinRequestPending,
+	}
+	ctx := context.Background()
+
+	uow.On("Do", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(context.Context) error) error {
+			require.NoError(t, fn(ctx))
+			return errors.New("could not serialize access")
+		})
+	repo.On("GetByID", mock.Anything, req.ID).Return(req, nil)
+	repo.On("Resolve", mock.Anything, req.ID, domain.CoinRequestAccepted).Return(nil)
+	txRepo.On("SendCoin", mock.Anything, mock.Anything).Return(nil)
+
+	err := svc.Accept(ctx, req.Payer, req.ID)
+
+	require.Error(t, err)
+	uow.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+}
+
+func TestAcceptCoinRequest_NotPayer(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewCoinRequest(t)
+	svc := NewCoinRequest(newUnitOfWork(t), repo, nil, nil, time.Hour)
+
+	req := domain.CoinRequest{
+		ID:        1,
+		Requester: 1,
+		Payer:     2,
+		Amount:    100,
+		Status:    domain.CoinRequestPending,
+	}
+	ctx := context.Background()
+
+	repo.On("GetByID", inUnitOfWork, req.ID).Return(req, nil)
+
+	err := svc.Accept(ctx, req.Requester, req.ID)
+
+	require.ErrorIs(t, err, domain.ErrCoinRequestNotFound)
+	repo.AssertExpectations(t)
+}
+
+func TestAcceptCoinRequest_Expired(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewCoinRequest(t)
+	svc := NewCoinRequest(newUnitOfWork(t), repo, nil, nil, time.Hour)
+
+	req := domain.CoinRequest{
+		ID:        1,
+		Requester: 1,
+		Payer:     2,
+		Amount:    100,
+		Status:    domain.CoinRequestExpired,
+	}
+	ctx := context.Background()
+
+	repo.On("GetByID", inUnitOfWork, req.ID).Return(req, nil)
+
+	err := svc.Accept(ctx, req.Payer, req.ID)
+
+	require.ErrorIs(t, err, domain.ErrCoinRequestNotActive)
+	repo.AssertExpectations(t)
+}
+
+func TestAcceptCoinRequest_ConcurrentlyResolved(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewCoinRequest(t)
+	svc := NewCoinRequest(newUnitOfWork(t), repo, nil, nil, time.Hour)
+
+	req := domain.CoinRequest{
+		ID:        1,
+		Requester: 1,
+		Payer:     2,
+		Amount:    100,
+		Status:    domain.CoinRequestPending,
+	}
+	ctx := context.Background()
+
+	repo.On("GetByID", inUnitOfWork, req.ID).Return(req, nil)
+	repo.On("Resolve", inUnitOfWork, req.ID, domain.CoinRequestAccepted).
+		Return(domain.ErrCoinRequestNotActive)
+
+	err := svc.Accept(ctx, req.Payer, req.ID)
+
+	require.ErrorIs(t, err, domain.ErrCoinRequestNotActive)
+	repo.AssertExpectations(t)
+}
+
+func TestDeclineCoinRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewCoinRequest(t)
+	svc := NewCoinRequest(nil, repo, nil, nil, time.Hour)
+
+	req := domain.CoinRequest{
+		ID:        1,
+		Requester: 1,
+		Payer:     2,
+		Amount:    100,
+		Status:    domain.CoinRequestPending,
+	}
+	ctx := context.Background()
+
+	repo.On("GetByID", mock.Anything, req.ID).Return(req, nil)
+	repo.On("Resolve", mock.Anything, req.ID, domain.CoinRequestDeclined).Return(nil)
+
+	err := svc.Decline(ctx, req.Payer, req.ID)
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestListIncomingCoinRequests_DBError(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewCoinRequest(t)
+	svc := NewCoinRequest(nil, repo, nil, nil, time.Hour)
+
+	uid := 2
+	ctx := context.Background()
+
+	repo.On("ListByPayer", mock.Anything, uid).
+		Return(nil, errors.New("unexpected DBError"))
+
+	_, err := svc.ListIncoming(ctx, uid)
+
+	require.Error(t, err)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/infra/mail"
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	netmail "net/mail"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/email
+var emailTemplatesFS embed.FS
+
+// Every emailed notification kind defines "<kind>.subject" and "<kind>.text" as text templates
+// and "<kind>.html" as an HTML template.
+var (
+	emailTextTemplates = texttemplate.Must(texttemplate.ParseFS(emailTemplatesFS, "templates/email/*.txt.tmpl"))
+	emailHTMLTemplates = htmltemplate.Must(htmltemplate.ParseFS(emailTemplatesFS, "templates/email/*.html.tmpl"))
+)
+
+type Email struct {
+	repo   repository.Email
+	sender mail.Sender
+	// addressDomain is the corporate domain the addresses must be in, so the emails
+	// can't be pointed at anyone outside the company
+	addressDomain string
+	batchSize     int
+	lease         time.Duration
+	maxAttempts   int
+	maxBackoff    time.Duration
+}
+
+func NewEmail(
+	repo repository.Email,
+	sender mail.Sender,
+	addressDomain string,
+	batchSize int,
+	lease time.Duration,
+	maxAttempts int,
+	maxBackoff time.Duration,
+) usecases.Email {
+	return &Email{
+		repo:          repo,
+		sender:        sender,
+		addressDomain: addressDomain,
+		batchSize:     batchSize,
+		lease:         lease,
+		maxAttempts:   maxAttempts,
+		maxBackoff:    maxBackoff,
+	}
+}
+
+func (s *Email) GetPreferences(ctx context.Context, uid domain.UserID) (domain.EmailPreferences, error) {
+	ctx, span := tracer.Start(ctx, "EmailService.GetPreferences")
+	defer span.End()
+
+	prefs, err := s.repo.GetPreferences(ctx, uid)
+	if err != nil {
+		return domain.EmailPreferences{}, fmt.Errorf("EmailService.GetPreferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+func (s *Email) SetPreferences(ctx context.Context, prefs domain.EmailPreferences) error {
+	ctx, span := tracer.Start(ctx, "EmailService.SetPreferences")
+	defer span.End()
+
+	if prefs.Address != "" {
+		addr, err := netmail.ParseAddress(prefs.Address)
+		if err != nil || addr.Address != prefs.Address {
+			return fmt.Errorf("EmailService.SetPreferences: invalid address %q: %w", prefs.Address, domain.ErrBadRequest)
+		}
+
+		if !s.allowed(prefs.Address) {
+			return fmt.Errorf("EmailService.SetPreferences: address %q is outside of %s: %w",
+				prefs.Address, s.addressDomain, domain.ErrBadRequest)
+		}
+	}
+
+	err := s.repo.SetPreferences(ctx, prefs)
+	if err != nil {
+		return fmt.Errorf("EmailService.SetPreferences: %w", err)
+	}
+
+	return nil
+}
+
+// SendDue sends one batch of queued emails. An email that can't be rendered or is addressed
+// outside of the corporate domain (e.g. set before the domain changed) fails at once,
+// one the SMTP server didn't take is retried with an exponential backoff.
+func (s *Email) SendDue(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "EmailService.SendDue")
+	defer span.End()
+
+	due, err := s.repo.ClaimDue(ctx, s.batchSize, s.lease)
+	if err != nil {
+		return 0, fmt.Errorf("EmailService.SendDue: %w", err)
+	}
+
+	for i, e := range due {
+		err = s.repo.Record(ctx, s.send(ctx, e))
+		if err != nil {
+			return i, fmt.Errorf("EmailService.SendDue: %w", err)
+		}
+	}
+
+	return len(due), nil
+}
+
+func (s *Email) send(ctx context.Context, e domain.Email) domain.Email {
+	e.Attempts++
+
+	if !s.allowed(e.Address) {
+		e.Status = domain.EmailFailed
+		e.LastError = fmt.Sprintf("address is outside of %s", s.addressDomain)
+		return e
+	}
+
+	msg, err := renderEmail(e.Event)
+	if err != nil {
+		e.Status = domain.EmailFailed
+		e.LastError = err.Error()
+		return e
+	}
+	msg.To = e.Address
+
+	err = s.sender.Send(ctx, msg)
+	switch {
+	case err == nil:
+		e.Status = domain.EmailSent
+		e.LastError = ""
+	case e.Attempts >= s.maxAttempts:
+		e.Status = domain.EmailFailed
+		e.LastError = err.Error()
+	default:
+		e.Status = domain.EmailPending
+		e.LastError = err.Error()
+		e.NextAttemptAt = time.Now().Add(retryDelay(e.Attempts-1, s.maxBackoff))
+	}
+
+	return e
+}
+
+func (s *Email) allowed(address string) bool {
+	at := strings.LastIndexByte(address, '@')
+
+	return at >= 0 && strings.EqualFold(address[at+1:], s.addressDomain)
+}
+
+func renderEmail(e domain.StreamEvent) (mail.Message, error) {
+	var data any
+
+	switch e.Name {
+	case domain.StreamCoinsReceived:
+		data = &domain.CoinsSent{}
+	case domain.StreamMerchPurchased:
+		data = &domain.MerchPurchased{}
+	default:
+		return mail.Message{}, fmt.Errorf("renderEmail: no template for %q", e.Name)
+	}
+
+	if err := json.Unmarshal(e.Data, data); err != nil {
+		return mail.Message{}, fmt.Errorf("renderEmail: %w", err)
+	}
+
+	var subject, text, html bytes.Buffer
+
+	err := emailTextTemplates.ExecuteTemplate(&subject, e.Name+".subject", data)
+	if err == nil {
+		err = emailTextTemplates.ExecuteTemplate(&text, e.Name+".text", data)
+	}
+	if err == nil {
+		err = emailHTMLTemplates.ExecuteTemplate(&html, e.Name+".html", data)
+	}
+	if err != nil {
+		return mail.Message{}, fmt.Errorf("renderEmail: %w", err)
+	}
+
+	return mail.Message{
+		Subject: subject.String(),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository/mocks"
+	"avito_shop/pkg/infra/mail"
+	"avito_shop/pkg/testutils"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	netmail "net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSMTPSender(t *testing.T) (*testutils.SMTPServer, mail.Sender) {
+	srv := testutils.NewSMTPServer(t)
+
+	return srv, mail.NewSMTPSender(mail.Config{
+		Host:    srv.Host(),
+		Port:    srv.Port(),
+		From:    "shop@avito.local",
+		Timeout: time.Second,
+	})
+}
+
+// parseTestEmail returns the decoded subject and the decoded parts by content type.
+func parseTestEmail(t *testing.T, raw string) (string, map[string]string) {
+	msg, err := netmail.ReadMessage(strings.NewReader(raw))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, partErr := mr.NextPart()
+		if partErr == io.EOF {
+			break
+		}
+		require.NoError(t, partErr)
+
+		body, readErr := io.ReadAll(part)
+		require.NoError(t, readErr)
+
+		mediaType, _, typeErr := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		require.NoError(t, typeErr)
+		parts[mediaType] = string(body)
+	}
+
+	return subject, parts
+}
+
+func TestEmail_SendDueSendsRendered(t *testing.T) {
+	t.Parallel()
+
+	srv, sender := newTestSMTPSender(t)
+	repo := mocks.NewEmail(t)
+	svc := NewEmail(repo, sender, "example.com", 10, time.Minute, 3, time.Hour)
+
+	queued := domain.Email{
+		ID:      1,
+		User:    2,
+		Address: "bob@example.com",
+		Event: domain.StreamEvent{
+			ID:   42,
+			Name: domain.StreamCoinsReceived,
+			Data: json.RawMessage(`{"from":1,"fromName":"alice","to":2,"toName":"bob","amount":50}`),
+		},
+		Status: domain.EmailPending,
+	}
+
+	repo.On("ClaimDue", mock.Anything, 10, time.Minute).Return([]domain.Email{queued}, nil)
+	repo.On("Record", mock.Anything, mock.MatchedBy(func(e domain.Email) bool {
+		return e.ID == 1 && e.Status == domain.EmailSent && e.Attempts == 1 && e.LastError == ""
+	})).Return(nil)
+
+	attempted, err := svc.SendDue(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 1, attempted)
+
+	msgs := srv.Messages()
+	require.Len(t, msgs, 1)
+	require.Equal(t, "shop@avito.local", msgs[0].From)
+	require.Equal(t, []string{"bob@example.com"}, msgs[0].To)
+
+	subject, parts := parseTestEmail(t, msgs[0].Data)
+	require.Equal(t, "Вам перевели 50 монет", subject)
+	require.Contains(t, parts["text/plain"], "alice перевёл(а) вам 50 монет")
+	require.Contains(t, parts["text/html"], "<b>alice</b>")
+	repo.AssertExpectations(t)
+}
+
+func TestEmail_SendDueEscapesHTML(t *testing.T) {
+	t.Parallel()
+
+	msg, err := renderEmail(domain.StreamEvent{
+		Name: domain.StreamMerchPurchased,
+		Data: json.RawMessage(`{"userName":"<script>","item":"cup","price":20}`),
+	})
+
+	require.NoError(t, err)
+	require.Contains(t, msg.Text, "<script>")
+	require.NotContains(t, msg.HTML, "<script>")
+	require.Contains(t, msg.HTML, "&lt;script&gt;")
+}
+
+func TestEmail_SendDueRetries(t *testing.T) {
+	t.Parallel()
+
+	srv, sender := newTestSMTPSender(t)
+	srv.SetFail(true)
+
+	tests := []struct {
+		Name      string
+		Attempts  int
+		ExpStatus domain.EmailStatus
+	}{
+		{"Retried later", 1, domain.EmailPending},
+		{"Out of attempts", 2, domain.EmailFailed},
+	}
+
+	for _, test := range tests {
+		repo := mocks.NewEmail(t)
+		svc := NewEmail(repo, sender, "example.com", 10, time.Minute, 3, time.Hour)
+
+		queued := domain.Email{
+			ID:       1,
+			Address:  "carol@example.com",
+			Event:    domain.StreamEvent{Name: domain.StreamMerchPurchased, Data: json.RawMessage(`{"item":"cup"}`)},
+			Status:   domain.EmailPending,
+			Attempts: test.Attempts,
+		}
+		before := time.Now()
+
+		repo.On("ClaimDue", mock.Anything, 10, time.Minute).Return([]domain.Email{queued}, nil)
+		repo.On("Record", mock.Anything, mock.MatchedBy(func(e domain.Email) bool {
+			return e.Status == test.ExpStatus &&
+				e.Attempts == test.Attempts+1 &&
+				e.LastError != "" &&
+				(e.Status != domain.EmailPending || e.NextAttemptAt.After(before))
+		})).Return(nil)
+
+		_, err := svc.SendDue(context.Background())
+
+		require.NoError(t, err, test.Name)
+		repo.AssertExpectations(t)
+	}
+
+	require.Empty(t, srv.Messages())
+}
+
+func TestEmail_SendDueWithoutTemplateFails(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewEmail(t)
+	svc := NewEmail(repo, nil, "example.com", 10, time.Minute, 3, time.Hour)
+
+	queued := domain.Email{ID: 1, Address: "bob@example.com", Event: domain.StreamEvent{Name: domain.StreamCoinsSent}}
+
+	repo.On("ClaimDue", mock.Anything, 10, time.Minute).Return([]domain.Email{queued}, nil)
+	repo.On("Record", mock.Anything, mock.MatchedBy(func(e domain.Email) bool {
+		return e.Status == domain.EmailFailed && e.Attempts == 1
+	})).Return(nil)
+
+	_, err := svc.SendDue(context.Background())
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestEmail_SendDueOutsideDomainFails(t *testing.T) {
+	t.Parallel()
+
+	srv, sender := newTestSMTPSender(t)
+	repo := mocks.NewEmail(t)
+	svc := NewEmail(repo, sender, "example.com", 10, time.Minute, 3, time.Hour)
+
+	data, err := json.Marshal(domain.CoinsSent{FromName: "alice", ToName: "bob", Amount: 10})
+	require.NoError(t, err)
+
+	queued := domain.Email{
+		ID:      1,
+		Address: "bob@gmail.com",
+		Event:   domain.StreamEvent{Name: domain.StreamCoinsReceived, Data: data},
+	}
+
+	repo.On("ClaimDue", mock.Anything, 10, time.Minute).Return([]domain.Email{queued}, nil)
+	repo.On("Record", mock.Anything, mock.MatchedBy(func(e domain.Email) bool {
+		return e.Status == domain.EmailFailed && e.Attempts == 1
+	})).Return(nil)
+
+	_, err = svc.SendDue(context.Background())
+
+	require.NoError(t, err)
+	require.Empty(t, srv.Messages())
+	repo.AssertExpectations(t)
+}
+
+func TestEmail_SetPreferences(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewEmail(t)
+	svc := NewEmail(repo, nil, "example.com", 10, time.Minute, 3, time.Hour)
+
+	for _, address := range []string{"not-an-email", "Bob <bob@example.com>", "bob@gmail.com", "bob@example.com.evil"} {
+		err := svc.SetPreferences(context.Background(), domain.EmailPreferences{User: 2, Address: address})
+
+		require.ErrorIs(t, err, domain.ErrBadRequest, address)
+	}
+
+	for _, prefs := range []domain.EmailPreferences{
+		{User: 2, Address: "bob@example.com", CoinsReceived: true},
+		{User: 2, Address: "Bob@EXAMPLE.com", MerchPurchased: true},
+		{User: 2},
+	} {
+		repo.On("SetPreferences", mock.Anything, prefs).Return(nil).Once()
+
+		err := svc.SetPreferences(context.Background(), prefs)
+
+		require.NoError(t, err)
+	}
+
+	repo.AssertExpectations(t)
+}
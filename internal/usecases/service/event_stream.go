@@ -0,0 +1,159 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/infra/events"
+	pkglog "avito_shop/pkg/log"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// maxListenBackoff caps the wait before resubscribing to the broker.
+const maxListenBackoff = 30 * time.Second
+
+type subscriber chan domain.StreamEvent
+
+// EventStream routes the events relayed from the outbox to the users they concern.
+// Every replica listens to the broker and serves only its own subscribers.
+type EventStream struct {
+	log        *slog.Logger
+	listener   events.Listener
+	bufferSize int
+	// backoff is how long to wait before the next subscription after attempt failed ones
+	backoff func(attempt int) time.Duration
+
+	mu     sync.Mutex
+	subs   map[domain.UserID]map[subscriber]struct{}
+	closed bool
+}
+
+func NewEventStream(log *slog.Logger, listener events.Listener, bufferSize int) usecases.EventStream {
+	return newEventStream(log, listener, bufferSize)
+}
+
+func newEventStream(log *slog.Logger, listener events.Listener, bufferSize int) *EventStream {
+	return &EventStream{
+		log:        log,
+		listener:   listener,
+		bufferSize: bufferSize,
+		backoff: func(attempt int) time.Duration {
+			return retryDelay(attempt, maxListenBackoff)
+		},
+		subs: make(map[domain.UserID]map[subscriber]struct{}),
+	}
+}
+
+func (s *EventStream) Subscribe(ctx context.Context, uid domain.UserID) <-chan domain.StreamEvent {
+	sub := make(subscriber, s.bufferSize)
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		close(sub)
+		return sub
+	}
+	if s.subs[uid] == nil {
+		s.subs[uid] = make(map[subscriber]struct{})
+	}
+	s.subs[uid][sub] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		s.unsubscribe(uid, sub)
+		s.mu.Unlock()
+	}()
+
+	return sub
+}
+
+// Listen resubscribes whenever the broker drops the subscription, a broker outage only pauses
+// the streams. It ends every subscription once ctx is done, no more events would arrive on them,
+// and the open streams would otherwise hold up the shutdown of the HTTP server.
+func (s *EventStream) Listen(ctx context.Context) error {
+	defer s.close()
+
+	for attempt := 0; ; attempt++ {
+		started := time.Now()
+		err := s.listener.Listen(ctx, s.dispatch)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil {
+			err = errors.New("the broker closed the subscription")
+		}
+
+		// a subscription that held for a while starts the backoff over
+		if time.Since(started) > maxListenBackoff {
+			attempt = 0
+		}
+
+		delay := s.backoff(attempt)
+		s.log.Error("event subscription lost, resubscribing",
+			pkglog.Err(err), slog.String("retry_in", delay.String()))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (s *EventStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for uid, subs := range s.subs {
+		for sub := range subs {
+			s.unsubscribe(uid, sub)
+		}
+	}
+}
+
+// dispatch never blocks the listener: a subscriber with a full buffer is dropped instead,
+// its stream ends and the client reconnects and reloads the state it missed.
+func (s *EventStream) dispatch(msg events.Message) {
+	targets, err := domain.StreamEventsOf(domain.Event{
+		ID:        msg.ID,
+		Type:      msg.Type,
+		Payload:   msg.Payload,
+		CreatedAt: msg.CreatedAt,
+	})
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for uid, e := range targets {
+		for sub := range s.subs[uid] {
+			select {
+			case sub <- e:
+			default:
+				s.unsubscribe(uid, sub)
+			}
+		}
+	}
+}
+
+// unsubscribe must be called with mu held.
+func (s *EventStream) unsubscribe(uid domain.UserID, sub subscriber) {
+	if _, ok := s.subs[uid][sub]; !ok {
+		return
+	}
+
+	delete(s.subs[uid], sub)
+	if len(s.subs[uid]) == 0 {
+		delete(s.subs, uid)
+	}
+	close(sub)
+}
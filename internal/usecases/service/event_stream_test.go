@@ -0,0 +1,155 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/pkg/infra/events"
+	"avito_shop/pkg/testutils"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// chanListener hands over the messages sent to it until ctx is cancelled.
+type chanListener chan events.Message
+
+func (l chanListener) Listen(ctx context.Context, handle func(events.Message)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-l:
+			handle(msg)
+		}
+	}
+}
+
+// flakyListener fails the first subscriptions, then hands over messages like chanListener.
+type flakyListener struct {
+	chanListener
+	failures atomic.Int32
+}
+
+func (l *flakyListener) Listen(ctx context.Context, handle func(events.Message)) error {
+	if l.failures.Add(-1) >= 0 {
+		return errors.New("connection refused")
+	}
+
+	return l.chanListener.Listen(ctx, handle)
+}
+
+func TestEventStream_RoutesToInvolvedUsers(t *testing.T) {
+	t.Parallel()
+
+	svc := newEventStream(testutils.NewDummyLogger(), nil, 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sender := svc.Subscribe(ctx, 1)
+	recipient := svc.Subscribe(ctx, 2)
+	other := svc.Subscribe(ctx, 3)
+
+	payload := json.RawMessage(`{"transactionId":10,"from":1,"fromName":"alice","to":2,"toName":"bob","amount":50}`)
+	svc.dispatch(events.Message{ID: 42, Type: domain.EventCoinsSent, Payload: payload})
+
+	require.Equal(t, domain.StreamEvent{ID: 42, Name: domain.StreamCoinsSent, Data: payload}, <-sender)
+	require.Equal(t, domain.StreamEvent{ID: 42, Name: domain.StreamCoinsReceived, Data: payload}, <-recipient)
+	require.Empty(t, other)
+
+	payload = json.RawMessage(`{"transactionId":11,"user":3,"userName":"carol","item":"cup","price":20}`)
+	svc.dispatch(events.Message{ID: 43, Type: domain.EventMerchPurchased, Payload: payload})
+
+	require.Equal(t, domain.StreamEvent{ID: 43, Name: domain.StreamMerchPurchased, Data: payload}, <-other)
+	require.Empty(t, sender)
+}
+
+func TestEventStream_DropsSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	svc := newEventStream(testutils.NewDummyLogger(), nil, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := svc.Subscribe(ctx, 3)
+
+	msg := events.Message{ID: 1, Type: domain.EventMerchPurchased, Payload: json.RawMessage(`{"user":3}`)}
+	svc.dispatch(msg)
+	svc.dispatch(msg)
+
+	_, ok := <-stream
+	require.True(t, ok)
+	_, ok = <-stream
+	require.False(t, ok)
+}
+
+func TestEventStream_ClosesStreams(t *testing.T) {
+	t.Parallel()
+
+	listener := make(chanListener)
+	svc := newEventStream(testutils.NewDummyLogger(), listener, 4)
+
+	subCtx, unsubscribe := context.WithCancel(context.Background())
+	left := svc.Subscribe(subCtx, 1)
+	unsubscribe()
+
+	_, ok := <-left
+	require.False(t, ok)
+
+	stream := svc.Subscribe(context.Background(), 1)
+
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- svc.Listen(ctx) }()
+
+	listener <- events.Message{ID: 1, Type: domain.EventMerchPurchased, Payload: json.RawMessage(`{"user":1}`)}
+	e := <-stream
+	require.Equal(t, domain.StreamMerchPurchased, e.Name)
+
+	stop()
+	require.NoError(t, <-done)
+
+	_, ok = <-stream
+	require.False(t, ok)
+
+	_, ok = <-svc.Subscribe(context.Background(), 1)
+	require.False(t, ok)
+}
+
+func TestEventStream_Resubscribes(t *testing.T) {
+	t.Parallel()
+
+	listener := &flakyListener{chanListener: make(chanListener)}
+	listener.failures.Store(3)
+	svc := newEventStream(testutils.NewDummyLogger(), listener, 4)
+
+	var attempts []int
+	svc.backoff = func(attempt int) time.Duration {
+		attempts = append(attempts, attempt)
+		return time.Millisecond
+	}
+
+	stream := svc.Subscribe(context.Background(), 1)
+
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- svc.Listen(ctx) }()
+
+	// the stream outlives the failed subscriptions
+	msg := events.Message{ID: 1, Type: domain.EventMerchPurchased, Payload: json.RawMessage(`{"user":1}`)}
+	listener.chanListener <- msg
+	e := <-stream
+	require.Equal(t, domain.StreamMerchPurchased, e.Name)
+
+	stop()
+	require.NoError(t, <-done)
+	require.Equal(t, []int{0, 1, 2}, attempts)
+
+	_, ok := <-stream
+	require.False(t, ok)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases"
+	"context"
+	"fmt"
+)
+
+const historyMaxPageSize = 100
+
+type History struct {
+	repo repository.History
+}
+
+func NewHistory(repo repository.History) usecases.History {
+	return &History{
+		repo: repo,
+	}
+}
+
+func (s *History) Transactions(
+	ctx context.Context,
+	uid domain.UserID,
+	limit int,
+) ([]domain.TransactionRecord, error) {
+	ctx, span := tracer.Start(ctx, "HistoryService.Transactions")
+	defer span.End()
+
+	if limit <= 0 || limit > historyMaxPageSize {
+		return nil, fmt.Errorf("HistoryService.Transactions: limit out of range: %w", domain.ErrBadRequest)
+	}
+
+	txs, err := s.repo.ListTransactions(ctx, uid, limit)
+	if err != nil {
+		return nil, fmt.Errorf("HistoryService.Transactions: %w", err)
+	}
+
+	return txs, nil
+}
+
+func (s *History) Orders(ctx context.Context, uid domain.UserID, limit int) ([]domain.Order, error) {
+	ctx, span := tracer.Start(ctx, "HistoryService.Orders")
+	defer span.End()
+
+	if limit <= 0 || limit > historyMaxPageSize {
+		return nil, fmt.Errorf("HistoryService.Orders: limit out of range: %w", domain.ErrBadRequest)
+	}
+
+	orders, err := s.repo.ListOrders(ctx, uid, limit)
+	if err != nil {
+		return nil, fmt.Errorf("HistoryService.Orders: %w", err)
+	}
+
+	return orders, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository/mocks"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory_LimitOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	svc := NewHistory(mocks.NewHistory(t))
+
+	for _, limit := range []int{0, -1, historyMaxPageSize + 1} {
+		_, err := svc.Transactions(context.Background(), 1, limit)
+		require.ErrorIs(t, err, domain.ErrBadRequest)
+
+		_, err = svc.Orders(context.Background(), 1, limit)
+		require.ErrorIs(t, err, domain.ErrBadRequest)
+	}
+}
+
+func TestHistory_Success(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewHistory(t)
+	svc := NewHistory(repo)
+
+	txs := []domain.TransactionRecord{{Transaction: domain.Transaction{ID: 1, From: 1, To: 2, Amount: 10}}}
+	orders := []domain.Order{{ID: 1, User: 1, Item: "cup", Price: 20}}
+
+	repo.On("ListTransactions", mock.Anything, 1, historyMaxPageSize).Return(txs, nil)
+	repo.On("ListOrders", mock.Anything, 1, 5).Return(orders, nil)
+
+	gotTxs, err := svc.Transactions(context.Background(), 1, historyMaxPageSize)
+	require.NoError(t, err)
+	require.Equal(t, txs, gotTxs)
+
+	gotOrders, err := svc.Orders(context.Background(), 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, orders, gotOrders)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases"
+	"context"
+	"fmt"
+)
+
+type Ledger struct {
+	repo repository.Ledger
+}
+
+func NewLedger(repo repository.Ledger) usecases.Ledger {
+	return &Ledger{
+		repo: repo,
+	}
+}
+
+func (s *Ledger) Verify(ctx context.Context) (domain.LedgerVerification, error) {
+	ctx, span := tracer.Start(ctx, "LedgerService.Verify")
+	defer span.End()
+
+	res, err := s.repo.Verify(ctx)
+	if err != nil {
+		return domain.LedgerVerification{}, fmt.Errorf("LedgerService.Verify: %w", err)
+	}
+
+	return res, nil
+}
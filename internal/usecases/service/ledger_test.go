@@ -0,0 +1,44 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger_Verify(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewLedger(t)
+	svc := NewLedger(repo)
+
+	expRes := domain.LedgerVerification{Postings: 4}
+	repo.On("Verify", mock.Anything).Return(expRes, nil)
+
+	res, err := svc.Verify(context.Background())
+
+	require.NoError(t, err)
+	require.True(t, res.OK())
+	require.Equal(t, expRes, res)
+	repo.AssertExpectations(t)
+}
+
+func TestLedger_VerifyError(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewLedger(t)
+	svc := NewLedger(repo)
+
+	dbErr := errors.New("unexpected DBError")
+	repo.On("Verify", mock.Anything).Return(domain.LedgerVerification{}, dbErr)
+
+	_, err := svc.Verify(context.Background())
+
+	require.ErrorIs(t, err, dbErr)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases"
+	"context"
+	"fmt"
+)
+
+type Merch struct {
+	repo repository.Merch
+}
+
+func NewMerch(repo repository.Merch) usecases.Merch {
+	return &Merch{
+		repo: repo,
+	}
+}
+
+func (s *Merch) List(ctx context.Context) ([]domain.Merch, error) {
+	ctx, span := tracer.Start(ctx, "MerchService.List")
+	defer span.End()
+
+	items, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("MerchService.List: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *Merch) GetByNames(ctx context.Context, names []domain.MerchName) ([]domain.Merch, error) {
+	ctx, span := tracer.Start(ctx, "MerchService.GetByNames")
+	defer span.End()
+
+	items, err := s.repo.GetByNames(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("MerchService.GetByNames: %w", err)
+	}
+
+	return items, nil
+}
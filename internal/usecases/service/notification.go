@@ -0,0 +1,57 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases"
+	"context"
+	"fmt"
+)
+
+const notificationsPageSize = 100
+
+type Notification struct {
+	repo repository.Notification
+}
+
+func NewNotification(repo repository.Notification) usecases.Notification {
+	return &Notification{
+		repo: repo,
+	}
+}
+
+func (s *Notification) ListUnread(
+	ctx context.Context,
+	uid domain.UserID,
+	after domain.EventID,
+) ([]domain.StreamEvent, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.ListUnread")
+	defer span.End()
+
+	notifications, err := s.repo.ListUnread(ctx, uid, after, notificationsPageSize)
+	if err != nil {
+		return nil, fmt.Errorf("NotificationService.ListUnread: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (s *Notification) Ack(ctx context.Context, uid domain.UserID, ids []domain.EventID) (int, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.Ack")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if len(ids) > notificationsPageSize {
+		return 0, fmt.Errorf("NotificationService.Ack: too many ids: %w", domain.ErrBadRequest)
+	}
+
+	acked, err := s.repo.MarkRead(ctx, uid, ids)
+	if err != nil {
+		return 0, fmt.Errorf("NotificationService.Ack: %w", err)
+	}
+
+	return acked, nil
+}
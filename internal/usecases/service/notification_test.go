@@ -0,0 +1,78 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository/mocks"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotification_ListUnread(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewNotification(t)
+	svc := NewNotification(repo)
+
+	unread := []domain.StreamEvent{
+		{ID: 42, Name: domain.StreamCoinsReceived, Data: json.RawMessage(`{"amount":50}`)},
+	}
+
+	repo.On("ListUnread", mock.Anything, 2, int64(41), notificationsPageSize).Return(unread, nil)
+
+	res, err := svc.ListUnread(context.Background(), 2, 41)
+
+	require.NoError(t, err)
+	require.Equal(t, unread, res)
+	repo.AssertExpectations(t)
+}
+
+func TestNotification_Ack(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewNotification(t)
+	svc := NewNotification(repo)
+
+	repo.On("MarkRead", mock.Anything, 2, []domain.EventID{41, 42}).Return(1, nil)
+
+	acked, err := svc.Ack(context.Background(), 2, []domain.EventID{41, 42})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, acked)
+	repo.AssertExpectations(t)
+}
+
+func TestNotification_AckInvalid(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewNotification(t)
+	svc := NewNotification(repo)
+
+	acked, err := svc.Ack(context.Background(), 2, nil)
+
+	require.NoError(t, err)
+	require.Zero(t, acked)
+
+	_, err = svc.Ack(context.Background(), 2, make([]domain.EventID, notificationsPageSize+1))
+
+	require.ErrorIs(t, err, domain.ErrBadRequest)
+	repo.AssertNotCalled(t, "MarkRead", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNotification_AckDBError(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewNotification(t)
+	svc := NewNotification(repo)
+
+	repo.On("MarkRead", mock.Anything, 2, []domain.EventID{42}).Return(0, errors.New("unexpected DBError"))
+
+	_, err := svc.Ack(context.Background(), 2, []domain.EventID{42})
+
+	require.Error(t, err)
+	repo.AssertExpectations(t)
+}
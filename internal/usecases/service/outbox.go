@@ -0,0 +1,87 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/infra/events"
+	"context"
+	"fmt"
+	"time"
+)
+
+const baseRetryDelay = time.Second
+
+type Outbox struct {
+	repo       repository.Outbox
+	sink       events.Sink
+	batchSize  int
+	lease      time.Duration
+	maxBackoff time.Duration
+}
+
+func NewOutbox(
+	repo repository.Outbox,
+	sink events.Sink,
+	batchSize int,
+	lease time.Duration,
+	maxBackoff time.Duration,
+) usecases.Outbox {
+	return &Outbox{
+		repo:       repo,
+		sink:       sink,
+		batchSize:  batchSize,
+		lease:      lease,
+		maxBackoff: maxBackoff,
+	}
+}
+
+// Relay publishes one batch of pending events. An event is marked published only after the sink
+// accepted it, so a crash in between delivers it again: delivery is at-least-once.
+// A failed event is retried with an exponential backoff and doesn't hold back the rest of the batch.
+func (s *Outbox) Relay(ctx context.Context) (domain.OutboxRelay, error) {
+	ctx, span := tracer.Start(ctx, "OutboxService.Relay")
+	defer span.End()
+
+	var res domain.OutboxRelay
+
+	pending, err := s.repo.ClaimPending(ctx, s.batchSize, s.lease)
+	if err != nil {
+		return res, fmt.Errorf("OutboxService.Relay: %w", err)
+	}
+
+	for _, e := range pending {
+		err = s.sink.Publish(ctx, events.Message{
+			ID:        e.ID,
+			Type:      e.Type,
+			CreatedAt: e.CreatedAt,
+			Payload:   e.Payload,
+		})
+		if err != nil {
+			res.Failed++
+			err = s.repo.MarkFailed(ctx, e.ID, err.Error(), time.Now().Add(retryDelay(e.Attempts, s.maxBackoff)))
+		} else {
+			res.Published++
+			err = s.repo.MarkPublished(ctx, e.ID)
+		}
+
+		if err != nil {
+			return res, fmt.Errorf("OutboxService.Relay: %w", err)
+		}
+	}
+
+	return res, nil
+}
+
+// retryDelay doubles with every failed attempt up to maxBackoff.
+func retryDelay(attempts int, maxBackoff time.Duration) time.Duration {
+	delay := baseRetryDelay
+	for range attempts {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return min(delay, maxBackoff)
+}
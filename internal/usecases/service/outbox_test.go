@@ -0,0 +1,100 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository/mocks"
+	"avito_shop/pkg/infra/events"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type failingSink struct {
+	err error
+}
+
+func (s failingSink) Publish(context.Context, events.Message) error {
+	return s.err
+}
+
+func TestOutbox_RelayPublishes(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewOutbox(t)
+	sink := events.NewMemorySink()
+	svc := NewOutbox(repo, sink, 10, time.Minute, time.Hour)
+
+	pending := []domain.Event{
+		{ID: 1, Type: domain.EventCoinsSent, Payload: json.RawMessage(`{"amount":100}`)},
+		{ID: 2, Type: domain.EventMerchPurchased, Payload: json.RawMessage(`{"item":"cup"}`)},
+	}
+
+	repo.On("ClaimPending", mock.Anything, 10, time.Minute).Return(pending, nil)
+	repo.On("MarkPublished", mock.Anything, int64(1)).Return(nil)
+	repo.On("MarkPublished", mock.Anything, int64(2)).Return(nil)
+
+	res, err := svc.Relay(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, domain.OutboxRelay{Published: 2}, res)
+
+	msgs := sink.Messages()
+	require.Len(t, msgs, 2)
+	require.Equal(t, domain.EventCoinsSent, msgs[0].Type)
+	require.JSONEq(t, `{"item":"cup"}`, string(msgs[1].Payload))
+	repo.AssertExpectations(t)
+}
+
+func TestOutbox_RelaySinkFailure(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewOutbox(t)
+	svc := NewOutbox(repo, failingSink{err: errors.New("connection refused")}, 10, time.Minute, time.Hour)
+
+	pending := []domain.Event{{ID: 1, Type: domain.EventCoinsSent, Attempts: 3}}
+	before := time.Now()
+
+	repo.On("ClaimPending", mock.Anything, 10, time.Minute).Return(pending, nil)
+	repo.On("MarkFailed", mock.Anything, int64(1), "connection refused",
+		mock.MatchedBy(func(retryAt time.Time) bool {
+			// 1s doubled for each of the 3 previous attempts
+			return !retryAt.Before(before.Add(8 * time.Second))
+		})).Return(nil)
+
+	res, err := svc.Relay(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, domain.OutboxRelay{Failed: 1}, res)
+	repo.AssertExpectations(t)
+}
+
+func TestOutbox_RelayClaimError(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewOutbox(t)
+	svc := NewOutbox(repo, events.NewMemorySink(), 10, time.Minute, time.Hour)
+
+	dbErr := errors.New("unexpected DBError")
+	repo.On("ClaimPending", mock.Anything, 10, time.Minute).Return(nil, dbErr)
+
+	_, err := svc.Relay(context.Background())
+
+	require.ErrorIs(t, err, dbErr)
+	repo.AssertExpectations(t)
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	maxBackoff := 10 * time.Second
+
+	require.Equal(t, time.Second, retryDelay(0, maxBackoff))
+	require.Equal(t, 4*time.Second, retryDelay(2, maxBackoff))
+	require.Equal(t, 10*time.Second, retryDelay(4, maxBackoff))
+	require.Equal(t, 10*time.Second, retryDelay(1000, maxBackoff))
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases"
+	"context"
+	"fmt"
+)
+
+type Reconciliation struct {
+	repo repository.Reconciliation
+}
+
+func NewReconciliation(repo repository.Reconciliation) usecases.Reconciliation {
+	return &Reconciliation{
+		repo: repo,
+	}
+}
+
+// Run only reports discrepancies, corrections are written by Apply once an admin has reviewed the report.
+func (s *Reconciliation) Run(ctx context.Context) (domain.Reconciliation, error) {
+	ctx, span := tracer.Start(ctx, "ReconciliationService.Run")
+	defer span.End()
+
+	rec, err := s.repo.Run(ctx)
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationService.Run: %w", err)
+	}
+
+	return rec, nil
+}
+
+func (s *Reconciliation) GetLatest(ctx context.Context) (domain.Reconciliation, error) {
+	ctx, span := tracer.Start(ctx, "ReconciliationService.GetLatest")
+	defer span.End()
+
+	rec, err := s.repo.GetLatest(ctx)
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationService.GetLatest: %w", err)
+	}
+
+	return rec, nil
+}
+
+func (s *Reconciliation) Apply(
+	ctx context.Context,
+	id domain.ReconciliationID,
+	adminID domain.UserID,
+) (domain.Reconciliation, error) {
+	ctx, span := tracer.Start(ctx, "ReconciliationService.Apply")
+	defer span.End()
+
+	rec, err := s.repo.Apply(ctx, id, adminID)
+	if err != nil {
+		return domain.Reconciliation{}, fmt.Errorf("ReconciliationService.Apply: %w", err)
+	}
+
+	return rec, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository/mocks"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciliation_Run(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewReconciliation(t)
+	svc := NewReconciliation(repo)
+
+	expRec := domain.Reconciliation{
+		ID:            1,
+		Checked:       3,
+		Discrepancies: []domain.BalanceDiscrepancy{{Account: 3, Stored: 900, Expected: 1000}},
+	}
+	repo.On("Run", mock.Anything).Return(expRec, nil)
+
+	rec, err := svc.Run(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, expRec, rec)
+	require.False(t, rec.Applied())
+	require.Equal(t, 100, rec.Discrepancies[0].Diff())
+	repo.AssertExpectations(t)
+}
+
+func TestReconciliation_ApplyAlreadyApplied(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewReconciliation(t)
+	svc := NewReconciliation(repo)
+
+	repo.On("Apply", mock.Anything, 1, 2).Return(domain.Reconciliation{}, domain.ErrReconciliationAlreadyApplied)
+
+	_, err := svc.Apply(context.Background(), 1, 2)
+
+	require.ErrorIs(t, err, domain.ErrReconciliationAlreadyApplied)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases"
+	pkgerr "avito_shop/pkg/pkgerror"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type ScheduledTransfer struct {
+	uow       repository.UnitOfWork
+	repo      repository.ScheduledTransfer
+	txRepo    repository.Transaction
+	userRepo  repository.User
+	batchSize int
+	lease     time.Duration
+}
+
+func NewScheduledTransfer(
+	uow repository.UnitOfWork,
+	repo repository.ScheduledTransfer,
+	txRepo repository.Transaction,
+	userRepo repository.User,
+	batchSize int,
+	lease time.Duration,
+) usecases.ScheduledTransfer {
+	return &ScheduledTransfer{
+		uow:       uow,
+		repo:      repo,
+		txRepo:    txRepo,
+		userRepo:  userRepo,
+		batchSize: batchSize,
+		lease:     lease,
+	}
+}
+
+func (s *ScheduledTransfer) CreateByName(
+	ctx context.Context,
+	st domain.ScheduledTransfer,
+	to domain.UserName,
+) (domain.ScheduledTransfer, error) {
+	ctx, span := tracer.Start(ctx, "ScheduledTransferService.CreateByName")
+	defer span.End()
+
+	toUser, err := s.userRepo.GetByName(ctx, to)
+	if err != nil {
+		return domain.ScheduledTransfer{}, fmt.Errorf("ScheduledTransferService.CreateByName: %w", err)
+	}
+
+	if isServiceAccount(toUser.ID) {
+		return domain.ScheduledTransfer{}, fmt.Errorf("ScheduledTransferService.CreateByName: %w", domain.ErrUserNotFound)
+	}
+
+	if toUser.ID == st.From {
+		return domain.ScheduledTransfer{}, fmt.Errorf(
+			"ScheduledTransferService.CreateByName: can't allow selfsending: %w", domain.ErrSelfSending,
+		)
+	}
+	st.To = toUser.ID
+
+	if st.NextRunAt.IsZero() {
+		st.NextRunAt = time.Now()
+	}
+
+	created, err := s.repo.Create(ctx, st)
+	if err != nil {
+		return domain.ScheduledTransfer{}, fmt.Errorf("ScheduledTransferService.CreateByName: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *ScheduledTransfer) List(ctx context.Context, uid domain.UserID) ([]domain.ScheduledTransfer, error) {
+	ctx, span := tracer.Start(ctx, "ScheduledTransferService.List")
+	defer span.End()
+
+	sts, err := s.repo.ListBySender(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("ScheduledTransferService.List: %w", err)
+	}
+
+	return sts, nil
+}
+
+func (s *ScheduledTransfer) Cancel(ctx context.Context, uid domain.UserID, id domain.ScheduledTransferID) error {
+	ctx, span := tracer.Start(ctx, "ScheduledTransferService.Cancel")
+	defer span.End()
+
+	err := s.repo.Cancel(ctx, uid, id)
+	if err != nil {
+		return fmt.Errorf("ScheduledTransferService.Cancel: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ScheduledTransfer) ListRuns(
+	ctx context.Context,
+	uid domain.UserID,
+	id domain.ScheduledTransferID,
+) ([]domain.ScheduledTransferRun, error) {
+	ctx, span := tracer.Start(ctx, "ScheduledTransferService.ListRuns")
+	defer span.End()
+
+	runs, err := s.repo.ListRuns(ctx, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("ScheduledTransferService.ListRuns: %w", err)
+	}
+
+	return runs, nil
+}
+
+// RunDue executes one batch of due transfers and returns how many of them were processed.
+// Failed transfers (e.g. low balance) are recorded as failed runs and don't stop the batch.
+// Every transfer is paid and completed in one unit of work, so a paid transfer is never left
+// to be claimed again once its lease expires. An unexpected error rolls the payment back and
+// stops the batch, the transfer is claimed again when its lease expires.
+func (s *ScheduledTransfer) RunDue(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "ScheduledTransferService.RunDue")
+	defer span.End()
+
+	due, err := s.repo.ClaimDue(ctx, s.batchSize, s.lease)
+	if err != nil {
+		return 0, fmt.Errorf("ScheduledTransferService.RunDue: %w", err)
+	}
+
+	processed := 0
+	for _, st := range due {
+		err = s.uow.Do(ctx, func(ctx context.Context) error {
+			run, execErr := s.execute(ctx, st)
+			if execErr != nil {
+				return execErr
+			}
+
+			return s.repo.Complete(ctx, st, advanceSchedule(st, run.RunAt), run)
+		})
+		if errors.Is(err, domain.ErrScheduledTransferNotDue) {
+			// another replica ran it after the lease expired, this payment was rolled back
+			continue
+		}
+		if err != nil {
+			return processed, fmt.Errorf("ScheduledTransferService.RunDue: %w", err)
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// execute pays the transfer. Only the errors of the transfer itself (e.g. low balance) make
+// a failed run, the unexpected ones are returned.
+func (s *ScheduledTransfer) execute(
+	ctx context.Context,
+	st domain.ScheduledTransfer,
+) (domain.ScheduledTransferRun, error) {
+	run := domain.ScheduledTransferRun{
+		TransferID: st.ID,
+		Status:     domain.ScheduledTransferSucceeded,
+	}
+
+	err := s.txRepo.SendCoin(ctx, domain.Transaction{
+		From:   st.From,
+		To:     st.To,
+		Amount: st.Amount,
+	})
+	if err != nil {
+		if _, ok := domain.LookupError(err); !ok {
+			return domain.ScheduledTransferRun{}, err
+		}
+		run.Status = domain.ScheduledTransferFailed
+		run.Reason = pkgerr.UnwrapAll(err).Error()
+	}
+	run.RunAt = time.Now()
+
+	return run, nil
+}
+
+// advanceSchedule moves the transfer to its next slot after now, skipping the slots
+// missed while the service was down. One-off transfers are deactivated.
+func advanceSchedule(st domain.ScheduledTransfer, now time.Time) domain.ScheduledTransfer {
+	if st.Interval <= 0 {
+		st.Active = false
+		return st
+	}
+
+	for !st.NextRunAt.After(now) {
+		st.NextRunAt = st.NextRunAt.Add(st.Interval)
+	}
+
+	return st
+}
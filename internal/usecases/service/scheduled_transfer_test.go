@@ -0,0 +1,248 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/repository/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBatchSize = 10
+	testLease     = time.Minute
+)
+
+func TestCreateScheduledTransferByName_Success(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewScheduledTransfer(t)
+	userRepo := mocks.NewUser(t)
+	svc := NewScheduledTransfer(nil, repo, nil, userRepo, testBatchSize, testLease)
+
+	fromID := 3
+	toID := 4
+	toName := "Avito"
+	st := domain.ScheduledTransfer{From: fromID, Amount: 50, Interval: 7 * 24 * time.Hour}
+	ctx := context.Background()
+
+	userRepo.On("GetByName", mock.Anything, toName).
+		Return(domain.User{ID: toID, Name: toName}, nil)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(st domain.ScheduledTransfer) bool {
+		return st.From == fromID && st.To == toID && !st.NextRunAt.IsZero()
+	})).Return(domain.ScheduledTransfer{ID: 1}, nil)
+
+	created, err := svc.CreateByName(ctx, st, toName)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, created.ID)
+	userRepo.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateScheduledTransferByName_SelfSending(t *testing.T) {
+	t.Parallel()
+
+	userRepo := mocks.NewUser(t)
+	svc := NewScheduledTransfer(nil, nil, nil, userRepo, testBatchSize, testLease)
+
+	fromID := 3
+	toName := "Avito"
+	ctx := context.Background()
+
+	userRepo.On("GetByName", mock.Anything, toName).
+		Return(domain.User{ID: fromID, Name: toName}, nil)
+
+	_, err := svc.CreateByName(ctx, domain.ScheduledTransfer{From: fromID, Amount: 50}, toName)
+
+	require.ErrorIs(t, err, domain.ErrSelfSending)
+	userRepo.AssertExpectations(t)
+}
+
+func TestCreateScheduledTransferByName_ServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	userRepo := mocks.NewUser(t)
+	svc := NewScheduledTransfer(nil, nil, nil, userRepo, testBatchSize, testLease)
+
+	toName := "shop"
+	ctx := context.Background()
+
+	userRepo.On("GetByName", mock.Anything, toName).
+		Return(domain.User{ID: repository.ShopDBID, Name: toName}, nil)
+
+	_, err := svc.CreateByName(ctx, domain.ScheduledTransfer{From: 3, Amount: 50}, toName)
+
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+	userRepo.AssertExpectations(t)
+}
+
+func TestRunDueScheduledTransfers_Success(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewScheduledTransfer(t)
+	txRepo := mocks.NewTransaction(t)
+	svc := NewScheduledTransfer(newUnitOfWork(t), repo, txRepo, nil, testBatchSize, testLease)
+
+	st := domain.ScheduledTransfer{
+		ID:        1,
+		From:      1,
+		To:        2,
+		Amount:    50,
+		Interval:  time.Hour,
+		NextRunAt: time.Now().Add(-time.Minute),
+		Active:    true,
+	}
+	ctx := context.Background()
+
+	repo.On("ClaimDue", mock.Anything, testBatchSize, testLease).
+		Return([]domain.ScheduledTransfer{st}, nil)
+	txRepo.On("SendCoin", inUnitOfWork, domain.Transaction{From: st.From, To: st.To, Amount: st.Amount}).
+		Return(nil)
+	repo.On("Complete",
+		inUnitOfWork,
+		st,
+		mock.MatchedBy(func(next domain.ScheduledTransfer) bool {
+			return next.Active && next.NextRunAt.After(time.Now())
+		}),
+		mock.MatchedBy(func(run domain.ScheduledTransferRun) bool {
+			return run.TransferID == st.ID && run.Status == domain.ScheduledTransferSucceeded
+		})).Return(nil)
+
+	processed, err := svc.RunDue(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, processed)
+	repo.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+}
+
+func TestRunDueScheduledTransfers_LowBalanceRecordsFailedRun(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewScheduledTransfer(t)
+	txRepo := mocks.NewTransaction(t)
+	svc := NewScheduledTransfer(newUnitOfWork(t), repo, txRepo, nil, testBatchSize, testLease)
+
+	st := domain.ScheduledTransfer{
+		ID:        1,
+		From:      1,
+		To:        2,
+		Amount:    5000,
+		NextRunAt: time.Now().Add(-time.Minute),
+		Active:    true,
+	}
+	ctx := context.Background()
+
+	repo.On("ClaimDue", mock.Anything, testBatchSize, testLease).
+		Return([]domain.ScheduledTransfer{st}, nil)
+	txRepo.On("SendCoin", inUnitOfWork, mock.Anything).
+		Return(domain.ErrLowBalance)
+	repo.On("Complete",
+		inUnitOfWork,
+		st,
+		mock.MatchedBy(func(next domain.ScheduledTransfer) bool {
+			return !next.Active
+		}),
+		mock.MatchedBy(func(run domain.ScheduledTransferRun) bool {
+			return run.Status == domain.ScheduledTransferFailed && run.Reason == domain.ErrLowBalance.Error()
+		})).Return(nil)
+
+	processed, err := svc.RunDue(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, processed)
+	repo.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+}
+
+func TestRunDueScheduledTransfers_UnexpectedErrorStopsBatch(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewScheduledTransfer(t)
+	txRepo := mocks.NewTransaction(t)
+	svc := NewScheduledTransfer(newUnitOfWork(t), repo, txRepo, nil, testBatchSize, testLease)
+
+	first := domain.ScheduledTransfer{ID: 1, From: 1, To: 2, Amount: 50, Active: true}
+	second := domain.ScheduledTransfer{ID: 2, From: 1, To: 2, Amount: 50, Active: true}
+	ctx := context.Background()
+
+	repo.On("ClaimDue", mock.Anything, testBatchSize, testLease).
+		Return([]domain.ScheduledTransfer{first, second}, nil)
+	txRepo.On("SendCoin", inUnitOfWork, mock.Anything).
+		Return(errors.New("conn closed")).Once()
+
+	processed, err := svc.RunDue(ctx)
+
+	// the transfer isn't completed, it's claimed again when the lease expires
+	require.Error(t, err)
+	require.Equal(t, 0, processed)
+	repo.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+}
+
+func TestRunDueScheduledTransfers_RunByAnotherReplica(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewScheduledTransfer(t)
+	txRepo := mocks.NewTransaction(t)
+	svc := NewScheduledTransfer(newUnitOfWork(t), repo, txRepo, nil, testBatchSize, testLease)
+
+	stale := domain.ScheduledTransfer{ID: 1, From: 1, To: 2, Amount: 50, Active: true}
+	due := domain.ScheduledTransfer{ID: 2, From: 1, To: 3, Amount: 50, Active: true}
+	ctx := context.Background()
+
+	repo.On("ClaimDue", mock.Anything, testBatchSize, testLease).
+		Return([]domain.ScheduledTransfer{stale, due}, nil)
+	txRepo.On("SendCoin", inUnitOfWork, mock.Anything).Return(nil).Twice()
+	repo.On("Complete", inUnitOfWork, stale, mock.Anything, mock.Anything).
+		Return(domain.ErrScheduledTransferNotDue)
+	repo.On("Complete", inUnitOfWork, due, mock.Anything, mock.Anything).
+		Return(nil)
+
+	processed, err := svc.RunDue(ctx)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, processed)
+	repo.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+}
+
+func TestRunDueScheduledTransfers_ClaimDBError(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewScheduledTransfer(t)
+	svc := NewScheduledTransfer(nil, repo, nil, nil, testBatchSize, testLease)
+
+	ctx := context.Background()
+
+	repo.On("ClaimDue", mock.Anything, testBatchSize, testLease).
+		Return(nil, errors.New("unexpected DBError"))
+
+	_, err := svc.RunDue(ctx)
+
+	require.Error(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestAdvanceSchedule_SkipsMissedSlots(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	st := domain.ScheduledTransfer{
+		Interval:  time.Hour,
+		NextRunAt: now.Add(-5*time.Hour - time.Minute),
+		Active:    true,
+	}
+
+	next := advanceSchedule(st, now)
+
+	require.True(t, next.Active)
+	require.True(t, next.NextRunAt.After(now))
+	require.True(t, next.NextRunAt.Before(now.Add(time.Hour)))
+}
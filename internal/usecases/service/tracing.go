@@ -0,0 +1,6 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer resolves to the provider installed at startup, every service method opens a span with it.
+var tracer = otel.Tracer("avito_shop/internal/usecases/service")
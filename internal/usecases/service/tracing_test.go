@@ -0,0 +1,54 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository/mocks"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// the provider is global, the test must not run in parallel with another one installing it
+func TestLogin_Spans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	userRepo := mocks.NewUser(t)
+	svc := NewAuth(userRepo, secretForTests)
+
+	password := "12345"
+	user := domain.User{
+		ID:             2,
+		Name:           "Tracer",
+		HashedPassword: hashPasswordForTests(t, password),
+	}
+
+	// the repository must be called within the service span to become its child
+	inSpan := mock.MatchedBy(func(ctx context.Context) bool {
+		return trace.SpanContextFromContext(ctx).IsValid()
+	})
+	userRepo.On("GetByName", inSpan, user.Name).Return(user, nil)
+
+	_, err := svc.Login(context.Background(), user.Name, password)
+	require.NoError(t, err)
+
+	var login, bcrypt sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		switch span.Name() {
+		case "AuthService.Login":
+			login = span
+		case "bcrypt.CompareHashAndPassword":
+			bcrypt = span
+		}
+	}
+
+	require.NotNil(t, login)
+	require.NotNil(t, bcrypt)
+	require.Equal(t, login.SpanContext().SpanID(), bcrypt.Parent().SpanID())
+}
@@ -9,52 +9,98 @@ import (
 )
 
 type Transaction struct {
+	uow       repository.UnitOfWork
 	repo      repository.Transaction
 	userRepo  repository.User
 	merchRepo repository.Merch
 }
 
 func NewTransaction(
+	uow repository.UnitOfWork,
 	repo repository.Transaction,
 	userRepo repository.User,
 	merchRepo repository.Merch,
 ) usecases.Transaction {
 	return &Transaction{
+		uow:       uow,
 		repo:      repo,
 		userRepo:  userRepo,
 		merchRepo: merchRepo,
 	}
 }
 
+// SendCoinByName looks the recipient up in the same transaction the coins are sent in,
+// so the recipient can't be deleted in between. The shop and the system account only
+// take coins through purchases and burns, to a sender they don't exist.
 func (s *Transaction) SendCoinByName(ctx context.Context, tx domain.Transaction, to domain.UserName) error {
-	toUser, err := s.userRepo.GetByName(ctx, to)
+	ctx, span := tracer.Start(ctx, "TxService.SendCoinByName")
+	defer span.End()
+
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		toUser, err := s.userRepo.GetByName(ctx, to)
+		if err != nil {
+			return err
+		}
+
+		if isServiceAccount(toUser.ID) {
+			return domain.ErrUserNotFound
+		}
+
+		if toUser.ID == tx.From {
+			return fmt.Errorf("can't allow selfsending: %w", domain.ErrSelfSending)
+		}
+		tx.To = toUser.ID
+
+		return s.repo.SendCoin(ctx, tx)
+	})
 	if err != nil {
 		return fmt.Errorf("TxService.SendCoinByName: %w", err)
 	}
 
-	if toUser.ID == tx.From {
-		return fmt.Errorf("TxService.SendCoinByName: can't allow selfsending: %w", domain.ErrSelfSending)
+	return nil
+}
+
+func (s *Transaction) Reverse(ctx context.Context, rev domain.TransactionReversal) ([]domain.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TxService.Reverse")
+	defer span.End()
+
+	if rev.Reason == "" {
+		return nil, fmt.Errorf("TxService.Reverse: reason is required: %w", domain.ErrBadRequest)
 	}
-	tx.To = toUser.ID
 
-	err = s.repo.SendCoin(ctx, tx)
+	txs, err := s.repo.Reverse(ctx, rev)
 	if err != nil {
-		return fmt.Errorf("TxService.SendCoinByName: %w", err)
+		return nil, fmt.Errorf("TxService.Reverse: %w", err)
 	}
 
-	return nil
+	return txs, nil
 }
 
 func (s *Transaction) BuyItemByName(ctx context.Context, uid domain.UserID, name domain.MerchName) error {
-	merch, err := s.merchRepo.GetByName(ctx, name)
-	if err != nil {
-		return fmt.Errorf("TxService.BuyItemByName: error while searching item: %w", err)
-	}
+	ctx, span := tracer.Start(ctx, "TxService.BuyItemByName")
+	defer span.End()
 
-	err = s.repo.BuyItem(ctx, uid, merch)
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		merch, err := s.merchRepo.GetByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("error while searching item: %w", err)
+		}
+
+		err = s.repo.BuyItem(ctx, uid, merch)
+		if err != nil {
+			return fmt.Errorf("error while making purchase: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("TxService.BuyItemByName: error while making purchase: %w", err)
+		return fmt.Errorf("TxService.BuyItemByName: %w", err)
 	}
 
 	return nil
 }
+
+// isServiceAccount reports the accounts employees can't send coins to.
+func isServiceAccount(id domain.UserID) bool {
+	return id == repository.ShopDBID || id == repository.SystemDBID
+}
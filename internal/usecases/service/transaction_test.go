@@ -2,6 +2,7 @@ package service
 
 import (
 	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
 	"avito_shop/internal/repository/mocks"
 	"context"
 	"errors"
@@ -11,24 +12,42 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+type unitOfWorkKey struct{}
+
+// newUnitOfWork runs fn right away with a marked context, so tests can check
+// which repository calls were made inside the unit of work.
+func newUnitOfWork(t *testing.T) *mocks.UnitOfWork {
+	uow := mocks.NewUnitOfWork(t)
+	uow.On("Do", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(context.WithValue(ctx, unitOfWorkKey{}, true))
+		})
+
+	return uow
+}
+
+var inUnitOfWork = mock.MatchedBy(func(ctx context.Context) bool {
+	return ctx.Value(unitOfWorkKey{}) != nil
+})
+
 func TestSendCoinByName_Success(t *testing.T) {
 	t.Parallel()
 
 	userRepo := mocks.NewUser(t)
 	txRepo := mocks.NewTransaction(t)
-	svc := NewTransaction(txRepo, userRepo, nil)
+	svc := NewTransaction(newUnitOfWork(t), txRepo, userRepo, nil)
 
 	fromID := 0
-	toID := 1
+	toID := 3
 	amount := 100
 	toName := "Avito"
 	ctx := context.Background()
 
-	userRepo.On("GetByName", mock.Anything, toName).
+	userRepo.On("GetByName", inUnitOfWork, toName).
 		Return(domain.User{ID: toID, Name: toName}, nil)
 	txRepo.On(
 		"SendCoin",
-		mock.Anything,
+		inUnitOfWork,
 		domain.Transaction{From: fromID, To: toID, Amount: amount}).
 		Return(nil)
 
@@ -43,7 +62,7 @@ func TestSendCoinByName_SelfSending(t *testing.T) {
 	t.Parallel()
 
 	userRepo := mocks.NewUser(t)
-	svc := NewTransaction(nil, userRepo, nil)
+	svc := NewTransaction(newUnitOfWork(t), nil, userRepo, nil)
 
 	fromID := 0
 	toID := fromID
@@ -61,11 +80,31 @@ func TestSendCoinByName_SelfSending(t *testing.T) {
 	userRepo.AssertExpectations(t)
 }
 
+func TestSendCoinByName_ServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	for _, toID := range []domain.UserID{repository.ShopDBID, repository.SystemDBID} {
+		userRepo := mocks.NewUser(t)
+		svc := NewTransaction(newUnitOfWork(t), nil, userRepo, nil)
+
+		toName := "shop"
+		ctx := context.Background()
+
+		userRepo.On("GetByName", inUnitOfWork, toName).
+			Return(domain.User{ID: toID, Name: toName}, nil)
+
+		err := svc.SendCoinByName(ctx, domain.Transaction{From: 3, Amount: 100}, toName)
+
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+		userRepo.AssertExpectations(t)
+	}
+}
+
 func TestSendCoinByName_InvalidUsername(t *testing.T) {
 	t.Parallel()
 
 	userRepo := mocks.NewUser(t)
-	svc := NewTransaction(nil, userRepo, nil)
+	svc := NewTransaction(newUnitOfWork(t), nil, userRepo, nil)
 
 	fromID := 0
 	amount := 100
@@ -86,7 +125,7 @@ func TestSendCoinByName_CheckingUsernameDBError(t *testing.T) {
 	t.Parallel()
 
 	userRepo := mocks.NewUser(t)
-	svc := NewTransaction(nil, userRepo, nil)
+	svc := NewTransaction(newUnitOfWork(t), nil, userRepo, nil)
 
 	fromID := 0
 	amount := 100
@@ -107,10 +146,10 @@ func TestSendCoinByName_MakingTxDBError(t *testing.T) {
 
 	userRepo := mocks.NewUser(t)
 	txRepo := mocks.NewTransaction(t)
-	svc := NewTransaction(txRepo, userRepo, nil)
+	svc := NewTransaction(newUnitOfWork(t), txRepo, userRepo, nil)
 
 	fromID := 0
-	toID := 1
+	toID := 3
 	amount := 100
 	toName := "Avito"
 	ctx := context.Background()
@@ -130,12 +169,27 @@ func TestSendCoinByName_MakingTxDBError(t *testing.T) {
 	txRepo.AssertExpectations(t)
 }
 
+func TestSendCoinByName_UnitOfWorkError(t *testing.T) {
+	t.Parallel()
+
+	uow := mocks.NewUnitOfWork(t)
+	svc := NewTransaction(uow, nil, nil, nil)
+
+	uow.On("Do", mock.Anything, mock.Anything).
+		Return(errors.New("could not serialize access"))
+
+	err := svc.SendCoinByName(context.Background(), domain.Transaction{From: 0, Amount: 100}, "Avito")
+
+	require.Error(t, err)
+	uow.AssertExpectations(t)
+}
+
 func TestBuyItemByName_Success(t *testing.T) {
 	t.Parallel()
 
 	txRepo := mocks.NewTransaction(t)
 	merchRepo := mocks.NewMerch(t)
-	svc := NewTransaction(txRepo, nil, merchRepo)
+	svc := NewTransaction(newUnitOfWork(t), txRepo, nil, merchRepo)
 
 	buyerID := 0
 	merch := domain.Merch{
@@ -145,9 +199,9 @@ func TestBuyItemByName_Success(t *testing.T) {
 	}
 	ctx := context.Background()
 
-	merchRepo.On("GetByName", mock.Anything, merch.Name).
+	merchRepo.On("GetByName", inUnitOfWork, merch.Name).
 		Return(merch, nil)
-	txRepo.On("BuyItem", mock.Anything, buyerID, merch).
+	txRepo.On("BuyItem", inUnitOfWork, buyerID, merch).
 		Return(nil)
 
 	err := svc.BuyItemByName(ctx, buyerID, merch.Name)
@@ -160,7 +214,7 @@ func TestBuyItemByName_InvalidMerchName(t *testing.T) {
 	t.Parallel()
 
 	merchRepo := mocks.NewMerch(t)
-	svc := NewTransaction(nil, nil, merchRepo)
+	svc := NewTransaction(newUnitOfWork(t), nil, nil, merchRepo)
 
 	buyerID := 0
 	merch := domain.Merch{
@@ -181,7 +235,7 @@ func TestBuyItemByName_CheckingMerchNameDBError(t *testing.T) {
 	t.Parallel()
 
 	merchRepo := mocks.NewMerch(t)
-	svc := NewTransaction(nil, nil, merchRepo)
+	svc := NewTransaction(newUnitOfWork(t), nil, nil, merchRepo)
 
 	buyerID := 0
 	merch := domain.Merch{
@@ -202,7 +256,7 @@ func TestBuyItemByName_PurchaseDBError(t *testing.T) {
 
 	txRepo := mocks.NewTransaction(t)
 	merchRepo := mocks.NewMerch(t)
-	svc := NewTransaction(txRepo, nil, merchRepo)
+	svc := NewTransaction(newUnitOfWork(t), txRepo, nil, merchRepo)
 
 	buyerID := 0
 	merch := domain.Merch{
@@ -222,3 +276,53 @@ func TestBuyItemByName_PurchaseDBError(t *testing.T) {
 	require.Error(t, err)
 	txRepo.AssertExpectations(t)
 }
+
+func TestReverse_Success(t *testing.T) {
+	t.Parallel()
+
+	txRepo := mocks.NewTransaction(t)
+	svc := NewTransaction(nil, txRepo, nil, nil)
+
+	rev := domain.TransactionReversal{
+		TransactionID: 7,
+		Reason:        "wrong recipient",
+		AdminID:       1,
+	}
+	reversals := []domain.Transaction{{ID: 8, From: 3, To: 2, Amount: 100}}
+	ctx := context.Background()
+
+	txRepo.On("Reverse", mock.Anything, rev).Return(reversals, nil)
+
+	result, err := svc.Reverse(ctx, rev)
+
+	require.NoError(t, err)
+	require.Equal(t, reversals, result)
+	txRepo.AssertExpectations(t)
+}
+
+func TestReverse_EmptyReason(t *testing.T) {
+	t.Parallel()
+
+	svc := NewTransaction(nil, nil, nil, nil)
+
+	_, err := svc.Reverse(context.Background(), domain.TransactionReversal{TransactionID: 7})
+
+	require.ErrorIs(t, err, domain.ErrBadRequest)
+}
+
+func TestReverse_LowBalance(t *testing.T) {
+	t.Parallel()
+
+	txRepo := mocks.NewTransaction(t)
+	svc := NewTransaction(nil, txRepo, nil, nil)
+
+	rev := domain.TransactionReversal{TransactionID: 7, Reason: "wrong recipient"}
+	ctx := context.Background()
+
+	txRepo.On("Reverse", mock.Anything, rev).Return(nil, domain.ErrLowBalance)
+
+	_, err := svc.Reverse(ctx, rev)
+
+	require.ErrorIs(t, err, domain.ErrLowBalance)
+	txRepo.AssertExpectations(t)
+}
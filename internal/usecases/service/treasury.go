@@ -0,0 +1,116 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases"
+	"context"
+	"fmt"
+	"time"
+)
+
+type Treasury struct {
+	txRepo          repository.Transaction
+	userRepo        repository.User
+	allowanceAmount int
+	expiryBatchSize int
+}
+
+func NewTreasury(
+	txRepo repository.Transaction,
+	userRepo repository.User,
+	allowanceAmount int,
+	expiryBatchSize int,
+) usecases.Treasury {
+	return &Treasury{
+		txRepo:          txRepo,
+		userRepo:        userRepo,
+		allowanceAmount: allowanceAmount,
+		expiryBatchSize: expiryBatchSize,
+	}
+}
+
+func (s *Treasury) MintByName(
+	ctx context.Context,
+	adj domain.CoinAdjustment,
+	to domain.UserName,
+) (domain.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TreasuryService.MintByName")
+	defer span.End()
+
+	if adj.Amount <= 0 || adj.Reason == "" {
+		return domain.Transaction{}, fmt.Errorf("TreasuryService.MintByName: %w", domain.ErrBadRequest)
+	}
+
+	user, err := s.userRepo.GetByName(ctx, to)
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("TreasuryService.MintByName: %w", err)
+	}
+	adj.User = user.ID
+
+	tx, err := s.txRepo.Mint(ctx, adj)
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("TreasuryService.MintByName: %w", err)
+	}
+
+	return tx, nil
+}
+
+func (s *Treasury) BurnByName(
+	ctx context.Context,
+	adj domain.CoinAdjustment,
+	from domain.UserName,
+) (domain.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "TreasuryService.BurnByName")
+	defer span.End()
+
+	if adj.Amount < 0 || adj.Reason == "" {
+		return domain.Transaction{}, fmt.Errorf("TreasuryService.BurnByName: %w", domain.ErrBadRequest)
+	}
+
+	user, err := s.userRepo.GetByName(ctx, from)
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("TreasuryService.BurnByName: %w", err)
+	}
+	adj.User = user.ID
+
+	tx, err := s.txRepo.Burn(ctx, adj)
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("TreasuryService.BurnByName: %w", err)
+	}
+
+	return tx, nil
+}
+
+// GrantAllowance is idempotent per calendar month, so the job may call it as often as it likes.
+func (s *Treasury) GrantAllowance(ctx context.Context, now time.Time) (domain.AllowanceGrant, error) {
+	ctx, span := tracer.Start(ctx, "TreasuryService.GrantAllowance")
+	defer span.End()
+
+	grant := domain.AllowanceGrant{Period: domain.AllowancePeriodOf(now)}
+
+	if s.allowanceAmount <= 0 {
+		return grant, nil
+	}
+
+	granted, err := s.txRepo.GrantAllowance(ctx, grant.Period, s.allowanceAmount)
+	if err != nil {
+		return domain.AllowanceGrant{}, fmt.Errorf("TreasuryService.GrantAllowance: %w", err)
+	}
+	grant.Granted = granted
+
+	return grant, nil
+}
+
+// ExpireCoins moves one batch of expired coin lots to the shop and returns how many lots were collected.
+func (s *Treasury) ExpireCoins(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "TreasuryService.ExpireCoins")
+	defer span.End()
+
+	expired, err := s.txRepo.ExpireLots(ctx, s.expiryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("TreasuryService.ExpireCoins: %w", err)
+	}
+
+	return expired, nil
+}
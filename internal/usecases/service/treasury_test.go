@@ -0,0 +1,145 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/repository/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMintByName_Success(t *testing.T) {
+	t.Parallel()
+
+	txRepo := mocks.NewTransaction(t)
+	userRepo := mocks.NewUser(t)
+	svc := NewTreasury(txRepo, userRepo, 0, 0)
+
+	userID := 3
+	userName := "Avito"
+	adj := domain.CoinAdjustment{Amount: 100, Reason: "bonus", AdminID: 4}
+	expected := domain.Transaction{ID: 1, From: repository.SystemDBID, To: userID, Amount: adj.Amount}
+	ctx := context.Background()
+
+	userRepo.On("GetByName", mock.Anything, userName).
+		Return(domain.User{ID: userID, Name: userName}, nil)
+	txRepo.On("Mint", mock.Anything, domain.CoinAdjustment{
+		User:    userID,
+		Amount:  adj.Amount,
+		Reason:  adj.Reason,
+		AdminID: adj.AdminID,
+	}).Return(expected, nil)
+
+	tx, err := svc.MintByName(ctx, adj, userName)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, tx)
+	userRepo.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+}
+
+func TestMintByName_BadRequestCases(t *testing.T) {
+	t.Parallel()
+
+	svc := NewTreasury(nil, nil, 0, 0)
+
+	tests := []struct {
+		Name string
+		Adj  domain.CoinAdjustment
+	}{
+		{"Zero amount", domain.CoinAdjustment{Reason: "bonus"}},
+		{"Empty reason", domain.CoinAdjustment{Amount: 100}},
+	}
+
+	for _, test := range tests {
+		_, err := svc.MintByName(context.Background(), test.Adj, "Avito")
+
+		require.ErrorIs(t, err, domain.ErrBadRequest, test.Name)
+	}
+}
+
+func TestBurnByName_LowBalance(t *testing.T) {
+	t.Parallel()
+
+	txRepo := mocks.NewTransaction(t)
+	userRepo := mocks.NewUser(t)
+	svc := NewTreasury(txRepo, userRepo, 0, 0)
+
+	userName := "Avito"
+	ctx := context.Background()
+
+	userRepo.On("GetByName", mock.Anything, userName).
+		Return(domain.User{ID: 3, Name: userName}, nil)
+	txRepo.On("Burn", mock.Anything, mock.Anything).
+		Return(domain.Transaction{}, domain.ErrLowBalance)
+
+	_, err := svc.BurnByName(ctx, domain.CoinAdjustment{Amount: 5000, Reason: "leaving"}, userName)
+
+	require.ErrorIs(t, err, domain.ErrLowBalance)
+	userRepo.AssertExpectations(t)
+	txRepo.AssertExpectations(t)
+}
+
+func TestGrantAllowance_Success(t *testing.T) {
+	t.Parallel()
+
+	txRepo := mocks.NewTransaction(t)
+	svc := NewTreasury(txRepo, nil, 100, 0)
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	txRepo.On("GrantAllowance", mock.Anything, "2026-10", 100).Return(42, nil)
+
+	grant, err := svc.GrantAllowance(ctx, now)
+
+	require.NoError(t, err)
+	require.Equal(t, domain.AllowanceGrant{Period: "2026-10", Granted: 42}, grant)
+	txRepo.AssertExpectations(t)
+}
+
+func TestGrantAllowance_Disabled(t *testing.T) {
+	t.Parallel()
+
+	svc := NewTreasury(nil, nil, 0, 0)
+
+	grant, err := svc.GrantAllowance(context.Background(), time.Now())
+
+	require.NoError(t, err)
+	require.Zero(t, grant.Granted)
+}
+
+func TestGrantAllowance_DBError(t *testing.T) {
+	t.Parallel()
+
+	txRepo := mocks.NewTransaction(t)
+	svc := NewTreasury(txRepo, nil, 100, 0)
+
+	txRepo.On("GrantAllowance", mock.Anything, mock.Anything, 100).
+		Return(0, errors.New("unexpected DBError"))
+
+	_, err := svc.GrantAllowance(context.Background(), time.Now())
+
+	require.Error(t, err)
+	txRepo.AssertExpectations(t)
+}
+
+func TestTreasury_ExpireCoins(t *testing.T) {
+	t.Parallel()
+
+	txRepo := mocks.NewTransaction(t)
+	svc := NewTreasury(txRepo, nil, 0, 500)
+
+	txRepo.On("ExpireLots", mock.Anything, 500).Return(3, nil)
+
+	expired, err := svc.ExpireCoins(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 3, expired)
+	txRepo.AssertExpectations(t)
+}
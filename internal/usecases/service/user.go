@@ -6,6 +6,7 @@ import (
 	"avito_shop/internal/usecases"
 	"context"
 	"fmt"
+	"slices"
 )
 
 type User struct {
@@ -19,6 +20,9 @@ func NewUser(repo repository.User) usecases.User {
 }
 
 func (s *User) Put(ctx context.Context, user domain.User) (domain.UserID, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Put")
+	defer span.End()
+
 	uid, err := s.repo.Put(ctx, user)
 	if err != nil {
 		return 0, fmt.Errorf("UserService.Put: %w", err)
@@ -28,6 +32,9 @@ func (s *User) Put(ctx context.Context, user domain.User) (domain.UserID, error)
 }
 
 func (s *User) GetByName(ctx context.Context, name domain.UserName) (domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetByName")
+	defer span.End()
+
 	user, err := s.repo.GetByName(ctx, name)
 	if err != nil {
 		return domain.User{}, fmt.Errorf("UserService.GetByName: %w", err)
@@ -37,6 +44,9 @@ func (s *User) GetByName(ctx context.Context, name domain.UserName) (domain.User
 }
 
 func (s *User) GetInfoByID(ctx context.Context, id domain.UserID) (domain.UserInfo, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetInfoByID")
+	defer span.End()
+
 	info, err := s.repo.GetInfoByID(ctx, id)
 	if err != nil {
 		return domain.UserInfo{}, fmt.Errorf("UserService.GetInfoByID: %w", err)
@@ -44,3 +54,44 @@ func (s *User) GetInfoByID(ctx context.Context, id domain.UserID) (domain.UserIn
 
 	return info, nil
 }
+
+func (s *User) GetNamesByIDs(ctx context.Context, ids []domain.UserID) (map[domain.UserID]domain.UserName, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetNamesByIDs")
+	defer span.End()
+
+	names, err := s.repo.GetNamesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("UserService.GetNamesByIDs: %w", err)
+	}
+
+	return names, nil
+}
+
+func (s *User) GetLanguage(ctx context.Context, id domain.UserID) (domain.Language, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetLanguage")
+	defer span.End()
+
+	lang, err := s.repo.GetLanguage(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("UserService.GetLanguage: %w", err)
+	}
+
+	return lang, nil
+}
+
+// SetLanguage accepts one of domain.Languages, or an empty language to follow Accept-Language again.
+func (s *User) SetLanguage(ctx context.Context, id domain.UserID, lang domain.Language) error {
+	ctx, span := tracer.Start(ctx, "UserService.SetLanguage")
+	defer span.End()
+
+	if lang != "" && !slices.Contains(domain.Languages, lang) {
+		return fmt.Errorf("UserService.SetLanguage: unsupported language %q: %w", lang, domain.ErrBadRequest)
+	}
+
+	err := s.repo.SetLanguage(ctx, id, lang)
+	if err != nil {
+		return fmt.Errorf("UserService.SetLanguage: %w", err)
+	}
+
+	return nil
+}
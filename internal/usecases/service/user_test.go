@@ -197,3 +197,36 @@ func TestGetInfoByID_DBError(t *testing.T) {
 	require.Error(t, err)
 	userRepo.AssertExpectations(t)
 }
+
+func TestSetLanguage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name   string
+		Lang   domain.Language
+		Stored bool
+		ExpErr error
+	}{
+		{"Supported", domain.LanguageRussian, true, nil},
+		{"Reset", "", true, nil},
+		{"Unsupported", "de", false, domain.ErrBadRequest},
+	}
+
+	for _, test := range tests {
+		userRepo := mocks.NewUser(t)
+		svc := NewUser(userRepo)
+
+		if test.Stored {
+			userRepo.On("SetLanguage", mock.Anything, 2, test.Lang).Return(nil)
+		}
+
+		err := svc.SetLanguage(context.Background(), 2, test.Lang)
+
+		if test.ExpErr != nil {
+			require.ErrorIs(t, err, test.ExpErr, test.Name)
+		} else {
+			require.NoError(t, err, test.Name)
+		}
+		userRepo.AssertExpectations(t)
+	}
+}
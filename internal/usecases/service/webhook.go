@@ -0,0 +1,186 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository"
+	"avito_shop/internal/usecases"
+	"avito_shop/pkg/infra/events"
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const webhookDeliveriesLimit = 100
+
+type Webhook struct {
+	repo        repository.Webhook
+	client      *events.WebhookClient
+	batchSize   int
+	lease       time.Duration
+	maxAttempts int
+	maxBackoff  time.Duration
+}
+
+func NewWebhook(
+	repo repository.Webhook,
+	client *events.WebhookClient,
+	batchSize int,
+	lease time.Duration,
+	maxAttempts int,
+	maxBackoff time.Duration,
+) usecases.Webhook {
+	return &Webhook{
+		repo:        repo,
+		client:      client,
+		batchSize:   batchSize,
+		lease:       lease,
+		maxAttempts: maxAttempts,
+		maxBackoff:  maxBackoff,
+	}
+}
+
+func (s *Webhook) Create(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.Create")
+	defer span.End()
+
+	if err := validateWebhook(sub); err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("WebhookService.Create: %w", err)
+	}
+
+	created, err := s.repo.Create(ctx, sub)
+	if err != nil {
+		return domain.WebhookSubscription{}, fmt.Errorf("WebhookService.Create: %w", err)
+	}
+
+	return created, nil
+}
+
+func validateWebhook(sub domain.WebhookSubscription) error {
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url %q: %w", sub.URL, domain.ErrBadRequest)
+	}
+
+	if sub.Secret == "" {
+		return fmt.Errorf("secret is required: %w", domain.ErrBadRequest)
+	}
+
+	if len(sub.EventTypes) == 0 {
+		return fmt.Errorf("at least one event type is required: %w", domain.ErrBadRequest)
+	}
+
+	for _, t := range sub.EventTypes {
+		if !domain.IsKnownEventType(t) {
+			return fmt.Errorf("unknown event type %q: %w", t, domain.ErrBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func (s *Webhook) List(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.List")
+	defer span.End()
+
+	subs, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookService.List: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (s *Webhook) Delete(ctx context.Context, id domain.WebhookID) error {
+	ctx, span := tracer.Start(ctx, "WebhookService.Delete")
+	defer span.End()
+
+	err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("WebhookService.Delete: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Webhook) ListDeliveries(ctx context.Context, id domain.WebhookID) ([]domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.ListDeliveries")
+	defer span.End()
+
+	deliveries, err := s.repo.ListDeliveries(ctx, id, webhookDeliveriesLimit)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookService.ListDeliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (s *Webhook) Redeliver(ctx context.Context, id domain.WebhookDeliveryID) (domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.Redeliver")
+	defer span.End()
+
+	d, err := s.repo.Redeliver(ctx, id)
+	if err != nil {
+		return domain.WebhookDelivery{}, fmt.Errorf("WebhookService.Redeliver: %w", err)
+	}
+
+	return d, nil
+}
+
+// DeliverDue sends one batch of due deliveries and returns how many of them were attempted.
+// A failed delivery is retried with an exponential backoff until maxAttempts is reached.
+func (s *Webhook) DeliverDue(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.DeliverDue")
+	defer span.End()
+
+	due, err := s.repo.ClaimDue(ctx, s.batchSize, s.lease)
+	if err != nil {
+		return 0, fmt.Errorf("WebhookService.DeliverDue: %w", err)
+	}
+
+	for i, d := range due {
+		attempted, attempt := s.deliver(ctx, d)
+
+		err = s.repo.RecordAttempt(ctx, attempted, attempt)
+		if err != nil {
+			return i, fmt.Errorf("WebhookService.DeliverDue: %w", err)
+		}
+	}
+
+	return len(due), nil
+}
+
+func (s *Webhook) deliver(
+	ctx context.Context,
+	d domain.WebhookDelivery,
+) (domain.WebhookDelivery, domain.WebhookAttempt) {
+	attempt := domain.WebhookAttempt{At: time.Now()}
+
+	status, err := s.client.Post(ctx, d.Subscription.URL, d.Subscription.Secret, events.Message{
+		ID:        d.Event.ID,
+		Type:      d.Event.Type,
+		CreatedAt: d.Event.CreatedAt,
+		Payload:   d.Event.Payload,
+	})
+	attempt.Duration = time.Since(attempt.At)
+	attempt.StatusCode = status
+
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	d.Attempts++
+	d.LastError = attempt.Error
+
+	switch {
+	case attempt.OK():
+		d.Status = domain.WebhookDeliveryDelivered
+	case d.Attempts >= s.maxAttempts:
+		d.Status = domain.WebhookDeliveryFailed
+	default:
+		d.Status = domain.WebhookDeliveryPending
+		d.NextAttemptAt = time.Now().Add(retryDelay(d.Attempts-1, s.maxBackoff))
+	}
+
+	return d, attempt
+}
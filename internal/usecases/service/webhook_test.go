@@ -0,0 +1,183 @@
+package service
+
+import (
+	"avito_shop/internal/domain"
+	"avito_shop/internal/repository/mocks"
+	"avito_shop/pkg/infra/events"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_CreateValidation(t *testing.T) {
+	t.Parallel()
+
+	valid := domain.WebhookSubscription{
+		URL:        "https://chat.example.com/hooks/shop",
+		Secret:     "s3cr3t",
+		EventTypes: []domain.EventType{domain.EventCoinsSent},
+	}
+
+	tests := []struct {
+		Name   string
+		Modify func(sub *domain.WebhookSubscription)
+	}{
+		{"Relative URL", func(sub *domain.WebhookSubscription) { sub.URL = "/hooks/shop" }},
+		{"Not an HTTP URL", func(sub *domain.WebhookSubscription) { sub.URL = "ftp://example.com" }},
+		{"Empty secret", func(sub *domain.WebhookSubscription) { sub.Secret = "" }},
+		{"No event types", func(sub *domain.WebhookSubscription) { sub.EventTypes = nil }},
+		{"Unknown event type", func(sub *domain.WebhookSubscription) {
+			sub.EventTypes = []domain.EventType{"CoinsBurnt"}
+		}},
+	}
+
+	svc := NewWebhook(nil, nil, 10, time.Minute, 3, time.Hour)
+
+	for _, test := range tests {
+		sub := valid
+		test.Modify(&sub)
+
+		_, err := svc.Create(context.Background(), sub)
+
+		require.ErrorIs(t, err, domain.ErrBadRequest, test.Name)
+	}
+}
+
+func TestWebhook_CreateSuccess(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewWebhook(t)
+	svc := NewWebhook(repo, nil, 10, time.Minute, 3, time.Hour)
+
+	sub := domain.WebhookSubscription{
+		URL:        "https://chat.example.com/hooks/shop",
+		Secret:     "s3cr3t",
+		EventTypes: []domain.EventType{domain.EventCoinsSent, domain.EventMerchPurchased},
+		CreatedBy:  1,
+	}
+	created := sub
+	created.ID = 5
+
+	repo.On("Create", mock.Anything, sub).Return(created, nil)
+
+	res, err := svc.Create(context.Background(), sub)
+
+	require.NoError(t, err)
+	require.Equal(t, created, res)
+	repo.AssertExpectations(t)
+}
+
+func TestWebhook_DeliverDueSigned(t *testing.T) {
+	t.Parallel()
+
+	const secret = "s3cr3t"
+
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	repo := mocks.NewWebhook(t)
+	svc := NewWebhook(repo, events.NewWebhookClient(time.Second), 10, time.Minute, 3, time.Hour)
+
+	d := domain.WebhookDelivery{
+		ID:           7,
+		Subscription: domain.WebhookSubscription{ID: 1, URL: srv.URL, Secret: secret},
+		Event: domain.Event{
+			ID:      42,
+			Type:    domain.EventCoinsSent,
+			Payload: json.RawMessage(`{"fromName":"alice","toName":"bob","amount":50}`),
+		},
+		Status: domain.WebhookDeliveryPending,
+	}
+
+	repo.On("ClaimDue", mock.Anything, 10, time.Minute).Return([]domain.WebhookDelivery{d}, nil)
+	repo.On("RecordAttempt", mock.Anything,
+		mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+			return d.ID == 7 && d.Status == domain.WebhookDeliveryDelivered && d.Attempts == 1
+		}),
+		mock.MatchedBy(func(a domain.WebhookAttempt) bool {
+			return a.OK() && a.StatusCode == http.StatusNoContent
+		}),
+	).Return(nil)
+
+	attempted, err := svc.DeliverDue(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 1, attempted)
+
+	req, body := <-received, <-bodies
+	timestamp, err := strconv.ParseInt(req.Header.Get(events.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, events.Sign(secret, timestamp, body), req.Header.Get(events.HeaderSignature))
+	require.Equal(t, "42", req.Header.Get(events.HeaderEventID))
+	require.Equal(t, domain.EventCoinsSent, req.Header.Get(events.HeaderEventType))
+
+	var msg events.Message
+	require.NoError(t, json.Unmarshal(body, &msg))
+	require.JSONEq(t, string(d.Event.Payload), string(msg.Payload))
+	repo.AssertExpectations(t)
+}
+
+func TestWebhook_DeliverDueRetries(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		Name      string
+		Attempts  int
+		ExpStatus domain.WebhookDeliveryStatus
+	}{
+		{"Retried later", 1, domain.WebhookDeliveryPending},
+		{"Out of attempts", 2, domain.WebhookDeliveryFailed},
+	}
+
+	for _, test := range tests {
+		repo := mocks.NewWebhook(t)
+		svc := NewWebhook(repo, events.NewWebhookClient(time.Second), 10, time.Minute, 3, time.Hour)
+
+		d := domain.WebhookDelivery{
+			ID:           7,
+			Subscription: domain.WebhookSubscription{URL: srv.URL, Secret: "s3cr3t"},
+			Event:        domain.Event{ID: 42, Type: domain.EventCoinsSent, Payload: json.RawMessage(`{}`)},
+			Status:       domain.WebhookDeliveryPending,
+			Attempts:     test.Attempts,
+		}
+		before := time.Now()
+
+		repo.On("ClaimDue", mock.Anything, 10, time.Minute).Return([]domain.WebhookDelivery{d}, nil)
+		repo.On("RecordAttempt", mock.Anything,
+			mock.MatchedBy(func(d domain.WebhookDelivery) bool {
+				return d.Status == test.ExpStatus &&
+					d.Attempts == test.Attempts+1 &&
+					d.LastError != "" &&
+					(d.Status != domain.WebhookDeliveryPending || d.NextAttemptAt.After(before))
+			}),
+			mock.MatchedBy(func(a domain.WebhookAttempt) bool {
+				return !a.OK() && a.StatusCode == http.StatusServiceUnavailable
+			}),
+		).Return(nil)
+
+		_, err := svc.DeliverDue(context.Background())
+
+		require.NoError(t, err, test.Name)
+		repo.AssertExpectations(t)
+	}
+}
@@ -9,4 +9,5 @@ import (
 type Transaction interface {
 	SendCoinByName(ctx context.Context, tx domain.Transaction, to domain.UserName) error
 	BuyItemByName(ctx context.Context, uid domain.UserID, name domain.MerchName) error
+	Reverse(ctx context.Context, rev domain.TransactionReversal) ([]domain.Transaction, error)
 }
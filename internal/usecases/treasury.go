@@ -0,0 +1,15 @@
+package usecases
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Treasury --filename=treasury_service_mock.go
+type Treasury interface {
+	MintByName(ctx context.Context, adj domain.CoinAdjustment, to domain.UserName) (domain.Transaction, error)
+	BurnByName(ctx context.Context, adj domain.CoinAdjustment, from domain.UserName) (domain.Transaction, error)
+	GrantAllowance(ctx context.Context, now time.Time) (domain.AllowanceGrant, error)
+	ExpireCoins(ctx context.Context) (int, error)
+}
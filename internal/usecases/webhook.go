@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"avito_shop/internal/domain"
+	"context"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.52.1 --name=Webhook --filename=webhook_service_mock.go
+type Webhook interface {
+	Create(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	List(ctx context.Context) ([]domain.WebhookSubscription, error)
+	Delete(ctx context.Context, id domain.WebhookID) error
+	ListDeliveries(ctx context.Context, id domain.WebhookID) ([]domain.WebhookDelivery, error)
+	Redeliver(ctx context.Context, id domain.WebhookDeliveryID) (domain.WebhookDelivery, error)
+	DeliverDue(ctx context.Context) (int, error)
+}
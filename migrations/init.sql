@@ -11,7 +11,13 @@ CREATE TABLE employees
     id              SERIAL PRIMARY KEY,
     username        VARCHAR(127) NOT NULL UNIQUE,
     hashed_password TEXT         NOT NULL,
-    coins           INT          NOT NULL DEFAULT 1000 CHECK (coins >= 0)
+    coins           INT          NOT NULL DEFAULT 1000 CHECK (coins >= 0),
+    -- admins are appointed manually: UPDATE employees SET is_admin = TRUE WHERE username = '...'
+    is_admin        BOOLEAN      NOT NULL DEFAULT FALSE,
+    -- departed employees are deactivated and stop receiving allowances
+    active          BOOLEAN      NOT NULL DEFAULT TRUE,
+    -- the language of the API answers, NULL follows the Accept-Language header
+    language        VARCHAR(8)
 );
 
 CREATE TABLE inventory
@@ -25,6 +31,17 @@ CREATE TABLE inventory
     FOREIGN KEY (merch_id) REFERENCES merch (id) ON DELETE RESTRICT ON UPDATE CASCADE
 );
 
+-- balance checks against coin_transactions history, stored as dry-run reports until applied
+CREATE TABLE reconciliations
+(
+    id         SERIAL PRIMARY KEY,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    checked    INT                      NOT NULL,
+    applied_at TIMESTAMP WITH TIME ZONE NULL,
+    applied_by INT                      NULL,
+    FOREIGN KEY (applied_by) REFERENCES employees (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
 CREATE TABLE coin_transactions
 (
     id         SERIAL PRIMARY KEY,
@@ -32,16 +49,267 @@ CREATE TABLE coin_transactions
     recipient  INT NULL,
     amount     INT NOT NULL CHECK (amount >= 0),
     created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    -- audit trail of admin reversals: the compensated transaction, why and by whom
+    reverses     INT  NULL,
+    reason       TEXT NULL,
+    performed_by INT  NULL,
+    -- corrective transactions of a reconciliation don't count when balances are recomputed
+    corrects     INT  NULL,
     FOREIGN KEY (sender) REFERENCES employees (id) ON DELETE SET NULL ON UPDATE CASCADE,
-    FOREIGN KEY (recipient) REFERENCES employees (id) ON DELETE SET NULL ON UPDATE CASCADE
+    FOREIGN KEY (recipient) REFERENCES employees (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    FOREIGN KEY (reverses) REFERENCES coin_transactions (id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    FOREIGN KEY (performed_by) REFERENCES employees (id) ON DELETE SET NULL ON UPDATE CASCADE,
+    FOREIGN KEY (corrects) REFERENCES reconciliations (id) ON DELETE RESTRICT ON UPDATE CASCADE
 );
 
 CREATE INDEX idx_coin_transactions_sender_time ON coin_transactions (sender, created_at DESC);
 CREATE INDEX idx_coin_transactions_recipient_time ON coin_transactions (recipient, created_at DESC);
+CREATE INDEX idx_coin_transactions_reverses ON coin_transactions (reverses) WHERE reverses IS NOT NULL;
+
+-- coins expire 12 months after they were granted; a transfer keeps the original grant date
+CREATE TABLE coin_lots
+(
+    id          SERIAL PRIMARY KEY,
+    employee_id INT                      NOT NULL,
+    amount      INT                      NOT NULL CHECK (amount > 0),
+    granted_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    expires_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now() + INTERVAL '12 months',
+    FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_coin_lots_employee_expiry ON coin_lots (employee_id, expires_at);
+CREATE INDEX idx_coin_lots_expiry ON coin_lots (expires_at);
+
+-- double-entry ledger, the source of truth for balances: postings of an entry sum up to zero
+-- and employees.coins equals the sum of the account's postings (except the issuing system account)
+CREATE TABLE ledger_entries
+(
+    id             SERIAL PRIMARY KEY,
+    transaction_id INT                      NULL UNIQUE,
+    memo           TEXT                     NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    FOREIGN KEY (transaction_id) REFERENCES coin_transactions (id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+
+CREATE TABLE ledger_postings
+(
+    id       SERIAL PRIMARY KEY,
+    entry_id INT NOT NULL,
+    account  INT NOT NULL,
+    amount   INT NOT NULL CHECK (amount <> 0),
+    FOREIGN KEY (entry_id) REFERENCES ledger_entries (id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    FOREIGN KEY (account) REFERENCES employees (id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_ledger_postings_entry ON ledger_postings (entry_id);
+CREATE INDEX idx_ledger_postings_account ON ledger_postings (account);
+
+CREATE TABLE reconciliation_discrepancies
+(
+    reconciliation_id INT NOT NULL,
+    employee_id       INT NOT NULL,
+    stored            INT NOT NULL,
+    expected          INT NOT NULL,
+    correction        INT NULL,
+    PRIMARY KEY (reconciliation_id, employee_id),
+    FOREIGN KEY (reconciliation_id) REFERENCES reconciliations (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (correction) REFERENCES coin_transactions (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+CREATE TABLE coin_requests
+(
+    id          SERIAL PRIMARY KEY,
+    requester   INT                      NOT NULL,
+    payer       INT                      NOT NULL,
+    amount      INT                      NOT NULL CHECK (amount > 0),
+    status      VARCHAR(16)              NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'accepted', 'declined')),
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    expires_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE NULL,
+    FOREIGN KEY (requester) REFERENCES employees (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (payer) REFERENCES employees (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_coin_requests_payer_time ON coin_requests (payer, created_at DESC);
+CREATE INDEX idx_coin_requests_requester_time ON coin_requests (requester, created_at DESC);
+
+CREATE TABLE scheduled_transfers
+(
+    id           SERIAL PRIMARY KEY,
+    sender       INT                      NOT NULL,
+    recipient    INT                      NOT NULL,
+    amount       INT                      NOT NULL CHECK (amount > 0),
+    interval_sec BIGINT                   NOT NULL DEFAULT 0 CHECK (interval_sec >= 0),
+    next_run_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    active       BOOLEAN                  NOT NULL DEFAULT TRUE,
+    locked_until TIMESTAMP WITH TIME ZONE NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    FOREIGN KEY (sender) REFERENCES employees (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (recipient) REFERENCES employees (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_scheduled_transfers_sender_time ON scheduled_transfers (sender, created_at DESC);
+CREATE INDEX idx_scheduled_transfers_due ON scheduled_transfers (next_run_at) WHERE active;
+
+CREATE TABLE scheduled_transfer_runs
+(
+    id          SERIAL PRIMARY KEY,
+    transfer_id INT                      NOT NULL,
+    status      VARCHAR(16)              NOT NULL CHECK (status IN ('succeeded', 'failed')),
+    reason      TEXT                     NULL,
+    run_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    FOREIGN KEY (transfer_id) REFERENCES scheduled_transfers (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_scheduled_transfer_runs_transfer_time ON scheduled_transfer_runs (transfer_id, run_at DESC);
+
+CREATE TABLE allowance_grants
+(
+    period      VARCHAR(7) NOT NULL,
+    employee_id INT        NOT NULL,
+    PRIMARY KEY (period, employee_id),
+    FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+-- domain events written in the same transaction as the change they describe,
+-- the outbox relay publishes them and keeps the retry bookkeeping
+CREATE TABLE outbox
+(
+    id              BIGSERIAL PRIMARY KEY,
+    event_type      VARCHAR(64)              NOT NULL,
+    payload         JSONB                    NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    attempts        INT                      NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    last_error      TEXT,
+    published_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_outbox_pending ON outbox (next_attempt_at) WHERE published_at IS NULL;
+
+CREATE TABLE webhook_subscriptions
+(
+    id          SERIAL PRIMARY KEY,
+    url         TEXT                     NOT NULL,
+    secret      TEXT                     NOT NULL,
+    event_types VARCHAR(64)[]            NOT NULL,
+    created_by  INT,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    FOREIGN KEY (created_by) REFERENCES employees (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+-- one row per event and subscription, written together with the outbox event
+CREATE TABLE webhook_deliveries
+(
+    id              BIGSERIAL PRIMARY KEY,
+    subscription_id INT                      NOT NULL,
+    event_id        BIGINT                   NOT NULL,
+    status          VARCHAR(16)              NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts        INT                      NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    last_error      TEXT,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    delivered_at    TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (event_id) REFERENCES outbox (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries (subscription_id, id DESC);
+
+CREATE TABLE webhook_delivery_attempts
+(
+    id           BIGSERIAL PRIMARY KEY,
+    delivery_id  BIGINT                   NOT NULL,
+    attempted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    status_code  INT,
+    error        TEXT,
+    duration_ms  INT                      NOT NULL,
+    FOREIGN KEY (delivery_id) REFERENCES webhook_deliveries (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_webhook_delivery_attempts_delivery ON webhook_delivery_attempts (delivery_id, attempted_at);
+
+-- the events a user is notified about, kept as unread until the user acknowledges them
+CREATE TABLE notifications
+(
+    employee_id INT                      NOT NULL,
+    event_id    BIGINT                   NOT NULL,
+    kind        VARCHAR(32)              NOT NULL,
+    read_at     TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (employee_id, event_id),
+    FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (event_id) REFERENCES outbox (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_notifications_unread ON notifications (employee_id, event_id) WHERE read_at IS NULL;
+
+-- users opt in to notification emails by setting an address
+CREATE TABLE email_preferences
+(
+    employee_id     INT PRIMARY KEY,
+    address         TEXT                     NOT NULL,
+    coins_received  BOOLEAN                  NOT NULL DEFAULT TRUE,
+    merch_purchased BOOLEAN                  NOT NULL DEFAULT TRUE,
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+-- emails queued together with the notifications they announce
+CREATE TABLE email_queue
+(
+    id              BIGSERIAL PRIMARY KEY,
+    employee_id     INT                      NOT NULL,
+    address         TEXT                     NOT NULL,
+    event_id        BIGINT                   NOT NULL,
+    kind            VARCHAR(32)              NOT NULL,
+    status          VARCHAR(16)              NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts        INT                      NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    last_error      TEXT,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    sent_at         TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (event_id) REFERENCES outbox (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_email_queue_due ON email_queue (next_attempt_at) WHERE status = 'pending';
+
+-- purchases with the item they bought, the payment itself is the referenced transaction
+CREATE TABLE orders
+(
+    id             SERIAL PRIMARY KEY,
+    employee_id    INT                      NOT NULL,
+    merch_id       INT                      NOT NULL,
+    price          INT                      NOT NULL,
+    transaction_id INT                      NOT NULL UNIQUE,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (merch_id) REFERENCES merch (id) ON DELETE RESTRICT ON UPDATE CASCADE,
+    FOREIGN KEY (transaction_id) REFERENCES coin_transactions (id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_orders_employee_time ON orders (employee_id, created_at DESC);
+
+-- static rows in db to make shop and admin (mint/burn/allowance) transactions correct
+INSERT INTO employees (username, hashed_password, active)
+VALUES ('shop', 'SHOP_HASH', FALSE),
+       ('system', 'SYSTEM_HASH', FALSE);
 
--- static row in db to make shop transactions correct
-INSERT INTO employees (username, hashed_password)
-VALUES ('shop', 'SHOP_HASH');
+-- the shop starts with the default balance like everyone else
+WITH entry AS (
+    INSERT INTO ledger_entries (memo) VALUES ('opening balance') RETURNING id
+)
+INSERT INTO ledger_postings (entry_id, account, amount)
+SELECT entry.id, p.account, p.amount
+FROM entry,
+     employees shop
+     CROSS JOIN LATERAL (VALUES (2, -shop.coins), (shop.id, shop.coins)) AS p (account, amount)
+WHERE shop.id = 1;
 
 INSERT INTO merch (name, price)
 VALUES ('t-shirt', 80),
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// The admin calls need the token of an admin user.
+
+func (c *Client) Mint(ctx context.Context, req types.PostMintRequest) (*types.CoinAdjustmentResponse, error) {
+	var out types.CoinAdjustmentResponse
+	err := c.do(ctx, call{method: http.MethodPost, path: "/admin/mint", body: req, out: &out, unsafe: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (c *Client) Burn(ctx context.Context, req types.PostBurnRequest) (*types.CoinAdjustmentResponse, error) {
+	var out types.CoinAdjustmentResponse
+	err := c.do(ctx, call{method: http.MethodPost, path: "/admin/burn", body: req, out: &out, unsafe: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// GrantAllowance grants the allowance of the current month, another call in the month grants nothing.
+func (c *Client) GrantAllowance(ctx context.Context) (*types.PostAllowanceResponse, error) {
+	var out types.PostAllowanceResponse
+	err := c.do(ctx, call{method: http.MethodPost, path: "/admin/allowances", out: &out, unsafe: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// ReverseTransaction reverses the transaction req.ID.
+func (c *Client) ReverseTransaction(
+	ctx context.Context,
+	req types.PostReverseTransactionRequest,
+) (*types.PostReverseTransactionResponse, error) {
+	var out types.PostReverseTransactionResponse
+	err := c.do(ctx, call{
+		method: http.MethodPost,
+		path:   "/admin/transactions/" + strconv.Itoa(req.ID) + "/reverse",
+		body:   req,
+		out:    &out,
+		unsafe: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// VerifyLedger checks the balances of the users against the ledger postings.
+func (c *Client) VerifyLedger(ctx context.Context) (*types.GetLedgerVerificationResponse, error) {
+	var out types.GetLedgerVerificationResponse
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/admin/ledger/verification", out: &out}); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// Reconcile recounts the balances from the transfer history, ApplyReconciliation corrects the discrepancies.
+func (c *Client) Reconcile(ctx context.Context) (*types.ReconciliationResponse, error) {
+	return c.reconciliation(ctx, http.MethodPost, "/admin/reconciliations", true)
+}
+
+func (c *Client) LatestReconciliation(ctx context.Context) (*types.ReconciliationResponse, error) {
+	return c.reconciliation(ctx, http.MethodGet, "/admin/reconciliations/latest", false)
+}
+
+// ApplyReconciliation corrects only the balances that haven't changed since the reconciliation.
+func (c *Client) ApplyReconciliation(
+	ctx context.Context,
+	id domain.ReconciliationID,
+) (*types.ReconciliationResponse, error) {
+	return c.reconciliation(ctx, http.MethodPost, "/admin/reconciliations/"+strconv.Itoa(id)+"/apply", true)
+}
+
+func (c *Client) reconciliation(
+	ctx context.Context,
+	method, path string,
+	unsafe bool,
+) (*types.ReconciliationResponse, error) {
+	var out types.ReconciliationResponse
+	if err := c.do(ctx, call{method: method, path: path, out: &out, unsafe: unsafe}); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
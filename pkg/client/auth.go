@@ -0,0 +1,97 @@
+package client
+
+import (
+	"avito_shop/internal/api/http/types"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenLeeway is how long before its expiry a token is refreshed, so it doesn't expire in flight.
+const tokenLeeway = 30 * time.Second
+
+// Auth logs in, registering the user if there is none, and makes the following calls with the token.
+func (c *Client) Auth(ctx context.Context, username, password string) (string, error) {
+	token, err := c.login(ctx, username, password)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.token = token
+	c.mu.Unlock()
+
+	return token, nil
+}
+
+func (c *Client) login(ctx context.Context, username, password string) (string, error) {
+	var out types.PostAuthResponse
+	err := c.do(ctx, call{
+		method:    http.MethodPost,
+		path:      "/auth",
+		body:      types.PostAuthRequest{Username: username, Password: password},
+		out:       &out,
+		anonymous: true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return out.Token, nil
+}
+
+// tokenFor returns the token to make the call with, logging in when there is none yet
+// or it is about to expire.
+func (c *Client) tokenFor(ctx context.Context, cl call) (string, error) {
+	if cl.anonymous {
+		return "", nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.canLogin() || (c.token != "" && !expiresSoon(c.token)) {
+		return c.token, nil
+	}
+
+	// the lock is held while logging in, so concurrent calls wait for one login
+	token, err := c.login(ctx, c.username, c.password)
+	if err != nil {
+		return "", err
+	}
+	c.token = token
+
+	return token, nil
+}
+
+// dropToken forgets a token the server rejected, unless a concurrent call already replaced it.
+func (c *Client) dropToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token == token {
+		c.token = ""
+	}
+}
+
+func (c *Client) canLogin() bool {
+	return c.username != ""
+}
+
+// expiresSoon reads the expiry of the token without verifying it, the client has no key to verify
+// it with. Tokens without an expiry are only refreshed once the server rejects them.
+func expiresSoon(token string) bool {
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return false
+	}
+
+	exp, err := parsed.Claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return false
+	}
+
+	return time.Until(exp.Time) < tokenLeeway
+}
@@ -0,0 +1,98 @@
+// Package client is the Go client of the shop API v2. Every operation of docs/swagger.yaml has a method,
+// TestClientCoversAPI fails as soon as one is documented without it.
+//
+// The client logs in with its credentials on the first call and logs in again when the token
+// expires or the server rejects it. Failed calls are retried with a backoff, the calls changing
+// state carry an Idempotency-Key, so a retry of a call the server already ran is not run again.
+package client
+
+import (
+	resp "avito_shop/pkg/http/responses"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	apiPath = "/api/v2"
+
+	defaultRetries = 3
+	defaultBackoff = 200 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+type Client struct {
+	baseURL  string
+	http     *http.Client
+	username string
+	password string
+	retries  int
+	backoff  time.Duration
+
+	mu    sync.Mutex
+	token string
+}
+
+type Option func(c *Client)
+
+// WithHTTPClient replaces http.DefaultClient. Its timeout must leave the event streams alone,
+// a request deadline is better set on the context of the call.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.http = httpClient
+	}
+}
+
+// WithCredentials logs the client in on the first call, the user is registered if there is none.
+func WithCredentials(username, password string) Option {
+	return func(c *Client) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// WithToken makes the calls with a token got elsewhere, without credentials it is never refreshed.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithRetries sets how many times a failed call is retried, the delay starts at backoff and doubles.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New builds a client of the server at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		http:    http.DefaultClient,
+		retries: defaultRetries,
+		backoff: defaultBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Error is a call the server failed, Problem is the RFC 7807 body it answered with.
+// Problem.Code is the stable reason to switch on, e.g. "LOW_BALANCE". RetryAfter is set
+// when the server told when to retry, e.g. on 429.
+type Error struct {
+	StatusCode int
+	Problem    resp.ErrorResponse
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("client: %d %s: %s", e.StatusCode, e.Problem.Code, e.Problem.Detail)
+}
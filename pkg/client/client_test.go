@@ -0,0 +1,218 @@
+package client
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/pkg/http/responses"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer serves /auth handing out the tokens in turn and the routes given.
+func newTestServer(t *testing.T, tokens []string, routes func(r chi.Router)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var logins atomic.Int32
+	r := chi.NewRouter()
+	r.Route(apiPath, func(r chi.Router) {
+		r.Post("/auth", func(w http.ResponseWriter, r *http.Request) {
+			var req types.PostAuthRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "alice", req.Username)
+
+			n := int(logins.Add(1)) - 1
+			_ = json.NewEncoder(w).Encode(types.PostAuthResponse{Token: tokens[min(n, len(tokens)-1)]})
+		})
+		routes(r)
+	})
+
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	return server, &logins
+}
+
+func writeProblem(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", responses.ProblemContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(responses.Problem(status, code, errors.New(code)))
+}
+
+func TestClient_LogsInOnce(t *testing.T) {
+	t.Parallel()
+
+	server, logins := newTestServer(t, []string{"t1"}, func(r chi.Router) {
+		r.Get("/info", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "t1", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"coins":1000,"inventory":[],"coinHistory":{"received":[],"sent":[]}}`))
+		})
+	})
+
+	c := New(server.URL, WithCredentials("alice", "secret"))
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			info, err := c.Info(context.Background())
+			if assert.NoError(t, err) {
+				assert.Equal(t, 1000, info.Coins)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), logins.Load())
+}
+
+func TestClient_RefreshesRejectedToken(t *testing.T) {
+	t.Parallel()
+
+	server, logins := newTestServer(t, []string{"t1", "t2"}, func(r chi.Router) {
+		r.Get("/merch", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "t2" {
+				writeProblem(w, http.StatusUnauthorized, responses.CodeUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`[{"name":"cup","displayName":"Cup","price":20}]`))
+		})
+	})
+
+	c := New(server.URL, WithCredentials("alice", "secret"))
+
+	merch, err := c.Merch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []types.MerchItem{{Name: "cup", DisplayName: "Cup", Price: 20}}, merch)
+	require.Equal(t, int32(2), logins.Load())
+}
+
+func TestClient_RefreshesExpiringToken(t *testing.T) {
+	t.Parallel()
+
+	expiring, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": 1,
+		"exp":     time.Now().Add(tokenLeeway / 2).Unix(),
+	}).SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	server, logins := newTestServer(t, []string{expiring}, func(r chi.Router) {
+		r.Get("/preferences/language", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"language":"ru"}`))
+		})
+	})
+
+	c := New(server.URL, WithCredentials("alice", "secret"))
+
+	for range 2 {
+		lang, langErr := c.Language(context.Background())
+		require.NoError(t, langErr)
+		require.Equal(t, "ru", lang)
+	}
+	require.Equal(t, int32(2), logins.Load())
+}
+
+func TestClient_RetriesWithSameKey(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	server, _ := newTestServer(t, []string{"t1"}, func(r chi.Router) {
+		r.Post("/sendCoin", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			attempt := len(keys)
+			mu.Unlock()
+
+			switch attempt {
+			case 1:
+				writeProblem(w, http.StatusServiceUnavailable, responses.CodeInternal)
+			case 2:
+				writeProblem(w, http.StatusConflict, responses.CodeIdempotencyKeyInUse)
+			case 3:
+				w.Header().Set("Retry-After", "0")
+				writeProblem(w, http.StatusTooManyRequests, responses.CodeTooManyRequests)
+			}
+		})
+	})
+
+	c := New(server.URL, WithCredentials("alice", "secret"), WithRetries(3, time.Millisecond))
+
+	require.NoError(t, c.SendCoin(context.Background(), types.PostSendCoinRequest{ToUser: "bob", Amount: 10}))
+
+	require.Len(t, keys, 4)
+	require.NotEmpty(t, keys[0])
+	for _, key := range keys {
+		require.Equal(t, keys[0], key)
+	}
+
+	// every call has a key of its own
+	require.NoError(t, c.SendCoin(context.Background(), types.PostSendCoinRequest{ToUser: "bob", Amount: 10}))
+	require.NotEqual(t, keys[0], keys[4])
+}
+
+func TestClient_Fails(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server, _ := newTestServer(t, []string{"t1"}, func(r chi.Router) {
+		r.Get("/buy/{item}", func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if chi.URLParam(r, "item") == "cup" {
+				writeProblem(w, http.StatusUnprocessableEntity, "LOW_BALANCE")
+				return
+			}
+			w.WriteHeader(http.StatusBadGateway)
+		})
+	})
+
+	c := New(server.URL, WithCredentials("alice", "secret"), WithRetries(2, time.Millisecond))
+
+	// client errors aren't retried
+	err := c.Buy(context.Background(), "cup")
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	require.Equal(t, "LOW_BALANCE", apiErr.Problem.Code)
+	require.Equal(t, int32(1), calls.Load())
+
+	// the retries run out, a body that isn't a problem one is replaced with the status text
+	err = c.Buy(context.Background(), "pen")
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	require.Equal(t, http.StatusText(http.StatusBadGateway), apiErr.Problem.Detail)
+	require.Equal(t, int32(4), calls.Load())
+}
+
+func TestClient_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTestServer(t, []string{"t1"}, func(r chi.Router) {
+		r.Post("/sendCoin", func(w http.ResponseWriter, _ *http.Request) {
+			writeProblem(w, http.StatusServiceUnavailable, responses.CodeInternal)
+		})
+	})
+
+	c := New(server.URL, WithCredentials("alice", "secret"), WithRetries(10, time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := c.SendCoin(ctx, types.PostSendCoinRequest{ToUser: "bob", Amount: 10})
+	require.Error(t, err)
+	require.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// RequestCoins asks another user for coins, they are sent once the user accepts the request.
+func (c *Client) RequestCoins(ctx context.Context, req types.PostCoinRequestRequest) (*types.CoinRequest, error) {
+	var out types.CoinRequest
+	err := c.do(ctx, call{method: http.MethodPost, path: "/coinRequests", body: req, out: &out, unsafe: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// IncomingCoinRequests returns the requests other users sent to the user.
+func (c *Client) IncomingCoinRequests(ctx context.Context) (*types.GetCoinRequestsResponse, error) {
+	return c.coinRequests(ctx, "/coinRequests/incoming")
+}
+
+// OutgoingCoinRequests returns the requests the user sent.
+func (c *Client) OutgoingCoinRequests(ctx context.Context) (*types.GetCoinRequestsResponse, error) {
+	return c.coinRequests(ctx, "/coinRequests/outgoing")
+}
+
+func (c *Client) coinRequests(ctx context.Context, path string) (*types.GetCoinRequestsResponse, error) {
+	var out types.GetCoinRequestsResponse
+	if err := c.do(ctx, call{method: http.MethodGet, path: path, out: &out}); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (c *Client) AcceptCoinRequest(ctx context.Context, id domain.CoinRequestID) error {
+	return c.do(ctx, call{
+		method: http.MethodPost,
+		path:   "/coinRequests/" + strconv.Itoa(id) + "/accept",
+		unsafe: true,
+	})
+}
+
+func (c *Client) DeclineCoinRequest(ctx context.Context, id domain.CoinRequestID) error {
+	return c.do(ctx, call{
+		method: http.MethodPost,
+		path:   "/coinRequests/" + strconv.Itoa(id) + "/decline",
+		unsafe: true,
+	})
+}
@@ -0,0 +1,21 @@
+package client
+
+import (
+	"avito_shop/internal/api/http/types"
+	"context"
+	"net/http"
+)
+
+func (c *Client) EmailPreferences(ctx context.Context) (*types.EmailPreferences, error) {
+	var out types.EmailPreferences
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/notifications/email", out: &out}); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// SetEmailPreferences with an empty email turns the notification emails off.
+func (c *Client) SetEmailPreferences(ctx context.Context, prefs types.EmailPreferences) error {
+	return c.do(ctx, call{method: http.MethodPut, path: "/notifications/email", body: prefs})
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"avito_shop/internal/domain"
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// EventStream reads the Server-Sent Events of the user until it is closed or the connection breaks,
+// it doesn't reconnect on its own.
+type EventStream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+}
+
+// Events subscribes to the events of the user, the context ends the stream as well as Close.
+func (c *Client) Events(ctx context.Context) (*EventStream, error) {
+	res, err := c.send(ctx, call{method: http.MethodGet, path: "/events", accept: "text/event-stream"})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventStream{body: res.Body, reader: bufio.NewReader(res.Body)}, nil
+}
+
+// Next blocks until the next event and skips the pings of the server. The events of the stream
+// have no CreatedAt, it isn't sent. Once the stream ends Next returns io.EOF.
+func (s *EventStream) Next() (domain.StreamEvent, error) {
+	var (
+		e    domain.StreamEvent
+		data []string
+	)
+
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return domain.StreamEvent{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if e.Name == "" && data == nil {
+				continue
+			}
+			e.Data = []byte(strings.Join(data, "\n"))
+			return e, nil
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "id":
+			e.ID, _ = strconv.ParseInt(value, 10, 64)
+		case "event":
+			e.Name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+}
+
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
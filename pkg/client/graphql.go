@@ -0,0 +1,51 @@
+package client
+
+import (
+	apigraphql "avito_shop/internal/api/graphql"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GraphQLError holds the errors of a GraphQL query, the query may have got a part of its data anyway.
+type GraphQLError struct {
+	Messages []string
+}
+
+func (e *GraphQLError) Error() string {
+	return "client: graphql: " + strings.Join(e.Messages, "; ")
+}
+
+// GraphQL runs the query and decodes its data into data. The query may hold mutations,
+// so it carries an idempotency key like the other calls changing state.
+func (c *Client) GraphQL(ctx context.Context, req apigraphql.Request, data any) error {
+	var out struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	err := c.do(ctx, call{method: http.MethodPost, path: "/graphql", body: req, out: &out, unsafe: true})
+	if err != nil {
+		return err
+	}
+
+	if data != nil && len(out.Data) > 0 {
+		if err = json.Unmarshal(out.Data, data); err != nil {
+			return fmt.Errorf("client: error while decoding graphql data: %w", err)
+		}
+	}
+
+	if len(out.Errors) > 0 {
+		gqlErr := &GraphQLError{}
+		for _, e := range out.Errors {
+			gqlErr.Messages = append(gqlErr.Messages, e.Message)
+		}
+		return gqlErr
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"context"
+	"net/http"
+)
+
+// Language returns the language the user chose, empty if the Accept-Language header decides.
+func (c *Client) Language(ctx context.Context) (domain.Language, error) {
+	var out types.LanguagePreference
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/preferences/language", out: &out}); err != nil {
+		return "", err
+	}
+
+	return out.Language, nil
+}
+
+// SetLanguage chooses the language of the user, the empty one clears the choice.
+func (c *Client) SetLanguage(ctx context.Context, lang domain.Language) error {
+	return c.do(ctx, call{
+		method: http.MethodPut,
+		path:   "/preferences/language",
+		body:   types.LanguagePreference{Language: lang},
+	})
+}
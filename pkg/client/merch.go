@@ -0,0 +1,17 @@
+package client
+
+import (
+	"avito_shop/internal/api/http/types"
+	"context"
+	"net/http"
+)
+
+// Merch returns the catalog with the names and descriptions in the language of the user.
+func (c *Client) Merch(ctx context.Context) ([]types.MerchItem, error) {
+	var out []types.MerchItem
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/merch", out: &out}); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
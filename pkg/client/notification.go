@@ -0,0 +1,131 @@
+package client
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Notifications returns the unread notifications with event ids greater than after.
+func (c *Client) Notifications(ctx context.Context, after domain.EventID) (*types.GetNotificationsResponse, error) {
+	var query url.Values
+	if after > 0 {
+		query = url.Values{"after": {strconv.FormatInt(after, 10)}}
+	}
+
+	var out types.GetNotificationsResponse
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/notifications", query: query, out: &out}); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// AckNotifications marks the notifications read and returns how many of them were unread.
+func (c *Client) AckNotifications(ctx context.Context, ids ...domain.EventID) (int, error) {
+	var out types.PostAckNotificationsResponse
+	err := c.do(ctx, call{
+		method: http.MethodPost,
+		path:   "/notifications/ack",
+		body:   types.PostAckNotificationsRequest{IDs: ids},
+		out:    &out,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return out.Acked, nil
+}
+
+// NotificationConn is the websocket the notifications are pushed over. Receive and Ack may run
+// concurrently with each other, but not with themselves.
+type NotificationConn struct {
+	conn *websocket.Conn
+}
+
+// ConnectNotifications opens the websocket, the unread notifications after lastEventID come first.
+// The connection doesn't go through the http.Client of the client and isn't retried.
+func (c *Client) ConnectNotifications(ctx context.Context, lastEventID domain.EventID) (*NotificationConn, error) {
+	u := c.baseURL + apiPath + "/ws"
+	u = "ws" + strings.TrimPrefix(u, "http")
+	if lastEventID > 0 {
+		u += "?" + url.Values{"lastEventId": {strconv.FormatInt(lastEventID, 10)}}.Encode()
+	}
+
+	for refreshed := false; ; refreshed = true {
+		token, err := c.tokenFor(ctx, call{})
+		if err != nil {
+			return nil, err
+		}
+
+		conn, res, err := websocket.DefaultDialer.DialContext(ctx, u, http.Header{"Authorization": {token}})
+		if err == nil {
+			return &NotificationConn{conn: conn}, nil
+		}
+		if res == nil {
+			return nil, fmt.Errorf("client: GET /ws: %w", err)
+		}
+
+		err = readError(res)
+		if res.StatusCode != http.StatusUnauthorized || refreshed || !c.canLogin() {
+			return nil, err
+		}
+		c.dropToken(token)
+	}
+}
+
+// Receive blocks until the next notification, the confirmations of the acks are skipped.
+func (n *NotificationConn) Receive() (*types.Notification, error) {
+	for {
+		_, raw, err := n.conn.ReadMessage()
+		if err != nil {
+			return nil, fmt.Errorf("client: notifications: %w", err)
+		}
+
+		var msg struct {
+			Type  string `json:"type"`
+			Error string `json:"error"`
+		}
+		if err = json.Unmarshal(raw, &msg); err != nil {
+			return nil, fmt.Errorf("client: notifications: %w", err)
+		}
+
+		switch msg.Type {
+		case types.WSMessageNotification:
+			var notification types.WSNotificationMessage
+			if err = json.Unmarshal(raw, &notification); err != nil {
+				return nil, fmt.Errorf("client: notifications: %w", err)
+			}
+			return &notification.Notification, nil
+		case types.WSMessageError:
+			return nil, fmt.Errorf("client: notifications: %w", errors.New(msg.Error))
+		}
+	}
+}
+
+// Ack marks the notifications read, the server confirms it asynchronously.
+func (n *NotificationConn) Ack(ids ...domain.EventID) error {
+	if err := n.conn.WriteJSON(types.WSClientMessage{Type: types.WSMessageAck, IDs: ids}); err != nil {
+		return fmt.Errorf("client: notifications: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the connection, telling the server first.
+func (n *NotificationConn) Close() error {
+	closing := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	_ = n.conn.WriteControl(websocket.CloseMessage, closing, time.Now().Add(time.Second))
+
+	return n.conn.Close()
+}
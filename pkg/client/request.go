@@ -0,0 +1,212 @@
+package client
+
+import (
+	pkgmiddleware "avito_shop/pkg/http/middleware"
+	resp "avito_shop/pkg/http/responses"
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// call is a request to one of the operations of the API.
+type call struct {
+	method string
+	path   string
+	query  url.Values
+	body   any
+	out    any
+	// accept is the media type of the response, JSON unless set
+	accept string
+	// unsafe calls change state every time they run, they carry an idempotency key
+	// so the retries run them once. GET /buy/{item} is one of them.
+	unsafe bool
+	// anonymous calls go without a token
+	anonymous bool
+}
+
+func (c *Client) do(ctx context.Context, cl call) error {
+	res, err := c.send(ctx, cl)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if cl.out == nil {
+		return nil
+	}
+
+	if err = json.NewDecoder(res.Body).Decode(cl.out); err != nil {
+		return fmt.Errorf("client: error while decoding %s %s response: %w", cl.method, cl.path, err)
+	}
+
+	return nil
+}
+
+// send makes the call until it succeeds, fails for good or runs out of retries. The response
+// returned has a 2xx status, a response with any other one is returned as *Error.
+func (c *Client) send(ctx context.Context, cl call) (*http.Response, error) {
+	var body []byte
+	if cl.body != nil {
+		var err error
+		if body, err = json.Marshal(cl.body); err != nil {
+			return nil, fmt.Errorf("client: error while encoding %s %s request: %w", cl.method, cl.path, err)
+		}
+	}
+
+	var key string
+	if cl.unsafe {
+		key = newIdempotencyKey()
+	}
+
+	refreshed := false
+	for attempt := 0; ; attempt++ {
+		token, err := c.tokenFor(ctx, cl)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.attempt(ctx, cl, body, key, token)
+		if err == nil && res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices {
+			return res, nil
+		}
+		if err == nil {
+			err = readError(res)
+		}
+
+		// a rejected token is refreshed once, that doesn't count as a retry
+		var apiErr *Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized &&
+			!cl.anonymous && !refreshed && c.canLogin() {
+			c.dropToken(token)
+			refreshed = true
+			attempt--
+			continue
+		}
+
+		delay, ok := c.retryDelay(err, attempt)
+		if !ok || ctx.Err() != nil {
+			return nil, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, cl call, body []byte, key, token string) (*http.Response, error) {
+	u := c.baseURL + apiPath + cl.path
+	if len(cl.query) > 0 {
+		u += "?" + cl.query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, cl.method, u, reader)
+	if err != nil {
+		return nil, fmt.Errorf("client: %s %s: %w", cl.method, cl.path, err)
+	}
+
+	accept := cl.accept
+	if accept == "" {
+		accept = "application/json"
+	}
+	req.Header.Set("Accept", accept)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	if key != "" {
+		req.Header.Set(pkgmiddleware.IdempotencyKeyHeader, key)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("client: %s %s: %w", cl.method, cl.path, err)
+	}
+
+	return res, nil
+}
+
+// retryDelay tells how long to wait before the retry of a failed attempt, ok is false when
+// a retry would fail the same way. Calls that didn't reach the server are always retried,
+// the unsafe ones are safe to retry thanks to their idempotency key.
+func (c *Client) retryDelay(err error, attempt int) (time.Duration, bool) {
+	if attempt >= c.retries {
+		return 0, false
+	}
+
+	delay := maxBackoff
+	if attempt < 32 && c.backoff<<attempt < maxBackoff {
+		delay = c.backoff << attempt
+	}
+	half := delay / 2
+	//nolint:gosec // jitter doesn't need a cryptographically secure source
+	delay = half + time.Duration(rand.Int64N(int64(half)+1))
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return delay, true
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if apiErr.RetryAfter > 0 {
+			delay = apiErr.RetryAfter
+		}
+		return delay, true
+	case http.StatusConflict:
+		// the first attempt is still running on the server
+		return delay, apiErr.Problem.Code == resp.CodeIdempotencyKeyInUse
+	default:
+		return 0, false
+	}
+}
+
+// readError turns a failed response into *Error, a body that isn't a problem details one,
+// e.g. the one of a proxy, is replaced with the status text.
+func readError(res *http.Response) error {
+	defer func() { _ = res.Body.Close() }()
+
+	apiErr := &Error{StatusCode: res.StatusCode}
+	if err := json.NewDecoder(res.Body).Decode(&apiErr.Problem); err != nil || apiErr.Problem.Code == "" {
+		apiErr.Problem = resp.ErrorResponse{
+			Type:   "about:blank",
+			Title:  http.StatusText(res.StatusCode),
+			Status: res.StatusCode,
+			Detail: http.StatusText(res.StatusCode),
+		}
+	}
+
+	if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	return apiErr
+}
+
+func newIdempotencyKey() string {
+	var key [16]byte
+	_, _ = crand.Read(key[:])
+
+	return hex.EncodeToString(key[:])
+}
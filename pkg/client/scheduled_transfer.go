@@ -0,0 +1,49 @@
+package client
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// ScheduleTransfer sends coins at StartAt and then every Interval, e.g. "168h", if it is set.
+func (c *Client) ScheduleTransfer(
+	ctx context.Context,
+	req types.PostScheduledTransferRequest,
+) (*types.ScheduledTransfer, error) {
+	var out types.ScheduledTransfer
+	err := c.do(ctx, call{method: http.MethodPost, path: "/scheduledTransfers", body: req, out: &out, unsafe: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (c *Client) ScheduledTransfers(ctx context.Context) (*types.GetScheduledTransfersResponse, error) {
+	var out types.GetScheduledTransfersResponse
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/scheduledTransfers", out: &out}); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (c *Client) CancelScheduledTransfer(ctx context.Context, id domain.ScheduledTransferID) error {
+	return c.do(ctx, call{method: http.MethodDelete, path: "/scheduledTransfers/" + strconv.Itoa(id)})
+}
+
+func (c *Client) ScheduledTransferRuns(
+	ctx context.Context,
+	id domain.ScheduledTransferID,
+) (*types.GetScheduledTransferRunsResponse, error) {
+	var out types.GetScheduledTransferRunsResponse
+	err := c.do(ctx, call{method: http.MethodGet, path: "/scheduledTransfers/" + strconv.Itoa(id) + "/runs", out: &out})
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
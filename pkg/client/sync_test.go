@@ -0,0 +1,164 @@
+package client
+
+import (
+	apigraphql "avito_shop/internal/api/graphql"
+	"avito_shop/internal/api/http/types"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// swaggerDocs are generated by `make generate_docs` together with the yaml ones.
+var swaggerDocs = []string{"../../docs/swagger.json", "../../docs/v2/v2_swagger.json"}
+
+func documentedOperations(t *testing.T, path string) []string {
+	t.Helper()
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &doc))
+
+	var ops []string
+	for route, methods := range doc.Paths {
+		for method := range methods {
+			ops = append(ops, strings.ToUpper(method)+" "+route)
+		}
+	}
+
+	return ops
+}
+
+// TestClientCoversAPI calls every method of the client against a server serving the documented
+// operations, an operation no method calls is one the client is missing.
+func TestClientCoversAPI(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		called = make(map[string]bool)
+	)
+	upgrader := websocket.Upgrader{}
+
+	documented := make(map[string]bool)
+	for _, doc := range swaggerDocs {
+		for _, op := range documentedOperations(t, doc) {
+			documented[op] = true
+		}
+	}
+
+	r := chi.NewRouter()
+	r.Post(apiPath+"/auth", func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		called["POST /auth"] = true
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"token":"t1"}`))
+	})
+	for op := range documented {
+		method, route, _ := strings.Cut(op, " ")
+		if op == "POST /auth" {
+			continue
+		}
+
+		r.Method(method, apiPath+route, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			called[op] = true
+			mu.Unlock()
+
+			switch route {
+			case "/ws":
+				conn, err := upgrader.Upgrade(w, req, nil)
+				if assert.NoError(t, err) {
+					_ = conn.Close()
+				}
+			case "/events":
+				w.Header().Set("Content-Type", "text/event-stream")
+			default:
+				_, _ = w.Write([]byte("null"))
+			}
+		}))
+	}
+
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithCredentials("alice", "secret"))
+	ctx := context.Background()
+
+	calls := []func() error{
+		func() error { _, err := c.Auth(ctx, "alice", "secret"); return err },
+		func() error { _, err := c.Info(ctx); return err },
+		func() error { _, err := c.Language(ctx); return err },
+		func() error { return c.SetLanguage(ctx, "ru") },
+		func() error { return c.SendCoin(ctx, types.PostSendCoinRequest{ToUser: "bob", Amount: 1}) },
+		func() error { return c.Buy(ctx, "cup") },
+		func() error { _, err := c.Merch(ctx); return err },
+		func() error {
+			_, err := c.RequestCoins(ctx, types.PostCoinRequestRequest{FromUser: "bob", Amount: 1})
+			return err
+		},
+		func() error { _, err := c.IncomingCoinRequests(ctx); return err },
+		func() error { _, err := c.OutgoingCoinRequests(ctx); return err },
+		func() error { return c.AcceptCoinRequest(ctx, 1) },
+		func() error { return c.DeclineCoinRequest(ctx, 1) },
+		func() error { _, err := c.ScheduleTransfer(ctx, types.PostScheduledTransferRequest{}); return err },
+		func() error { _, err := c.ScheduledTransfers(ctx); return err },
+		func() error { return c.CancelScheduledTransfer(ctx, 1) },
+		func() error { _, err := c.ScheduledTransferRuns(ctx, 1); return err },
+		func() error { _, err := c.Notifications(ctx, 1); return err },
+		func() error { _, err := c.AckNotifications(ctx, 1); return err },
+		func() error { _, err := c.EmailPreferences(ctx); return err },
+		func() error { return c.SetEmailPreferences(ctx, types.EmailPreferences{}) },
+		func() error { return c.GraphQL(ctx, apigraphql.Request{Query: "{ me { coins } }"}, nil) },
+		func() error { _, err := c.Mint(ctx, types.PostMintRequest{}); return err },
+		func() error { _, err := c.Burn(ctx, types.PostBurnRequest{}); return err },
+		func() error { _, err := c.GrantAllowance(ctx); return err },
+		func() error {
+			_, err := c.ReverseTransaction(ctx, types.PostReverseTransactionRequest{ID: 1})
+			return err
+		},
+		func() error { _, err := c.VerifyLedger(ctx); return err },
+		func() error { _, err := c.Reconcile(ctx); return err },
+		func() error { _, err := c.LatestReconciliation(ctx); return err },
+		func() error { _, err := c.ApplyReconciliation(ctx, 1); return err },
+		func() error { _, err := c.CreateWebhook(ctx, types.PostWebhookRequest{}); return err },
+		func() error { _, err := c.Webhooks(ctx); return err },
+		func() error { return c.DeleteWebhook(ctx, 1) },
+		func() error { _, err := c.WebhookDeliveries(ctx, 1); return err },
+		func() error { _, err := c.RedeliverWebhook(ctx, 1); return err },
+		func() error {
+			stream, err := c.Events(ctx)
+			if err != nil {
+				return err
+			}
+			return stream.Close()
+		},
+		func() error {
+			conn, err := c.ConnectNotifications(ctx, 1)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+	}
+	for _, call := range calls {
+		require.NoError(t, call())
+	}
+
+	for op := range documented {
+		require.True(t, called[op], "%s has no client method", op)
+	}
+}
@@ -0,0 +1,18 @@
+package client
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"context"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) SendCoin(ctx context.Context, req types.PostSendCoinRequest) error {
+	return c.do(ctx, call{method: http.MethodPost, path: "/sendCoin", body: req, unsafe: true})
+}
+
+// Buy buys one item of the merch, it is a GET but buys one more item every time it runs.
+func (c *Client) Buy(ctx context.Context, item domain.MerchName) error {
+	return c.do(ctx, call{method: http.MethodGet, path: "/buy/" + url.PathEscape(item), unsafe: true})
+}
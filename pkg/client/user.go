@@ -0,0 +1,17 @@
+package client
+
+import (
+	apihttpv2 "avito_shop/internal/api/http/v2"
+	"context"
+	"net/http"
+)
+
+// Info returns the coins, the inventory and the coin history of the user.
+func (c *Client) Info(ctx context.Context) (*apihttpv2.GetInfoResponse, error) {
+	var out apihttpv2.GetInfoResponse
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/info", out: &out}); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
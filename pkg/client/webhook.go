@@ -0,0 +1,67 @@
+package client
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// The webhook calls need the token of an admin user.
+
+func (c *Client) CreateWebhook(ctx context.Context, req types.PostWebhookRequest) (*types.Webhook, error) {
+	var out types.Webhook
+	err := c.do(ctx, call{method: http.MethodPost, path: "/admin/webhooks", body: req, out: &out, unsafe: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (c *Client) Webhooks(ctx context.Context) (*types.GetWebhooksResponse, error) {
+	var out types.GetWebhooksResponse
+	if err := c.do(ctx, call{method: http.MethodGet, path: "/admin/webhooks", out: &out}); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// DeleteWebhook deletes the subscription together with its deliveries.
+func (c *Client) DeleteWebhook(ctx context.Context, id domain.WebhookID) error {
+	return c.do(ctx, call{method: http.MethodDelete, path: "/admin/webhooks/" + strconv.Itoa(id)})
+}
+
+func (c *Client) WebhookDeliveries(
+	ctx context.Context,
+	id domain.WebhookID,
+) (*types.GetWebhookDeliveriesResponse, error) {
+	var out types.GetWebhookDeliveriesResponse
+	err := c.do(ctx, call{method: http.MethodGet, path: "/admin/webhooks/" + strconv.Itoa(id) + "/deliveries", out: &out})
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// RedeliverWebhook creates a new delivery of the event of the delivery id, it is sent asynchronously.
+func (c *Client) RedeliverWebhook(
+	ctx context.Context,
+	id domain.WebhookDeliveryID,
+) (*types.WebhookDelivery, error) {
+	var out types.WebhookDelivery
+	err := c.do(ctx, call{
+		method: http.MethodPost,
+		path:   "/admin/webhooks/deliveries/" + strconv.FormatInt(id, 10) + "/redeliver",
+		out:    &out,
+		unsafe: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
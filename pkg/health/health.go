@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp           = "up"
+	StatusDown         = "down"
+	StatusReady        = "ready"
+	StatusNotReady     = "not ready"
+	StatusShuttingDown = "shutting down"
+)
+
+// Check reports whether a dependency is reachable, it must return once ctx is done.
+type Check func(ctx context.Context) error
+
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Checker decides whether the instance may receive traffic. It is ready while every check passes
+// within the timeout and until Drain is called.
+type Checker struct {
+	checks   map[string]Check
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+func NewChecker(timeout time.Duration, checks map[string]Check) *Checker {
+	return &Checker{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// Drain makes the instance report not ready for good, so load balancers stop sending it traffic
+// while the requests already in flight are finished.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Ready runs the checks concurrently, each one under its own timeout.
+func (c *Checker) Ready(ctx context.Context) (Report, bool) {
+	if c.draining.Load() {
+		return Report{Status: StatusShuttingDown}, false
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		report = Report{Status: StatusReady, Checks: make(map[string]CheckResult, len(c.checks))}
+	)
+
+	for name, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			res := CheckResult{Status: StatusUp}
+			if err := check(checkCtx); err != nil {
+				res = CheckResult{Status: StatusDown, Error: err.Error()}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = res
+			if res.Status == StatusDown {
+				report.Status = StatusNotReady
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report, report.Status == StatusReady
+}
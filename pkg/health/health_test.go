@@ -0,0 +1,62 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_Ready(t *testing.T) {
+	t.Parallel()
+
+	checker := NewChecker(time.Second, map[string]Check{
+		"postgres": func(context.Context) error { return nil },
+		"redis":    func(context.Context) error { return nil },
+	})
+
+	report, ok := checker.Ready(context.Background())
+	require.True(t, ok)
+	require.Equal(t, Report{
+		Status: StatusReady,
+		Checks: map[string]CheckResult{
+			"postgres": {Status: StatusUp},
+			"redis":    {Status: StatusUp},
+		},
+	}, report)
+}
+
+func TestChecker_DependencyDown(t *testing.T) {
+	t.Parallel()
+
+	checker := NewChecker(50*time.Millisecond, map[string]Check{
+		"postgres": func(context.Context) error { return errors.New("connection refused") },
+		// a hanging dependency must not hang the probe
+		"redis": func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	report, ok := checker.Ready(context.Background())
+	require.False(t, ok)
+	require.Equal(t, StatusNotReady, report.Status)
+	require.Equal(t, CheckResult{Status: StatusDown, Error: "connection refused"}, report.Checks["postgres"])
+	require.Equal(t, StatusDown, report.Checks["redis"].Status)
+	require.Contains(t, report.Checks["redis"].Error, context.DeadlineExceeded.Error())
+}
+
+func TestChecker_Drain(t *testing.T) {
+	t.Parallel()
+
+	checker := NewChecker(time.Second, map[string]Check{
+		"postgres": func(context.Context) error { return nil },
+	})
+	checker.Drain()
+
+	report, ok := checker.Ready(context.Background())
+	require.False(t, ok)
+	require.Equal(t, Report{Status: StatusShuttingDown}, report)
+}
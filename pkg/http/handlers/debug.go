@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type buildInfo struct {
+	GoVersion string            `json:"go_version"`
+	Path      string            `json:"path"`
+	Version   string            `json:"version"`
+	Settings  map[string]string `json:"settings"`
+}
+
+type runtimeStats struct {
+	GoVersion    string  `json:"go_version"`
+	Uptime       string  `json:"uptime"`
+	NumCPU       int     `json:"num_cpu"`
+	GOMAXPROCS   int     `json:"gomaxprocs"`
+	NumGoroutine int     `json:"num_goroutine"`
+	HeapAlloc    uint64  `json:"heap_alloc_bytes"`
+	HeapInuse    uint64  `json:"heap_inuse_bytes"`
+	HeapObjects  uint64  `json:"heap_objects"`
+	Sys          uint64  `json:"sys_bytes"`
+	NumGC        uint32  `json:"num_gc"`
+	PauseTotal   string  `json:"gc_pause_total"`
+	GCCPUPercent float64 `json:"gc_cpu_percent"`
+}
+
+// WithDebugHandlers serves pprof under /pprof, expvar under /vars, the build info under /build
+// and the runtime stats under /runtime. They expose the internals of the process and must only
+// be reachable by operators.
+func WithDebugHandlers(started time.Time) RouterOption {
+	return func(r chi.Router) {
+		r.Mount("/", middleware.Profiler())
+		r.Get("/build", getBuildInfo)
+		r.Get("/runtime", func(w http.ResponseWriter, r *http.Request) {
+			getRuntimeStats(w, r, started)
+		})
+	}
+}
+
+func getBuildInfo(w http.ResponseWriter, r *http.Request) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		render.Status(r, http.StatusNotFound)
+		render.PlainText(w, r, "build info is not available")
+		return
+	}
+
+	settings := make(map[string]string, len(info.Settings))
+	for _, s := range info.Settings {
+		settings[s.Key] = s.Value
+	}
+
+	render.JSON(w, r, buildInfo{
+		GoVersion: info.GoVersion,
+		Path:      info.Main.Path,
+		Version:   info.Main.Version,
+		Settings:  settings,
+	})
+}
+
+func getRuntimeStats(w http.ResponseWriter, r *http.Request, started time.Time) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	const percent = 100
+
+	render.JSON(w, r, runtimeStats{
+		GoVersion:    runtime.Version(),
+		Uptime:       time.Since(started).Round(time.Second).String(),
+		NumCPU:       runtime.NumCPU(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		NumGoroutine: runtime.NumGoroutine(),
+		HeapAlloc:    mem.HeapAlloc,
+		HeapInuse:    mem.HeapInuse,
+		HeapObjects:  mem.HeapObjects,
+		Sys:          mem.Sys,
+		NumGC:        mem.NumGC,
+		PauseTotal:   time.Duration(mem.PauseTotalNs).String(), //nolint:gosec // the total pause fits in int64
+		GCCPUPercent: mem.GCCPUFraction * percent,
+	})
+}
@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"avito_shop/pkg/health"
 	pkgmiddleware "avito_shop/pkg/http/middleware"
 	"avito_shop/pkg/http/responses"
+	"avito_shop/pkg/i18n"
+	"avito_shop/pkg/idempotency"
+	"avito_shop/pkg/ratelimit"
 	"log/slog"
 	"net/http"
 
@@ -44,6 +48,11 @@ func Converter(h Handler) http.HandlerFunc {
 }
 
 func WriteResponse(w http.ResponseWriter, r *http.Request, response responses.Response) {
+	if problem, ok := response.(*responses.ErrorResponse); ok {
+		responses.WriteProblem(w, r, problem)
+		return
+	}
+
 	render.Status(r, response.StatusCode())
 	render.JSON(w, r, response.GetPayload())
 }
@@ -62,23 +71,60 @@ func RouterOptions(options ...RouterOption) func(chi.Router) {
 	}
 }
 
-func WithHealthHandler() RouterOption {
+// WithHealthHandlers serves the probes. /health/live only tells the process still serves requests,
+// /health/ready also checks the dependencies, /health is kept as the liveness probe it used to be.
+func WithHealthHandlers(checker *health.Checker) RouterOption {
+	live := func(w http.ResponseWriter, r *http.Request) {
+		render.Status(r, http.StatusOK)
+		render.PlainText(w, r, "OK")
+	}
+
+	ready := func(w http.ResponseWriter, r *http.Request) {
+		report, ok := checker.Ready(r.Context())
+		if !ok {
+			render.Status(r, http.StatusServiceUnavailable)
+		}
+		render.JSON(w, r, report)
+	}
+
 	return func(r chi.Router) {
-		r.Mount("/health", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			render.Status(r, http.StatusOK)
-			render.PlainText(w, r, "OK")
-		}))
+		r.Get("/health", live)
+		r.Get("/health/live", live)
+		r.Get("/health/ready", ready)
 	}
 }
 
-func WithSwagger() RouterOption {
+// WithSwagger serves the docs registered by swag under the instance name, every API version has its own.
+func WithSwagger(instance string) RouterOption {
 	return func(r chi.Router) {
 		r.Get("/docs/*", httpSwagger.Handler(
 			httpSwagger.URL("docs/doc.json"),
+			httpSwagger.InstanceName(instance),
 		))
 	}
 }
 
+// WithVersion serves a version of the API under the prefix. The empty prefix serves it
+// from the base path itself, as the unversioned v1 is served.
+func WithVersion(prefix string, opts ...RouterOption) RouterOption {
+	if prefix == "" {
+		return func(r chi.Router) {
+			r.Group(RouterOptions(opts...))
+		}
+	}
+
+	return func(r chi.Router) {
+		r.Route(prefix, RouterOptions(opts...))
+	}
+}
+
+// WithDeprecation must go first in WithVersion, before any route of the version is added.
+func WithDeprecation(d pkgmiddleware.Deprecation) RouterOption {
+	return func(r chi.Router) {
+		r.Use(pkgmiddleware.NewDeprecationMiddleware(d))
+	}
+}
+
 func WithLogging(logger *slog.Logger) RouterOption {
 	return func(r chi.Router) {
 		r.Use(pkgmiddleware.NewLoggingMiddleware(logger))
@@ -91,14 +137,65 @@ func WithRecover() RouterOption {
 	}
 }
 
-func WithProfilerHandlers() RouterOption {
-	return func(_ chi.Router) {
-		middleware.Profiler()
+func WithRequestID() RouterOption {
+	return func(r chi.Router) {
+		r.Use(middleware.RequestID)
 	}
 }
 
-func WithRequestID() RouterOption {
+// WithMetrics must go before WithRecover, so a recovered panic is observed as the 500 it is answered with.
+func WithMetrics() RouterOption {
 	return func(r chi.Router) {
-		r.Use(middleware.RequestID)
+		r.Use(pkgmiddleware.NewMetricsMiddleware())
+	}
+}
+
+// WithTracing must go before WithRecover as well, and before WithLogging so the log entries carry the trace.
+func WithTracing() RouterOption {
+	return func(r chi.Router) {
+		r.Use(pkgmiddleware.NewTracingMiddleware())
+	}
+}
+
+func WithMiddlewares(middlewares ...func(http.Handler) http.Handler) RouterOption {
+	return func(r chi.Router) {
+		r.Use(middlewares...)
+	}
+}
+
+// WithLanguage must go after the middlewares preference depends on, e.g. the one identifying the user.
+func WithLanguage(bundle *i18n.Bundle, preference pkgmiddleware.LanguagePreferenceFunc) RouterOption {
+	return func(r chi.Router) {
+		r.Use(pkgmiddleware.NewLanguageMiddleware(bundle, preference))
+	}
+}
+
+// WithRateLimit must go after the middlewares key depends on, e.g. the one identifying the user.
+func WithRateLimit(
+	log *slog.Logger,
+	limiter ratelimit.Limiter,
+	key pkgmiddleware.RateLimitKeyFunc,
+	cfg ratelimit.Config,
+) RouterOption {
+	return func(r chi.Router) {
+		if !cfg.Enabled {
+			return
+		}
+		r.Use(pkgmiddleware.NewRateLimitMiddleware(log, limiter, key, r, cfg))
+	}
+}
+
+// WithIdempotency must go after the middlewares scope depends on, e.g. the one identifying the user.
+func WithIdempotency(
+	log *slog.Logger,
+	store idempotency.Store,
+	scope pkgmiddleware.IdempotencyScopeFunc,
+	cfg idempotency.Config,
+) RouterOption {
+	return func(r chi.Router) {
+		if !cfg.Enabled {
+			return
+		}
+		r.Use(pkgmiddleware.NewIdempotencyMiddleware(log, store, scope, cfg))
 	}
 }
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Deprecation describes routes kept for old clients. Successor is the path prefix the routes moved to,
+// the route path of the request is appended to it, e.g. "/api/v2" links /api/info to /api/v2/info.
+type Deprecation struct {
+	Since     time.Time
+	Sunset    time.Time
+	Successor string
+}
+
+// NewDeprecationMiddleware announces the deprecation with the Deprecation header of RFC 9745,
+// the Sunset header of RFC 8594 once the sunset is known, and a successor-version link.
+func NewDeprecationMiddleware(d Deprecation) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Set("Deprecation", fmt.Sprintf("@%d", d.Since.Unix()))
+			if !d.Sunset.IsZero() {
+				h.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+			}
+
+			if d.Successor != "" {
+				successor := d.Successor
+				if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "/" {
+					successor += rctx.RoutePath
+				}
+				h.Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"avito_shop/pkg/http/responses"
+	"avito_shop/pkg/idempotency"
+	pkglog "avito_shop/pkg/log"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// replayedHeaders are the headers of the saved response sent again with it, the rest
+// are set anew by the middlewares on the way, e.g. the rate limit ones.
+var replayedHeaders = []string{"Content-Type", "Content-Language"}
+
+var (
+	errIdempotencyKeyTooLong = errors.New("idempotency key is too long")
+	errIdempotencyKeyInUse   = errors.New("a request with the idempotency key is still in flight, retry later")
+	errIdempotencyKeyReused  = errors.New("the idempotency key was used for another request")
+)
+
+// IdempotencyScopeFunc tells whose keys a request uses, two clients may pick the same key.
+type IdempotencyScopeFunc func(r *http.Request) string
+
+// NewIdempotencyMiddleware runs a request sent with an Idempotency-Key header once, the retries
+// with the key get the saved response with Idempotent-Replayed set. The key is taken on any method,
+// as GET /buy/{item} changes state as well. A response with a 5xx status is not saved, the retry
+// runs the request again. When the store fails the request runs as if it had no key.
+func NewIdempotencyMiddleware(
+	log *slog.Logger,
+	store idempotency.Store,
+	scope IdempotencyScopeFunc,
+	cfg idempotency.Config,
+) func(next http.Handler) http.Handler {
+	m := &idempotencyMiddleware{
+		logger: log.With(slog.String("component", "middleware/idempotency")),
+		store:  store,
+		cfg:    cfg,
+	}
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				responses.WriteProblem(w, r, responses.BadRequest(errIdempotencyKeyTooLong))
+				return
+			}
+
+			fingerprint, err := requestFingerprint(r)
+			if err != nil {
+				responses.WriteProblem(w, r, responses.BadRequest(err))
+				return
+			}
+
+			key = scope(r) + ":" + key
+
+			saved, err := store.Reserve(r.Context(), key, cfg.Lease)
+			switch {
+			case errors.Is(err, idempotency.ErrInFlight):
+				responses.WriteProblem(w, r, responses.IdempotencyKeyInUse(errIdempotencyKeyInUse))
+			case err != nil:
+				m.logger.ErrorContext(r.Context(), "idempotency store failed, request runs without key", pkglog.Err(err))
+				next.ServeHTTP(w, r)
+			case saved != nil && saved.Fingerprint != fingerprint:
+				responses.WriteProblem(w, r, responses.IdempotencyKeyReused(errIdempotencyKeyReused))
+			case saved != nil:
+				replay(w, saved)
+			default:
+				m.serveOnce(w, r, next, key, fingerprint)
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+type idempotencyMiddleware struct {
+	logger *slog.Logger
+	store  idempotency.Store
+	cfg    idempotency.Config
+}
+
+// serveOnce runs the request holding the key and saves its response, the key is released
+// if the request fails or panics.
+func (m *idempotencyMiddleware) serveOnce(
+	w http.ResponseWriter,
+	r *http.Request,
+	next http.Handler,
+	key, fingerprint string,
+) {
+	// the response is kept even if the client is gone, its retry is what the key is for
+	ctx := context.WithoutCancel(r.Context())
+
+	saved := false
+	defer func() {
+		if saved {
+			return
+		}
+		if err := m.store.Release(ctx, key); err != nil {
+			m.logger.ErrorContext(ctx, "error while releasing idempotency key", pkglog.Err(err))
+		}
+	}()
+
+	var body bytes.Buffer
+	ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+	ww.Tee(&body)
+
+	next.ServeHTTP(ww, r)
+
+	status := ww.Status()
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if status >= http.StatusInternalServerError {
+		return
+	}
+
+	resp := idempotency.Response{
+		Fingerprint: fingerprint,
+		Status:      status,
+		Header:      make(http.Header),
+		Body:        body.Bytes(),
+	}
+	for _, name := range replayedHeaders {
+		if v := ww.Header().Values(name); len(v) > 0 {
+			resp.Header[name] = v
+		}
+	}
+
+	if err := m.store.Save(ctx, key, resp, m.cfg.TTL); err != nil {
+		m.logger.ErrorContext(ctx, "error while saving idempotent response", pkglog.Err(err))
+		return
+	}
+	saved = true
+}
+
+// requestFingerprint hashes what makes a request the same one, the body is read and put back.
+func requestFingerprint(r *http.Request) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	h := sha256.New()
+	_, _ = io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	_, _ = h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func replay(w http.ResponseWriter, saved *idempotency.Response) {
+	for name, v := range saved.Header {
+		w.Header()[name] = v
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(saved.Status)
+	_, _ = w.Write(saved.Body)
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"avito_shop/pkg/i18n"
+	"net/http"
+)
+
+// LanguagePreferenceFunc returns the language the client chose to be answered in, or "" when there is none.
+type LanguagePreferenceFunc func(r *http.Request) string
+
+// NewLanguageMiddleware puts a localizer into the request context. The client's own preference
+// wins over Accept-Language, both are only looked at once something is translated.
+func NewLanguageMiddleware(
+	bundle *i18n.Bundle,
+	preference LanguagePreferenceFunc,
+) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Language")
+
+			localizer := i18n.NewLocalizer(bundle, func() string {
+				var preferred string
+				if preference != nil {
+					preferred = preference(r)
+				}
+
+				return bundle.Match(preferred, r.Header.Get("Accept-Language"))
+			})
+
+			next.ServeHTTP(w, r.WithContext(i18n.WithLocalizer(r.Context(), localizer)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"avito_shop/pkg/http/responses"
+	pkglog "avito_shop/pkg/log"
+	"avito_shop/pkg/ratelimit"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var errRateLimited = errors.New("too many requests, retry later")
+
+// RateLimitKeyFunc tells whose bucket a request takes its token from.
+type RateLimitKeyFunc func(r *http.Request) string
+
+// NewRateLimitMiddleware limits the requests of every key with a token bucket. It runs before
+// the routes are matched, so it resolves the route pattern on routes itself to find an override.
+// Every route with an override has its own buckets, the rest share the default ones.
+// When the limiter fails the request is let through, an unavailable Redis must not take the API down.
+func NewRateLimitMiddleware(
+	log *slog.Logger,
+	limiter ratelimit.Limiter,
+	key RateLimitKeyFunc,
+	routes chi.Routes,
+	cfg ratelimit.Config,
+) func(next http.Handler) http.Handler {
+	logger := log.With(slog.String("component", "middleware/ratelimit"))
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			bucket, limit := "default", cfg.Default()
+			if route := findRoute(r, routes); route != "" {
+				if override, ok := cfg.Routes[route]; ok {
+					bucket, limit = route, override
+				}
+			}
+
+			res, err := limiter.Allow(r.Context(), key(r)+":"+bucket, limit)
+			if err != nil {
+				logger.ErrorContext(r.Context(), "rate limiter failed, request let through", pkglog.Err(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+			h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+
+			if !res.Allowed {
+				h.Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+
+				responses.WriteProblem(w, r, responses.TooManyRequests(errRateLimited))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// findRoute returns the full pattern of the route the request goes to, e.g. "POST /api/buy/{item}".
+func findRoute(r *http.Request, routes chi.Routes) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return ""
+	}
+
+	// the routes belong to the router the middleware is used on, they only know the rest of the path
+	pattern := routes.Find(chi.NewRouteContext(), r.Method, rctx.RoutePath)
+	if pattern == "" {
+		return ""
+	}
+
+	return r.Method + " " + strings.TrimSuffix(rctx.RoutePattern(), "/*") + pattern
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -1,6 +1,12 @@
 package responses
 
-import "net/http"
+import (
+	"avito_shop/pkg/i18n"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
 
 type Response interface {
 	StatusCode() int
@@ -27,58 +33,124 @@ func OK(payload any) *BasicResponse {
 	}
 }
 
+// ProblemContentType is the media type of the RFC 7807 error bodies.
+const ProblemContentType = "application/problem+json"
+
+// Stable error codes of the generic failures, the domain errors have codes of their own.
+const (
+	CodeBadRequest       = "BAD_REQUEST"
+	CodeNotFound         = "NOT_FOUND"
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	CodeUnauthorized     = "UNAUTHORIZED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeTooManyRequests  = "TOO_MANY_REQUESTS"
+	CodeInternal         = "INTERNAL"
+
+	CodeIdempotencyKeyInUse  = "IDEMPOTENCY_KEY_IN_USE"
+	CodeIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+)
+
+// ErrorResponse is an RFC 7807 problem details body. Code is the stable reason clients can switch on,
+// Detail is meant for humans and may change. Message repeats Detail under the "errors" key
+// the clients of the original API read.
 type ErrorResponse struct {
-	Message    string `json:"errors"`
-	err        error
-	statusCode int
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	RequestID string `json:"requestId,omitempty"`
+	Message   string `json:"errors"`
+	err       error
 }
 
 func (r ErrorResponse) StatusCode() int {
-	return r.statusCode
+	return r.Status
 }
 
 func (r ErrorResponse) GetPayload() any {
 	return r
 }
 
-func BadRequest(err error) *ErrorResponse {
+// Problem builds the body of an error the client is allowed to see the message of.
+func Problem(status int, code string, err error) *ErrorResponse {
 	return &ErrorResponse{
-		statusCode: http.StatusBadRequest,
-		Message:    err.Error(),
-		err:        err,
+		Type:    "about:blank",
+		Title:   http.StatusText(status),
+		Status:  status,
+		Detail:  err.Error(),
+		Code:    code,
+		Message: err.Error(),
+		err:     err,
 	}
 }
 
-func NotFound(err error) *ErrorResponse {
-	return &ErrorResponse{
-		statusCode: http.StatusNotFound,
-		Message:    err.Error(),
-		err:        err,
+// DetailKey is the key of the translated detail of the error code in the i18n bundles.
+func DetailKey(code string) string {
+	return "errors." + code
+}
+
+// WriteProblem answers with the problem, filling in the path and the ID of the request it failed.
+// The detail is translated to the request's language, an untranslated one is left as is.
+func WriteProblem(w http.ResponseWriter, r *http.Request, problem *ErrorResponse) {
+	body := *problem
+	body.Instance = r.URL.Path
+	body.RequestID = middleware.GetReqID(r.Context())
+
+	if localizer := i18n.FromContext(r.Context()); localizer != nil {
+		body.Detail = localizer.T(DetailKey(body.Code), body.Detail)
+		body.Message = body.Detail
+		w.Header().Set("Content-Language", localizer.Language())
 	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(body.Status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func BadRequest(err error) *ErrorResponse {
+	return Problem(http.StatusBadRequest, CodeBadRequest, err)
+}
+
+func NotFound(err error) *ErrorResponse {
+	return Problem(http.StatusNotFound, CodeNotFound, err)
 }
 
 func MethodNotAllowed(err error) *ErrorResponse {
-	return &ErrorResponse{
-		statusCode: http.StatusMethodNotAllowed,
-		Message:    err.Error(),
-		err:        err,
-	}
+	return Problem(http.StatusMethodNotAllowed, CodeMethodNotAllowed, err)
 }
 
 const InternalError = "Internal server error"
 
 func Unknown(err error) *ErrorResponse {
 	return &ErrorResponse{
-		statusCode: http.StatusInternalServerError,
-		Message:    InternalError,
-		err:        err,
+		Type:    "about:blank",
+		Title:   http.StatusText(http.StatusInternalServerError),
+		Status:  http.StatusInternalServerError,
+		Detail:  InternalError,
+		Code:    CodeInternal,
+		Message: InternalError,
+		err:     err,
 	}
 }
 
+func Forbidden(err error) *ErrorResponse {
+	return Problem(http.StatusForbidden, CodeForbidden, err)
+}
+
 func Unauthorized(err error) *ErrorResponse {
-	return &ErrorResponse{
-		statusCode: http.StatusUnauthorized,
-		Message:    err.Error(),
-		err:        err,
-	}
+	return Problem(http.StatusUnauthorized, CodeUnauthorized, err)
+}
+
+func TooManyRequests(err error) *ErrorResponse {
+	return Problem(http.StatusTooManyRequests, CodeTooManyRequests, err)
+}
+
+func IdempotencyKeyInUse(err error) *ErrorResponse {
+	return Problem(http.StatusConflict, CodeIdempotencyKeyInUse, err)
+}
+
+func IdempotencyKeyReused(err error) *ErrorResponse {
+	return Problem(http.StatusUnprocessableEntity, CodeIdempotencyKeyReused, err)
 }
@@ -0,0 +1,82 @@
+package responses
+
+import (
+	"avito_shop/pkg/i18n"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteProblem(t *testing.T) {
+	t.Parallel()
+
+	var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteProblem(w, r, Problem(http.StatusConflict, "USER_EXISTS", errors.New("user already exist")))
+	})
+	h = middleware.RequestID(h)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+	require.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	require.Equal(t, map[string]any{
+		"type":      "about:blank",
+		"title":     "Conflict",
+		"status":    float64(http.StatusConflict),
+		"detail":    "user already exist",
+		"instance":  "/api/auth",
+		"code":      "USER_EXISTS",
+		"requestId": "req-1",
+		"errors":    "user already exist",
+	}, body)
+}
+
+func TestWriteProblem_Localized(t *testing.T) {
+	t.Parallel()
+
+	bundle, err := i18n.NewBundle(fstest.MapFS{
+		"en.json": {Data: []byte(`{}`)},
+		"ru.json": {Data: []byte(`{"errors.LOW_BALANCE": "Недостаточно монет"}`)},
+	}, "en", "ru")
+	require.NoError(t, err)
+
+	tests := []struct {
+		Name      string
+		Lang      string
+		Problem   *ErrorResponse
+		ExpDetail string
+	}{
+		{"Translated", "ru", Problem(http.StatusUnprocessableEntity, "LOW_BALANCE", errors.New("not enough coins")),
+			"Недостаточно монет"},
+		{"Default language keeps the detail", "en",
+			Problem(http.StatusUnprocessableEntity, "LOW_BALANCE", errors.New("not enough coins")), "not enough coins"},
+		{"No translation", "ru", BadRequest(errors.New("amount must be positive")), "amount must be positive"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/api/sendCoin", nil)
+		localizer := i18n.NewLocalizer(bundle, func() string { return test.Lang })
+		req = req.WithContext(i18n.WithLocalizer(req.Context(), localizer))
+
+		rec := httptest.NewRecorder()
+		WriteProblem(rec, req, test.Problem)
+
+		var body ErrorResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&body), test.Name)
+		require.Equal(t, test.ExpDetail, body.Detail, test.Name)
+		require.Equal(t, test.ExpDetail, body.Message, test.Name)
+		require.Equal(t, test.Lang, rec.Header().Get("Content-Language"), test.Name)
+	}
+}
@@ -0,0 +1,137 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// Bundle holds the messages of the supported languages, read from "<lang>.json" files of flat key-message maps.
+// The first language is the default one clients get when none of theirs is supported.
+type Bundle struct {
+	langs    []string
+	matcher  language.Matcher
+	messages map[string]map[string]string
+}
+
+func NewBundle(fsys fs.FS, langs ...string) (*Bundle, error) {
+	if len(langs) == 0 {
+		return nil, fmt.Errorf("i18n.NewBundle: no languages")
+	}
+
+	b := &Bundle{
+		langs:    langs,
+		messages: make(map[string]map[string]string, len(langs)),
+	}
+
+	tags := make([]language.Tag, 0, len(langs))
+	for _, lang := range langs {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("i18n.NewBundle: %w", err)
+		}
+		tags = append(tags, tag)
+
+		data, err := fs.ReadFile(fsys, lang+".json")
+		if err != nil {
+			return nil, fmt.Errorf("i18n.NewBundle: %w", err)
+		}
+
+		messages := make(map[string]string)
+		if err = json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("i18n.NewBundle: %s: %w", lang, err)
+		}
+		b.messages[lang] = messages
+	}
+
+	b.matcher = language.NewMatcher(tags)
+
+	return b, nil
+}
+
+func (b *Bundle) Default() string {
+	return b.langs[0]
+}
+
+func (b *Bundle) Languages() []string {
+	return b.langs
+}
+
+// Match picks the supported language for the client's preferences in order of priority,
+// each one is a language tag or a whole Accept-Language header. Empty preferences are skipped.
+func (b *Bundle) Match(prefs ...string) string {
+	for _, pref := range prefs {
+		if pref == "" {
+			continue
+		}
+
+		tags, _, err := language.ParseAcceptLanguage(pref)
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+
+		if _, i, confidence := b.matcher.Match(tags...); confidence != language.No {
+			return b.langs[i]
+		}
+	}
+
+	return b.Default()
+}
+
+// Message returns the message of lang, ok is false when the language has no message for the key.
+func (b *Bundle) Message(lang, key string) (string, bool) {
+	msg, ok := b.messages[lang][key]
+	return msg, ok
+}
+
+// Localizer translates for a single request. The language is only picked on the first translation,
+// so requests that never show a translated message don't pay for looking up the user's preference.
+type Localizer struct {
+	bundle   *Bundle
+	language func() string
+}
+
+func NewLocalizer(bundle *Bundle, pick func() string) *Localizer {
+	return &Localizer{
+		bundle:   bundle,
+		language: sync.OnceValue(pick),
+	}
+}
+
+// Language is empty for a nil localizer, i.e. when the request went around the language middleware.
+func (l *Localizer) Language() string {
+	if l == nil {
+		return ""
+	}
+
+	return l.language()
+}
+
+// T translates the message under key, fallback is returned as is when there is no translation.
+func (l *Localizer) T(key, fallback string) string {
+	if l == nil {
+		return fallback
+	}
+
+	if msg, ok := l.bundle.Message(l.Language(), key); ok {
+		return msg
+	}
+
+	return fallback
+}
+
+type localizerCtxKey struct{}
+
+func WithLocalizer(ctx context.Context, l *Localizer) context.Context {
+	return context.WithValue(ctx, localizerCtxKey{}, l)
+}
+
+// FromContext returns nil when the context has no localizer, a nil localizer leaves the messages untranslated.
+func FromContext(ctx context.Context) *Localizer {
+	l, _ := ctx.Value(localizerCtxKey{}).(*Localizer)
+	return l
+}
@@ -0,0 +1,105 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBundle(t *testing.T) *Bundle {
+	t.Helper()
+
+	fsys := fstest.MapFS{
+		"en.json": {Data: []byte(`{"greeting": "Hello"}`)},
+		"ru.json": {Data: []byte(`{"greeting": "Привет", "only.ru": "Только по-русски"}`)},
+	}
+
+	bundle, err := NewBundle(fsys, "en", "ru")
+	require.NoError(t, err)
+
+	return bundle
+}
+
+func TestNewBundle_Broken(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewBundle(fstest.MapFS{"en.json": {Data: []byte(`{"a": "b"}`)}}, "en", "ru")
+	require.Error(t, err)
+
+	_, err = NewBundle(fstest.MapFS{"en.json": {Data: []byte(`["a"]`)}}, "en")
+	require.Error(t, err)
+
+	_, err = NewBundle(fstest.MapFS{})
+	require.Error(t, err)
+}
+
+func TestBundle_Match(t *testing.T) {
+	t.Parallel()
+
+	bundle := newTestBundle(t)
+
+	tests := []struct {
+		Name  string
+		Prefs []string
+		Exp   string
+	}{
+		{"Nothing", nil, "en"},
+		{"Exact", []string{"ru"}, "ru"},
+		{"Region", []string{"ru-RU"}, "ru"},
+		{"Accept-Language with weights", []string{"de-DE,de;q=0.9,ru;q=0.8,en;q=0.7"}, "ru"},
+		{"Weights out of order", []string{"en;q=0.3, ru"}, "ru"},
+		{"Unsupported", []string{"de"}, "en"},
+		{"Malformed", []string{";;q=x"}, "en"},
+		{"Preference wins", []string{"en", "ru-RU,ru"}, "en"},
+		{"Empty preference skipped", []string{"", "ru-RU,ru"}, "ru"},
+		{"Unsupported preference skipped", []string{"de", "ru"}, "ru"},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.Exp, bundle.Match(test.Prefs...), test.Name)
+	}
+}
+
+func TestLocalizer_T(t *testing.T) {
+	t.Parallel()
+
+	bundle := newTestBundle(t)
+
+	picks := 0
+	l := NewLocalizer(bundle, func() string {
+		picks++
+		return "ru"
+	})
+
+	require.Equal(t, "Привет", l.T("greeting", "fallback"))
+	require.Equal(t, "fallback", l.T("missing", "fallback"))
+	require.Equal(t, "ru", l.Language())
+	require.Equal(t, 1, picks)
+
+	en := NewLocalizer(bundle, bundle.Default)
+	require.Equal(t, "fallback", en.T("only.ru", "fallback"))
+}
+
+func TestLocalizer_Lazy(t *testing.T) {
+	t.Parallel()
+
+	l := NewLocalizer(newTestBundle(t), func() string {
+		t.Fatal("the language is picked before anything is translated")
+		return ""
+	})
+
+	ctx := WithLocalizer(context.Background(), l)
+	require.Same(t, l, FromContext(ctx))
+}
+
+func TestLocalizer_Nil(t *testing.T) {
+	t.Parallel()
+
+	l := FromContext(context.Background())
+
+	require.Nil(t, l)
+	require.Equal(t, "fallback", l.T("greeting", "fallback"))
+	require.Empty(t, l.Language())
+}
@@ -0,0 +1,58 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	ModeMemory = "memory"
+	ModeRedis  = "redis"
+)
+
+// Config.TTL is how long the response to a key is kept for the retries, Lease is how long a request
+// holds its key before a retry may run it again, in case the replica running it died.
+type Config struct {
+	Enabled bool          `env:"IDEMPOTENCY_ENABLED" yaml:"enabled" env-default:"true"`
+	Mode    string        `env:"IDEMPOTENCY_MODE" yaml:"mode" env-default:"memory"`
+	TTL     time.Duration `env:"IDEMPOTENCY_TTL" yaml:"ttl" env-default:"24h"`
+	Lease   time.Duration `env:"IDEMPOTENCY_LEASE" yaml:"lease" env-default:"1m"`
+}
+
+var ErrInFlight = errors.New("idempotency: a request with the key is still in flight")
+
+// Response is what the first request with a key answered. Fingerprint tells the request apart
+// from another one sent with the same key by mistake.
+type Response struct {
+	Fingerprint string      `json:"fingerprint"`
+	Status      int         `json:"status"`
+	Header      http.Header `json:"header"`
+	Body        []byte      `json:"body"`
+}
+
+type Store interface {
+	// Reserve takes the key for a request. It returns the response when a request with the key
+	// was already answered and ErrInFlight while one is still running.
+	Reserve(ctx context.Context, key string, lease time.Duration) (*Response, error)
+	Save(ctx context.Context, key string, resp Response, ttl time.Duration) error
+	// Release frees the key of a request that failed, so a retry runs it again.
+	Release(ctx context.Context, key string) error
+}
+
+// NewStore builds the store chosen in cfg. The memory store only sees the retries that come
+// to the same replica, the redis one shares the keys between the replicas.
+func NewStore(cfg Config, client *redis.Client) (Store, error) {
+	switch cfg.Mode {
+	case ModeMemory:
+		return NewMemoryStore(), nil
+	case ModeRedis:
+		return NewRedisStore(client), nil
+	default:
+		return nil, fmt.Errorf("idempotency.NewStore: unknown mode %q", cfg.Mode)
+	}
+}
@@ -0,0 +1,69 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	saved, err := s.Reserve(ctx, "user:1:a", time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, saved)
+
+	_, err = s.Reserve(ctx, "user:1:a", time.Minute)
+	require.ErrorIs(t, err, ErrInFlight)
+
+	resp := Response{Fingerprint: "f", Status: http.StatusOK, Body: []byte("{}")}
+	require.NoError(t, s.Save(ctx, "user:1:a", resp, time.Hour))
+
+	saved, err = s.Reserve(ctx, "user:1:a", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, &resp, saved)
+
+	// a released key is free for the retry
+	_, err = s.Reserve(ctx, "user:1:b", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, s.Release(ctx, "user:1:b"))
+	saved, err = s.Reserve(ctx, "user:1:b", time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, saved)
+
+	// the lease of a request that never finished runs out
+	now = now.Add(2 * time.Minute)
+	saved, err = s.Reserve(ctx, "user:1:b", time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, saved)
+}
+
+func TestMemoryStore_SweepsExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	require.NoError(t, s.Save(ctx, "user:1:a", Response{}, time.Second))
+	require.NoError(t, s.Save(ctx, "user:1:b", Response{}, time.Hour))
+
+	now = now.Add(sweepInterval + time.Second)
+
+	_, err := s.Reserve(ctx, "user:1:c", time.Minute)
+	require.NoError(t, err)
+
+	require.NotContains(t, s.entries, "user:1:a")
+	require.Contains(t, s.entries, "user:1:b")
+	require.Contains(t, s.entries, "user:1:c")
+}
@@ -0,0 +1,77 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "idempotency:"
+
+// inFlight is what a key holds until its request is answered.
+const inFlight = ""
+
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{
+		client: client,
+	}
+}
+
+func (s *RedisStore) Reserve(ctx context.Context, key string, lease time.Duration) (*Response, error) {
+	reserved, err := s.client.SetNX(ctx, keyPrefix+key, inFlight, lease).Result()
+	if err != nil {
+		return nil, fmt.Errorf("RedisStore.Reserve: %w", err)
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	raw, err := s.client.Get(ctx, keyPrefix+key).Bytes()
+	// the key expired between the two calls, the retry of the client will find it free
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInFlight
+	}
+	if err != nil {
+		return nil, fmt.Errorf("RedisStore.Reserve: %w", err)
+	}
+
+	if string(raw) == inFlight {
+		return nil, ErrInFlight
+	}
+
+	var resp Response
+	if err = json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("RedisStore.Reserve: %w", err)
+	}
+
+	return &resp, nil
+}
+
+func (s *RedisStore) Save(ctx context.Context, key string, resp Response, ttl time.Duration) error {
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("RedisStore.Save: %w", err)
+	}
+
+	if err = s.client.Set(ctx, keyPrefix+key, raw, ttl).Err(); err != nil {
+		return fmt.Errorf("RedisStore.Save: %w", err)
+	}
+
+	return nil
+}
+
+func (s *RedisStore) Release(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, keyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("RedisStore.Release: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisStore(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	// two replicas share the keys
+	replicas := []*RedisStore{NewRedisStore(client), NewRedisStore(client)}
+	ctx := context.Background()
+
+	saved, err := replicas[0].Reserve(ctx, "user:1:a", time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, saved)
+
+	_, err = replicas[1].Reserve(ctx, "user:1:a", time.Minute)
+	require.ErrorIs(t, err, ErrInFlight)
+
+	resp := Response{
+		Fingerprint: "f",
+		Status:      http.StatusOK,
+		Header:      http.Header{"Content-Type": {"application/json"}},
+		Body:        []byte(`{"coins":1000}`),
+	}
+	require.NoError(t, replicas[0].Save(ctx, "user:1:a", resp, time.Hour))
+	require.Equal(t, time.Hour, server.TTL(keyPrefix+"user:1:a"))
+
+	saved, err = replicas[1].Reserve(ctx, "user:1:a", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, &resp, saved)
+
+	require.NoError(t, replicas[1].Release(ctx, "user:1:a"))
+	saved, err = replicas[0].Reserve(ctx, "user:1:a", time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, saved)
+
+	// the lease of a request that never finished runs out
+	server.FastForward(2 * time.Minute)
+	saved, err = replicas[1].Reserve(ctx, "user:1:a", time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, saved)
+}
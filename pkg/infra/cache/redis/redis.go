@@ -5,15 +5,24 @@ import (
 	pkglog "avito_shop/pkg/log"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
 const CacheAlwaysAlive = redis.KeepTTL
 
+var cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "cache_lookups_total",
+	Help: "Redis cache lookups by result: hit, miss or error.",
+}, []string{"result"})
+
 type Redis struct {
 	client *redis.Client
 	logger *slog.Logger
@@ -28,6 +37,11 @@ func NewRedisClient(cfg Config) (*redis.Client, error) {
 		ReadTimeout:  cfg.ReadTimeout,
 	})
 
+	// every command gets a span, so cache lookups show up in the request traces
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		return nil, err
+	}
+
 	const ctxTimeExceed = 10 * time.Second
 
 	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeExceed)
@@ -77,10 +91,18 @@ func (r *Redis) Get(ctx context.Context, key string, value interface{}) error {
 
 	val, err := r.client.Get(ctx, key).Result()
 	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			cacheLookups.WithLabelValues("miss").Inc()
+			return err
+		}
+
+		cacheLookups.WithLabelValues("error").Inc()
 		log.ErrorContext(ctx, "error while getting data", pkglog.Err(err))
 		return err
 	}
 
+	cacheLookups.WithLabelValues("hit").Inc()
+
 	err = json.Unmarshal([]byte(val), value)
 	if err != nil {
 		log.ErrorContext(ctx, "error while unmarshalling data", pkglog.Err(err))
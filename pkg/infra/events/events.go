@@ -0,0 +1,100 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Message is what a sink delivers. ID identifies the event across redeliveries,
+// so consumers can drop the duplicates at-least-once delivery produces.
+type Message struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type Sink interface {
+	// Publish returns nil only once the message is delivered, an error means it should be retried.
+	Publish(ctx context.Context, msg Message) error
+}
+
+// Listener hands every message published to a broker over to handle until ctx is cancelled.
+type Listener interface {
+	Listen(ctx context.Context, handle func(Message)) error
+}
+
+// FanoutSink publishes every message to all of its sinks. A message counts as delivered only
+// if every sink accepted it, so a retry publishes it to the sinks that took it once more.
+type FanoutSink struct {
+	sinks []Sink
+}
+
+func NewFanoutSink(sinks ...Sink) *FanoutSink {
+	return &FanoutSink{
+		sinks: sinks,
+	}
+}
+
+func (s *FanoutSink) Publish(ctx context.Context, msg Message) error {
+	var errs []error
+	for _, sink := range s.sinks {
+		if err := sink.Publish(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("FanoutSink.Publish: %w", err)
+	}
+
+	return nil
+}
+
+const (
+	SinkWebhook = "webhook"
+	SinkStdout  = "stdout"
+	SinkFile    = "file"
+	SinkMemory  = "memory"
+)
+
+// Config.Sink has no default, a deployment has to choose where its events go.
+type Config struct {
+	Sink           string        `env:"OUTBOX_SINK" yaml:"type" env-required:"true"`
+	WebhookURL     string        `env:"OUTBOX_WEBHOOK_URL" yaml:"webhook_url"`
+	WebhookTimeout time.Duration `env:"OUTBOX_WEBHOOK_TIMEOUT" yaml:"webhook_timeout" env-default:"5s"`
+	FilePath       string        `env:"OUTBOX_FILE_PATH" yaml:"file_path"`
+}
+
+// NewSink builds the sink chosen in cfg. The returned closer releases what the sink holds
+// (e.g. the file) and must be called once the relay is stopped.
+func NewSink(cfg Config) (Sink, io.Closer, error) {
+	switch cfg.Sink {
+	case SinkWebhook:
+		if cfg.WebhookURL == "" {
+			return nil, nil, fmt.Errorf("events.NewSink: webhook sink requires a URL")
+		}
+		return NewWebhookSink(cfg.WebhookURL, cfg.WebhookTimeout), nopCloser{}, nil
+	case SinkStdout:
+		return NewWriterSink(os.Stdout), nopCloser{}, nil
+	case SinkFile:
+		file, err := os.OpenFile(cfg.FilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("events.NewSink: %w", err)
+		}
+		return NewWriterSink(file), file, nil
+	case SinkMemory:
+		return NewMemorySink(), nopCloser{}, nil
+	default:
+		return nil, nil, fmt.Errorf("events.NewSink: unknown sink %q", cfg.Sink)
+	}
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
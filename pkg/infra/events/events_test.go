@@ -0,0 +1,169 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingSink struct{}
+
+func (failingSink) Publish(context.Context, Message) error {
+	return errors.New("unavailable")
+}
+
+func testMessage() Message {
+	return Message{
+		ID:        7,
+		Type:      "coins.sent",
+		CreatedAt: time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC),
+		Payload:   json.RawMessage(`{"amount":10}`),
+	}
+}
+
+func TestFanoutSink(t *testing.T) {
+	t.Parallel()
+
+	first, second := NewMemorySink(), NewMemorySink()
+
+	require.NoError(t, NewFanoutSink(first, second).Publish(context.Background(), testMessage()))
+	require.Equal(t, []Message{testMessage()}, first.Messages())
+	require.Equal(t, []Message{testMessage()}, second.Messages())
+
+	// a sink that fails makes the relay retry, the others still get the message
+	delivered := NewMemorySink()
+	err := NewFanoutSink(failingSink{}, delivered).Publish(context.Background(), testMessage())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unavailable")
+	require.Len(t, delivered.Messages(), 1)
+}
+
+func TestWriterSink(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+
+	require.NoError(t, sink.Publish(context.Background(), testMessage()))
+	require.NoError(t, sink.Publish(context.Background(), testMessage()))
+
+	dec := json.NewDecoder(&buf)
+	for range 2 {
+		var msg Message
+		require.NoError(t, dec.Decode(&msg))
+		require.Equal(t, testMessage(), msg)
+	}
+	require.False(t, dec.More())
+}
+
+func TestNewSink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name   string
+		Config Config
+		ExpErr bool
+	}{
+		{"Memory", Config{Sink: SinkMemory}, false},
+		{"Webhook", Config{Sink: SinkWebhook, WebhookURL: "http://localhost/events"}, false},
+		{"Webhook without URL", Config{Sink: SinkWebhook}, true},
+		{"Unknown", Config{Sink: "kafka"}, true},
+		{"Not chosen", Config{}, true},
+	}
+
+	for _, test := range tests {
+		sink, closer, err := NewSink(test.Config)
+		if test.ExpErr {
+			require.Error(t, err, test.Name)
+			continue
+		}
+
+		require.NoError(t, err, test.Name)
+		require.NotNil(t, sink, test.Name)
+		require.NoError(t, closer.Close(), test.Name)
+	}
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"id":1}`)
+	sig := Sign("secret", 1700000000, body)
+
+	// echo -n '1700000000.{"id":1}' | openssl dgst -sha256 -hmac secret
+	require.Equal(t, "sha256=3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11", sig)
+	require.NotEqual(t, sig, Sign("other", 1700000000, body))
+	require.NotEqual(t, sig, Sign("secret", 1700000001, body))
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver answers every request with status and hands it over to the returned channel.
+func newReceiver(t *testing.T, status int) (*httptest.Server, <-chan receivedRequest) {
+	t.Helper()
+
+	received := make(chan receivedRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		received <- receivedRequest{header: r.Header, body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, received
+}
+
+func TestWebhookClient_Post(t *testing.T) {
+	t.Parallel()
+
+	server, received := newReceiver(t, http.StatusNoContent)
+
+	status, err := NewWebhookClient(time.Second).Post(context.Background(), server.URL, "secret", testMessage())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, status)
+
+	req := <-received
+	require.Equal(t, "7", req.header.Get(HeaderEventID))
+	require.Equal(t, "coins.sent", req.header.Get(HeaderEventType))
+
+	timestamp, err := strconv.ParseInt(req.header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, Sign("secret", timestamp, req.body), req.header.Get(HeaderSignature))
+
+	var msg Message
+	require.NoError(t, json.Unmarshal(req.body, &msg))
+	require.Equal(t, testMessage(), msg)
+}
+
+func TestWebhookSink(t *testing.T) {
+	t.Parallel()
+
+	server, received := newReceiver(t, http.StatusOK)
+
+	require.NoError(t, NewWebhookSink(server.URL, time.Second).Publish(context.Background(), testMessage()))
+
+	// the sink has no secret to sign with
+	req := <-received
+	require.Equal(t, "7", req.header.Get(HeaderEventID))
+	require.Empty(t, req.header.Get(HeaderTimestamp))
+	require.Empty(t, req.header.Get(HeaderSignature))
+
+	failing, received := newReceiver(t, http.StatusInternalServerError)
+
+	require.Error(t, NewWebhookSink(failing.URL, time.Second).Publish(context.Background(), testMessage()))
+	<-received
+}
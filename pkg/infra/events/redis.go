@@ -0,0 +1,69 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBroker broadcasts messages to every replica over a Redis pub/sub channel.
+// Pub/sub keeps nothing: a replica only gets the messages published while it listens.
+type RedisBroker struct {
+	client  *redis.Client
+	channel string
+}
+
+func NewRedisBroker(client *redis.Client, channel string) *RedisBroker {
+	return &RedisBroker{
+		client:  client,
+		channel: channel,
+	}
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("RedisBroker.Publish: %w", err)
+	}
+
+	if err = b.client.Publish(ctx, b.channel, body).Err(); err != nil {
+		return fmt.Errorf("RedisBroker.Publish: %w", err)
+	}
+
+	return nil
+}
+
+// Listen calls handle for every message on the channel until ctx is cancelled.
+// The client resubscribes on its own after a lost connection, messages published meanwhile are lost.
+func (b *RedisBroker) Listen(ctx context.Context, handle func(Message)) error {
+	sub := b.client.Subscribe(ctx, b.channel)
+	defer func() { _ = sub.Close() }()
+
+	if _, err := sub.Receive(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("RedisBroker.Listen: %w", err)
+	}
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case m, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			var msg Message
+			if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+				continue
+			}
+
+			handle(msg)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEventID   = "X-Event-Id"
+	HeaderEventType = "X-Event-Type"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// WebhookClient POSTs messages as JSON. Any 2xx answer counts as delivered.
+type WebhookClient struct {
+	client *http.Client
+}
+
+func NewWebhookClient(timeout time.Duration) *WebhookClient {
+	return &WebhookClient{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Post sends msg to url and returns the response status, zero if there was no response.
+// With a secret the request carries a Sign signature of its timestamp and body,
+// so the receiver can check the sender and reject replays.
+func (c *WebhookClient) Post(ctx context.Context, url, secret string, msg Message) (int, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return 0, fmt.Errorf("WebhookClient.Post: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("WebhookClient.Post: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, strconv.FormatInt(msg.ID, 10))
+	req.Header.Set(HeaderEventType, msg.Type)
+
+	if secret != "" {
+		timestamp := time.Now().Unix()
+		req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(HeaderSignature, Sign(secret, timestamp, body))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("WebhookClient.Post: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("WebhookClient.Post: unexpected status %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>".
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookSink publishes every message to a single URL without a signature.
+type WebhookSink struct {
+	client *WebhookClient
+	url    string
+}
+
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		client: NewWebhookClient(timeout),
+		url:    url,
+	}
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, msg Message) error {
+	if _, err := s.client.Post(ctx, s.url, "", msg); err != nil {
+		return fmt.Errorf("WebhookSink.Publish: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// WriterSink writes every message as a JSON line, e.g. to stdout or a file.
+type WriterSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{
+		enc: json.NewEncoder(w),
+	}
+}
+
+func (s *WriterSink) Publish(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.enc.Encode(msg); err != nil {
+		return fmt.Errorf("WriterSink.Publish: %w", err)
+	}
+
+	return nil
+}
@@ -21,7 +21,13 @@ func NewPostgresPool(cfg PostgresConfig) (*pgxpool.Pool, error) {
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName,
 	)
 
-	dbPool, err := pgxpool.New(context.Background(), psqlInfo)
+	poolCfg, err := pgxpool.ParseConfig(psqlInfo)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse postgres config: %w", err)
+	}
+	poolCfg.ConnConfig.Tracer = queryTracer{}
+
+	dbPool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
 	if err != nil {
 		return nil, fmt.Errorf("can't create connection to postgres: %w", err)
 	}
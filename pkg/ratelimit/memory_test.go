@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	l := NewMemoryLimiter()
+	l.now = func() time.Time { return now }
+
+	limit := Limit{Rate: 2, Burst: 3}
+
+	for remaining := 2; remaining >= 0; remaining-- {
+		res, err := l.Allow(context.Background(), "user:1", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		require.Equal(t, remaining, res.Remaining)
+	}
+
+	res, err := l.Allow(context.Background(), "user:1", limit)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Equal(t, 500*time.Millisecond, res.RetryAfter)
+	require.Equal(t, 1500*time.Millisecond, res.Reset)
+
+	// another key has a bucket of its own
+	res, err = l.Allow(context.Background(), "user:2", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	now = now.Add(500 * time.Millisecond)
+
+	res, err = l.Allow(context.Background(), "user:1", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+	require.Equal(t, 0, res.Remaining)
+}
+
+func TestMemoryLimiter_SweepsFullBuckets(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	l := NewMemoryLimiter()
+	l.now = func() time.Time { return now }
+
+	_, err := l.Allow(context.Background(), "ip:10.0.0.1", Limit{Rate: 1, Burst: 10})
+	require.NoError(t, err)
+	_, err = l.Allow(context.Background(), "ip:10.0.0.2", Limit{Rate: 0.001, Burst: 10})
+	require.NoError(t, err)
+
+	now = now.Add(sweepInterval + time.Second)
+
+	_, err = l.Allow(context.Background(), "ip:10.0.0.3", Limit{Rate: 1, Burst: 10})
+	require.NoError(t, err)
+
+	require.NotContains(t, l.buckets, "ip:10.0.0.1")
+	require.Contains(t, l.buckets, "ip:10.0.0.2")
+	require.Contains(t, l.buckets, "ip:10.0.0.3")
+}
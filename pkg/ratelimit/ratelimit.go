@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	ModeMemory = "memory"
+	ModeRedis  = "redis"
+)
+
+// Limit is a token bucket: it holds up to Burst requests and refills at Rate requests per second.
+type Limit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// Config.Routes overrides the default limit for single routes, they are keyed by the chi route
+// pattern with the method, e.g. "GET /api/buy/{item}".
+type Config struct {
+	Enabled bool             `env:"RATE_LIMIT_ENABLED" yaml:"enabled" env-default:"true"`
+	Mode    string           `env:"RATE_LIMIT_MODE" yaml:"mode" env-default:"memory"`
+	Rate    float64          `env:"RATE_LIMIT_RATE" yaml:"rate" env-default:"50"`
+	Burst   int              `env:"RATE_LIMIT_BURST" yaml:"burst" env-default:"100"`
+	Routes  map[string]Limit `yaml:"routes"`
+}
+
+func (c Config) Default() Limit {
+	return Limit{Rate: c.Rate, Burst: c.Burst}
+}
+
+// validate rejects limits that can't refill, a zero rate would put off the retries forever.
+func (c Config) validate() error {
+	if err := c.Default().validate(); err != nil {
+		return err
+	}
+
+	for route, limit := range c.Routes {
+		if err := limit.validate(); err != nil {
+			return fmt.Errorf("route %q: %w", route, err)
+		}
+	}
+
+	return nil
+}
+
+func (l Limit) validate() error {
+	if l.Rate <= 0 || l.Burst <= 0 {
+		return fmt.Errorf("rate and burst must be positive, got rate %v and burst %d", l.Rate, l.Burst)
+	}
+
+	return nil
+}
+
+// Result tells whether a request was let through and how the bucket stands after it.
+// Reset is when the bucket is full again, RetryAfter is when the next request may pass.
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// NewLimiter builds the limiter chosen in cfg. The memory limiter counts every replica on its own,
+// the redis one shares the buckets between the replicas.
+func NewLimiter(cfg Config, client *redis.Client) (Limiter, error) {
+	if cfg.Enabled {
+		if err := cfg.validate(); err != nil {
+			return nil, fmt.Errorf("ratelimit.NewLimiter: %w", err)
+		}
+	}
+
+	switch cfg.Mode {
+	case ModeMemory:
+		return NewMemoryLimiter(), nil
+	case ModeRedis:
+		return NewRedisLimiter(client), nil
+	default:
+		return nil, fmt.Errorf("ratelimit.NewLimiter: unknown mode %q", cfg.Mode)
+	}
+}
+
+// take refills a bucket holding tokens since elapsed and takes a token from it if there is one.
+// The redis limiter runs the same in its script.
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, Result) {
+	tokens = math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate)
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	return tokens, newResult(allowed, tokens, limit)
+}
+
+// newResult describes a bucket left with tokens after a request.
+func newResult(allowed bool, tokens float64, limit Limit) Result {
+	res := Result{
+		Allowed:   allowed,
+		Remaining: int(tokens),
+		Reset:     seconds((float64(limit.Burst) - tokens) / limit.Rate),
+	}
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLimiter(t *testing.T) {
+	t.Parallel()
+
+	valid := Config{Enabled: true, Mode: ModeMemory, Rate: 50, Burst: 100}
+
+	tests := []struct {
+		Name   string
+		Config func(cfg Config) Config
+		ExpErr bool
+	}{
+		{"Valid", func(cfg Config) Config { return cfg }, false},
+		{"Zero Rate", func(cfg Config) Config { cfg.Rate = 0; return cfg }, true},
+		{"Negative Burst", func(cfg Config) Config { cfg.Burst = -1; return cfg }, true},
+		{"Route With Zero Rate", func(cfg Config) Config {
+			cfg.Routes = map[string]Limit{"POST /api/buy/{item}": {Rate: 0, Burst: 5}}
+			return cfg
+		}, true},
+		{"Disabled", func(cfg Config) Config { cfg.Enabled = false; cfg.Rate = 0; return cfg }, false},
+		{"Unknown Mode", func(cfg Config) Config { cfg.Mode = "file"; return cfg }, true},
+	}
+
+	for _, test := range tests {
+		_, err := NewLimiter(test.Config(valid), nil)
+
+		if test.ExpErr {
+			require.Error(t, err, test.Name)
+		} else {
+			require.NoError(t, err, test.Name)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	// two replicas share the bucket
+	replicas := []*RedisLimiter{NewRedisLimiter(client), NewRedisLimiter(client)}
+	limit := Limit{Rate: 0.5, Burst: 2}
+
+	for i, want := range []struct {
+		allowed   bool
+		remaining int
+	}{{true, 1}, {true, 0}, {false, 0}} {
+		res, err := replicas[i%2].Allow(context.Background(), "user:1", limit)
+		require.NoError(t, err)
+		require.Equal(t, want.allowed, res.Allowed)
+		require.Equal(t, want.remaining, res.Remaining)
+	}
+
+	res, err := replicas[0].Allow(context.Background(), "user:2", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+	require.Equal(t, 1, res.Remaining)
+
+	// the bucket expires once it would be full again
+	require.Greater(t, server.TTL(keyPrefix+"user:1"), 3*time.Second)
+	require.LessOrEqual(t, server.TTL(keyPrefix+"user:1"), 5*time.Second)
+}
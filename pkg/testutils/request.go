@@ -27,13 +27,22 @@ func NewMockJSONRequest(t *testing.T, payload interface{}) *http.Request {
 const GetBuyItemRequestQueryParam = "item"
 
 func NewMockRequestWithItemQueryVal(itemName string) *http.Request {
-	req := httptest.NewRequest("", "/", nil)
-	chiCtx := chi.NewRouteContext()
+	return NewMockRequestWithURLParam(GetBuyItemRequestQueryParam, itemName)
+}
 
-	chiCtx.URLParams.Add(GetBuyItemRequestQueryParam, itemName)
+func NewMockRequestWithURLParam(key, val string) *http.Request {
+	return AddURLParamToRequest(httptest.NewRequest("", "/", nil), key, val)
+}
 
-	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, chiCtx))
-	return req
+func AddURLParamToRequest(r *http.Request, key, val string) *http.Request {
+	chiCtx, ok := r.Context().Value(chi.RouteCtxKey).(*chi.Context)
+	if !ok {
+		chiCtx = chi.NewRouteContext()
+	}
+
+	chiCtx.URLParams.Add(key, val)
+
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
 }
 
 func AddUserIDToRequestContext(r *http.Request, id domain.UserID) *http.Request {
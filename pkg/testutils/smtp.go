@@ -0,0 +1,143 @@
+package testutils
+
+import (
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// SMTPMessage is a message accepted by SMTPServer, Data is the raw message after DATA.
+type SMTPMessage struct {
+	From string
+	To   []string
+	Data string
+}
+
+// SMTPServer is a local stand-in for an SMTP server. It speaks just enough of the protocol
+// for net/smtp without TLS and auth, and rejects every message while Fail is set.
+type SMTPServer struct {
+	listener net.Listener
+	host     string
+	port     int
+
+	mu       sync.Mutex
+	messages []SMTPMessage
+	fail     bool
+}
+
+func NewSMTPServer(t *testing.T) *SMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+
+	s := &SMTPServer{listener: listener, host: host}
+	s.port, err = strconv.Atoi(port)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, acceptErr := listener.Accept()
+			if acceptErr != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *SMTPServer) Host() string {
+	return s.host
+}
+
+func (s *SMTPServer) Port() int {
+	return s.port
+}
+
+func (s *SMTPServer) SetFail(fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fail = fail
+}
+
+func (s *SMTPServer) Messages() []SMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SMTPMessage(nil), s.messages...)
+}
+
+func (s *SMTPServer) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	tp := textproto.NewConn(conn)
+	reply := func(code int, msg string) bool {
+		return tp.PrintfLine("%s %s", strconv.Itoa(code), msg) == nil
+	}
+
+	if !reply(220, "localhost ESMTP test") {
+		return
+	}
+
+	var msg SMTPMessage
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(cmd) {
+		case "EHLO", "HELO":
+			reply(250, "localhost")
+		case "MAIL":
+			msg = SMTPMessage{From: trimAddress(arg)}
+			reply(250, "OK")
+		case "RCPT":
+			msg.To = append(msg.To, trimAddress(arg))
+			reply(250, "OK")
+		case "DATA":
+			reply(354, "go ahead")
+
+			data, readErr := tp.ReadDotLines()
+			if readErr != nil {
+				return
+			}
+			msg.Data = strings.Join(data, "\r\n")
+
+			s.mu.Lock()
+			fail := s.fail
+			if !fail {
+				s.messages = append(s.messages, msg)
+			}
+			s.mu.Unlock()
+
+			if fail {
+				reply(554, "rejected")
+			} else {
+				reply(250, "queued")
+			}
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(250, "OK")
+		}
+	}
+}
+
+func trimAddress(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	return strings.Trim(addr, "<> ")
+}
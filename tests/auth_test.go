@@ -70,11 +70,16 @@ func TestPostAuthHandler_InvalidPassword(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expStatus, resp.StatusCode)
 
+	require.Equal(t, pkgresp.ProblemContentType, resp.Header.Get("Content-Type"))
+
 	var errPayload pkgresp.ErrorResponse
 	err = json.NewDecoder(resp.Body).Decode(&errPayload)
 	require.NoError(t, err)
 
 	require.Equal(t, errPayload.Message, domain.ErrUnauthorized.Error())
+	require.Equal(t, "UNAUTHORIZED", errPayload.Code)
+	require.Equal(t, expStatus, errPayload.Status)
+	require.NotEmpty(t, errPayload.RequestID)
 }
 
 func TestPostAuthHandler_BadRequestCases(t *testing.T) {
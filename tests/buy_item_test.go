@@ -44,7 +44,7 @@ func TestGetBuyItem_BadRequest(t *testing.T) {
 	}
 	token := getTokenHelper(t, userCreds)
 
-	expStatus := http.StatusBadRequest
+	expStatus := http.StatusNotFound
 	req := types.GetBuyItemRequest{Item: "spaceship"}
 
 	resp := buyItemHelper(t, req, token)
@@ -77,13 +77,13 @@ func TestGetBuyItem_UnauthorizedCases(t *testing.T) {
 
 func TestGetBuyItem_MoneyEnded(t *testing.T) {
 	// By the task, default amount of coins of new user is 1000
-	// So we will buy 3 pink-hoody (price 500). Two Ok's and 1 bad request expected
+	// So we will buy 3 pink-hoody (price 500). Two Ok's and 1 low balance expected
 
 	userCreds := types.PostAuthRequest{Username: "AvitoBrokeBuyer", Password: "12345"}
 	token := getTokenHelper(t, userCreds)
 
 	req := types.GetBuyItemRequest{Item: "pink-hoody"}
-	expStatuses := []int{http.StatusOK, http.StatusOK, http.StatusBadRequest}
+	expStatuses := []int{http.StatusOK, http.StatusOK, http.StatusUnprocessableEntity}
 
 	for _, exp := range expStatuses {
 		resp := buyItemHelper(t, req, token)
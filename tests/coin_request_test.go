@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"avito_shop/internal/api/http/types"
+	"avito_shop/internal/domain"
+	"avito_shop/pkg/testutils"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const coinRequestsPath = "/coinRequests"
+
+func createCoinRequestHelper(t *testing.T, req types.PostCoinRequestRequest, token string) *http.Response {
+	path := fmt.Sprintf("%s%s", apiPath, coinRequestsPath)
+
+	resp, err := testutils.SendRequest(t, path, http.MethodPost, token, &req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+func resolveCoinRequestHelper(t *testing.T, id domain.CoinRequestID, action, token string) *http.Response {
+	path := fmt.Sprintf("%s%s/%d/%s", apiPath, coinRequestsPath, id, action)
+
+	resp, err := testutils.SendRequest(t, path, http.MethodPost, token, nil)
+	require.NoError(t, err)
+
+	return resp
+}
+
+func TestCoinRequest_AcceptFlow(t *testing.T) {
+	requester := types.PostAuthRequest{
+		Username: "AvitoCoinRequester",
+		Password: "12345",
+	}
+	payer := types.PostAuthRequest{
+		Username: "AvitoCoinPayer",
+		Password: "12345",
+	}
+
+	reqToken := getTokenHelper(t, requester)
+	payerToken := getTokenHelper(t, payer)
+
+	resp := createCoinRequestHelper(t, types.PostCoinRequestRequest{FromUser: payer.Username, Amount: 10}, reqToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var created types.CoinRequest
+	err := json.NewDecoder(resp.Body).Decode(&created)
+	require.NoError(t, err)
+	require.Equal(t, domain.CoinRequestPending, created.Status)
+
+	resp = resolveCoinRequestHelper(t, created.ID, "accept", reqToken)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = resolveCoinRequestHelper(t, created.ID, "accept", payerToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = resolveCoinRequestHelper(t, created.ID, "decline", payerToken)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCoinRequest_SelfRequesting(t *testing.T) {
+	requester := types.PostAuthRequest{
+		Username: "AvitoCoinRequester",
+		Password: "12345",
+	}
+	token := getTokenHelper(t, requester)
+
+	resp := createCoinRequestHelper(t, types.PostCoinRequestRequest{FromUser: requester.Username, Amount: 10}, token)
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
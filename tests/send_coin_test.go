@@ -105,7 +105,7 @@ func TestPostSendCoin_UnauthorizedCases(t *testing.T) {
 
 func TestPostSendCoin_MoneyEnded(t *testing.T) {
 	// By the task, default amount of coins of new user is 1000
-	// So we will try to send 1000 coins two times. First OK and second bad request expected
+	// So we will try to send 1000 coins two times. First OK and second low balance expected
 
 	sender := types.PostAuthRequest{
 		Username: "AvitoBrokeSender",
@@ -120,7 +120,7 @@ func TestPostSendCoin_MoneyEnded(t *testing.T) {
 	_ = getTokenHelper(t, receiver) // need to create receiver
 
 	req := types.PostSendCoinRequest{ToUser: receiver.Username, Amount: 1000}
-	expStatuses := []int{http.StatusOK, http.StatusBadRequest}
+	expStatuses := []int{http.StatusOK, http.StatusUnprocessableEntity}
 
 	for _, exp := range expStatuses {
 		resp := sendCoinHelper(t, req, token)
@@ -59,7 +59,7 @@ func TestGetInfo_DeletedUserRequest(t *testing.T) {
 	authToken, err := authService.GenerateToken(delUser)
 	require.NoError(t, err)
 
-	expStatus := http.StatusBadRequest
+	expStatus := http.StatusNotFound
 
 	resp := userInfoHelper(t, authToken)
 